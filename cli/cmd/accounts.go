@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
 	"io"
 	"math/big"
 	"os"
+	"sort"
+	"strconv"
 
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -25,6 +29,7 @@ import (
 	"github.com/oasisprotocol/oasis-sdk/cli/cmd/common"
 	cliConfig "github.com/oasisprotocol/oasis-sdk/cli/config"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
@@ -32,10 +37,28 @@ import (
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 )
 
+// defaultHistoryRounds is the number of most recent rounds covered by the account history when no
+// start round is given.
+const defaultHistoryRounds = 1000
+
 var (
 	commissionScheduleRates  []string
 	commissionScheduleBounds []string
 
+	historyStartRound uint64
+	historyEndRound   uint64
+	historyCSVFile    string
+
+	accountHistoryHeader = []string{
+		"Round",
+		"Kind",
+		"Counterparty",
+		"Tx Hash",
+		"Gas Used",
+		"Change",
+		"Balance",
+	}
+
 	accountsCmd = &cobra.Command{
 		Use:   "accounts",
 		Short: "Account operations",
@@ -69,7 +92,7 @@ var (
 			c, err := connection.Connect(ctx, npa.Network)
 			cobra.CheckErr(err)
 
-			addr, err := helpers.ResolveAddress(npa.Network, targetAddress)
+			addr, err := common.ResolveLocalAccountOrAddress(npa.Network, targetAddress)
 			cobra.CheckErr(err)
 
 			height, err := common.GetActualHeight(
@@ -84,86 +107,26 @@ var (
 			}
 
 			// Query consensus layer account.
-			// TODO: Nicer overall formatting.
-
-			consensusAccount, err := c.Consensus().Staking().Account(ctx, ownerQuery)
+			consensusAccount, err := c.Staking().AccountOf(ctx, height, *addr)
 			cobra.CheckErr(err)
-
-			fmt.Printf("Address: %s\n", addr)
-			fmt.Printf("Nonce: %d\n", consensusAccount.General.Nonce)
-			fmt.Println()
-			fmt.Printf("=== CONSENSUS LAYER (%s) ===\n", npa.NetworkName)
-
-			outgoingDelegations, err := c.Consensus().Staking().DelegationInfosFor(ctx, ownerQuery)
+			outgoingDelegations, err := c.Staking().DelegationInfosFor(ctx, ownerQuery)
 			cobra.CheckErr(err)
-			outgoingDebondingDelegations, err := c.Consensus().Staking().DebondingDelegationInfosFor(ctx, ownerQuery)
+			outgoingDebondingDelegations, err := c.Staking().DebondingDelegationInfosFor(ctx, ownerQuery)
 			cobra.CheckErr(err)
-
-			helpers.PrettyPrintAccountBalanceAndDelegationsFrom(
-				npa.Network,
-				addr,
-				consensusAccount.General,
-				outgoingDelegations,
-				outgoingDebondingDelegations,
-				"  ",
-				os.Stdout,
-			)
-			fmt.Println()
-
-			if len(consensusAccount.General.Allowances) > 0 {
-				fmt.Println("  Allowances for this Account:")
-				helpers.PrettyPrintAllowances(
-					npa.Network,
-					addr,
-					consensusAccount.General.Allowances,
-					"    ",
-					os.Stdout,
-				)
-				fmt.Println()
-			}
-
-			incomingDelegations, err := c.Consensus().Staking().DelegationsTo(ctx, ownerQuery)
+			incomingDelegations, err := c.Staking().DelegationsTo(ctx, ownerQuery)
 			cobra.CheckErr(err)
-			incomingDebondingDelegations, err := c.Consensus().Staking().DebondingDelegationsTo(ctx, ownerQuery)
+			incomingDebondingDelegations, err := c.Staking().DebondingDelegationsTo(ctx, ownerQuery)
 			cobra.CheckErr(err)
 
-			if len(incomingDelegations) > 0 {
-				fmt.Println("  Active Delegations to this Account:")
-				helpers.PrettyPrintDelegationsTo(
-					npa.Network,
-					addr,
-					consensusAccount.Escrow.Active,
-					incomingDelegations,
-					"    ",
-					os.Stdout,
-				)
-				fmt.Println()
-			}
-			if len(incomingDebondingDelegations) > 0 {
-				fmt.Println("  Debonding Delegations to this Account:")
-				helpers.PrettyPrintDelegationsTo(
-					npa.Network,
-					addr,
-					consensusAccount.Escrow.Debonding,
-					incomingDebondingDelegations,
-					"    ",
-					os.Stdout,
-				)
-				fmt.Println()
-			}
-
-			cs := consensusAccount.Escrow.CommissionSchedule
-			if len(cs.Rates) > 0 || len(cs.Bounds) > 0 {
-				fmt.Println("  Commission Schedule:")
-				cs.PrettyPrint(ctx, "    ", os.Stdout)
-				fmt.Println()
-			}
-
-			sa := consensusAccount.Escrow.StakeAccumulator
-			if len(sa.Claims) > 0 {
-				fmt.Println("  Stake Accumulator:")
-				sa.PrettyPrint(ctx, "    ", os.Stdout)
-				fmt.Println()
+			out := accountShowOutput{
+				Address:                      *addr,
+				Network:                      npa.NetworkName,
+				Height:                       height,
+				ConsensusAccount:             consensusAccount,
+				OutgoingDelegations:          outgoingDelegations,
+				OutgoingDebondingDelegations: outgoingDebondingDelegations,
+				IncomingDelegations:          incomingDelegations,
+				IncomingDebondingDelegations: incomingDebondingDelegations,
 			}
 
 			if npa.ParaTime != nil {
@@ -172,7 +135,7 @@ var (
 				// Note: Public gRPC endpoints do not allow this method.
 				round := client.RoundLatest
 				if h := common.GetHeight(); h != consensus.HeightLatest {
-					blk, err := c.Consensus().RootHash().GetLatestBlock(
+					blk, err := c.RootHash().GetLatestBlock(
 						ctx,
 						&roothash.RuntimeRequest{
 							RuntimeID: npa.ParaTime.Namespace(),
@@ -187,8 +150,81 @@ var (
 				rtBalances, err := c.Runtime(npa.ParaTime).Accounts.Balances(ctx, round, *addr)
 				cobra.CheckErr(err)
 
+				out.ParaTime = npa.ParaTimeName
+				out.ParaTimeBalances = rtBalances.Balances
+			}
+
+			common.Output(&out, func() {
+				// TODO: Nicer overall formatting.
+				fmt.Printf("Address: %s\n", addr)
+				fmt.Printf("Nonce: %d\n", consensusAccount.General.Nonce)
+				fmt.Println()
+				fmt.Printf("=== CONSENSUS LAYER (%s) ===\n", npa.NetworkName)
+
+				helpers.PrettyPrintAccountBalanceAndDelegationsFrom(
+					npa.Network,
+					addr,
+					consensusAccount.General,
+					outgoingDelegations,
+					outgoingDebondingDelegations,
+					"  ",
+					os.Stdout,
+				)
+				fmt.Println()
+
+				if len(consensusAccount.General.Allowances) > 0 {
+					fmt.Println("  Allowances for this Account:")
+					helpers.PrettyPrintAllowances(
+						npa.Network,
+						addr,
+						consensusAccount.General.Allowances,
+						"    ",
+						os.Stdout,
+					)
+					fmt.Println()
+				}
+
+				if len(incomingDelegations) > 0 {
+					fmt.Println("  Active Delegations to this Account:")
+					helpers.PrettyPrintDelegationsTo(
+						npa.Network,
+						addr,
+						consensusAccount.Escrow.Active,
+						incomingDelegations,
+						"    ",
+						os.Stdout,
+					)
+					fmt.Println()
+				}
+				if len(incomingDebondingDelegations) > 0 {
+					fmt.Println("  Debonding Delegations to this Account:")
+					helpers.PrettyPrintDelegationsTo(
+						npa.Network,
+						addr,
+						consensusAccount.Escrow.Debonding,
+						incomingDebondingDelegations,
+						"    ",
+						os.Stdout,
+					)
+					fmt.Println()
+				}
+
+				cs := consensusAccount.Escrow.CommissionSchedule
+				if len(cs.Rates) > 0 || len(cs.Bounds) > 0 {
+					fmt.Println("  Commission Schedule:")
+					cs.PrettyPrint(ctx, "    ", os.Stdout)
+					fmt.Println()
+				}
+
+				sa := consensusAccount.Escrow.StakeAccumulator
+				if len(sa.Claims) > 0 {
+					fmt.Println("  Stake Accumulator:")
+					sa.PrettyPrint(ctx, "    ", os.Stdout)
+					fmt.Println()
+				}
+
 				var hasNonZeroBalance bool
-				for _, balance := range rtBalances.Balances {
+				for _, balance := range out.ParaTimeBalances {
 					if hasNonZeroBalance = !balance.IsZero(); hasNonZeroBalance {
 						break
 					}
@@ -198,11 +234,125 @@ var (
 					fmt.Printf("=== %s PARATIME ===\n", npa.ParaTimeName)
 
 					fmt.Printf("Balances for all denominations:\n")
-					for denom, balance := range rtBalances.Balances {
+					for denom, balance := range out.ParaTimeBalances {
 						fmt.Printf("  %s\n", helpers.FormatParaTimeDenomination(npa.ParaTime, types.NewBaseUnits(balance, denom)))
 					}
 				}
+			})
+		},
+	}
+
+	accountsHistoryCmd = &cobra.Command{
+		Use:   "history [address]",
+		Short: "Show the ParaTime balance history of an account",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no paratime selected")
+			}
+
+			// Determine which address to show. If an explicit argument was given, use that
+			// otherwise use the default account.
+			var targetAddress string
+			switch {
+			case len(args) >= 1:
+				targetAddress = args[0]
+			case npa.Account != nil:
+				targetAddress = npa.Account.Address
+			default:
+				cobra.CheckErr("no address given and no wallet configured")
+			}
+			addr, err := common.ResolveLocalAccountOrAddress(npa.Network, targetAddress)
+			cobra.CheckErr(err)
+
+			// Establish connection with the target network.
+			ctx := context.Background()
+			c, err := connection.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+			rc := c.Runtime(npa.ParaTime)
+
+			endRound := historyEndRound
+			if endRound == 0 {
+				blk, err := rc.GetBlock(ctx, client.RoundLatest)
+				cobra.CheckErr(err)
+				endRound = blk.Header.Round
+			}
+			startRound := historyStartRound
+			if !cmd.Flags().Changed("start-round") {
+				// Gathering history requires queries for every round, so only cover recent
+				// rounds unless requested otherwise.
+				startRound = 0
+				if endRound >= defaultHistoryRounds {
+					startRound = endRound - defaultHistoryRounds + 1
+				}
 			}
+			if startRound > endRound {
+				cobra.CheckErr(fmt.Errorf("start round %d is after end round %d", startRound, endRound))
+			}
+
+			common.Infof("gathering history: address: %s, start-round: %d, end-round: %d\n", addr, startRound, endRound)
+			st, err := accounts.GenerateStatement(ctx, rc, *addr, startRound, endRound)
+			cobra.CheckErr(err)
+
+			lines := accountHistoryOutput(npa.ParaTime, st)
+			mismatches := st.Mismatches()
+
+			common.Output(st, func() {
+				fmt.Printf("Address:     %s\n", st.Address)
+				fmt.Printf("Rounds:      %d-%d\n", st.StartRound, st.EndRound)
+				fmt.Println()
+
+				fmt.Println("Opening balances:")
+				var openingDenoms []types.Denomination
+				for denom := range st.Opening {
+					openingDenoms = append(openingDenoms, denom)
+				}
+				for _, denom := range sortDenominations(openingDenoms) {
+					fmt.Printf("  %s\n", helpers.FormatParaTimeDenomination(npa.ParaTime, types.NewBaseUnits(st.Opening[denom], denom)))
+				}
+				fmt.Println()
+
+				table := tablewriter.NewWriter(os.Stdout)
+				table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+				table.SetCenterSeparator("|")
+				table.SetHeader(accountHistoryHeader)
+				table.AppendBulk(lines)
+				table.Render()
+				fmt.Println()
+
+				fmt.Println("Closing balances:")
+				var closingDenoms []types.Denomination
+				for denom := range st.Closing {
+					closingDenoms = append(closingDenoms, denom)
+				}
+				for _, denom := range sortDenominations(closingDenoms) {
+					fmt.Printf("  %s\n", formatSignedAmount(npa.ParaTime, st.Closing[denom], denom))
+				}
+			})
+
+			for _, denom := range mismatches {
+				actual := st.Actual[denom]
+				common.Infof(
+					"\nWarning: computed balance %s does not match actual balance %s. Some balance changes may not emit events (e.g. fee disbursements).\n",
+					formatSignedAmount(npa.ParaTime, st.Closing[denom], denom),
+					helpers.FormatParaTimeDenomination(npa.ParaTime, types.NewBaseUnits(actual, denom)),
+				)
+			}
+
+			if historyCSVFile == "" {
+				return
+			}
+			fout, err := os.Create(historyCSVFile)
+			cobra.CheckErr(err)
+			defer fout.Close()
+
+			w := csv.NewWriter(fout)
+			err = w.Write(accountHistoryHeader)
+			cobra.CheckErr(err)
+			err = w.WriteAll(lines)
+			cobra.CheckErr(err)
 		},
 	}
 
@@ -230,7 +380,7 @@ var (
 			}
 
 			// Resolve beneficiary address.
-			benAddr, err := helpers.ResolveAddress(npa.Network, beneficiary)
+			benAddr, err := common.ResolveLocalAccountOrAddress(npa.Network, beneficiary)
 			cobra.CheckErr(err)
 
 			// Parse amount.
@@ -253,7 +403,7 @@ var (
 			sigTx, err := common.SignConsensusTransaction(ctx, npa, acc, conn, tx)
 			cobra.CheckErr(err)
 
-			common.BroadcastTransaction(ctx, npa.ParaTime, conn, sigTx, nil, nil)
+			common.BroadcastTransaction(ctx, npa, conn, sigTx, nil, nil)
 		},
 	}
 
@@ -291,15 +441,12 @@ var (
 			var toAddr *types.Address
 			if to != "" {
 				var err error
-				toAddr, err = helpers.ResolveAddress(npa.Network, to)
+				toAddr, err = common.ResolveLocalAccountOrAddress(npa.Network, to)
 				cobra.CheckErr(err)
 			}
 
 			// Parse amount.
-			// TODO: This should actually query the ParaTime (or config) to check what the consensus
-			//       layer denomination is in the ParaTime. Assume NATIVE for now.
-			amountBaseUnits, err := helpers.ParseParaTimeDenomination(npa.ParaTime, amount, types.NativeDenomination)
-			cobra.CheckErr(err)
+			amountBaseUnits := parseConsensusAccountsAmount(ctx, npa, conn, amount)
 
 			// Prepare transaction.
 			tx := consensusaccounts.NewDepositTx(nil, &consensusaccounts.Deposit{
@@ -311,42 +458,24 @@ var (
 			sigTx, meta, err := common.SignParaTimeTransaction(ctx, npa, acc, conn, tx)
 			cobra.CheckErr(err)
 
-			if txCfg.Offline {
-				common.PrintSignedTransaction(sigTx)
+			if txCfg.Export {
+				common.ExportTransaction(npa, sigTx)
 				return
 			}
 
-			decoder := conn.Runtime(npa.ParaTime).ConsensusAccounts
-			waitCh := common.WaitForEvent(ctx, npa.ParaTime, conn, decoder, func(ev client.DecodedEvent) interface{} {
-				ce, ok := ev.(*consensusaccounts.Event)
-				if !ok || ce.Deposit == nil {
-					return nil
-				}
-				if !ce.Deposit.From.Equal(acc.Address()) || ce.Deposit.Nonce != tx.AuthInfo.SignerInfo[0].Nonce {
-					return nil
-				}
-				return ce.Deposit
-			})
-
-			common.BroadcastTransaction(ctx, npa.ParaTime, conn, sigTx, meta, nil)
+			// Start tracking the deposit before submitting it so that no events are missed.
+			tracker := consensusaccounts.NewTracker(conn.Runtime(npa.ParaTime), conn.Staking())
+			op, err := tracker.Watch(ctx, consensusaccounts.OperationDeposit, acc.Address(), tx.AuthInfo.SignerInfo[0].Nonce)
+			cobra.CheckErr(err)
+			defer op.Stop()
 
-			fmt.Printf("Waiting for deposit result...\n")
+			common.BroadcastTransaction(ctx, npa, conn, sigTx, meta, nil)
 
-			ev := <-waitCh
-			if ev == nil {
-				cobra.CheckErr("Failed to wait for event.")
-			}
+			common.Infof("Waiting for deposit result...\n")
 
-			// Check for result.
-			switch we := ev.(*consensusaccounts.DepositEvent); we.IsSuccess() {
-			case true:
-				fmt.Printf("Deposit succeeded.\n")
-			case false:
-				cobra.CheckErr(fmt.Errorf("deposit failed with error code %d from module %s",
-					we.Error.Code,
-					we.Error.Module,
-				))
-			}
+			result, err := op.Result(ctx)
+			cobra.CheckErr(err)
+			reportConsensusAccountsResult(npa, result)
 		},
 	}
 
@@ -384,7 +513,7 @@ var (
 			var toAddr *types.Address
 			if to != "" {
 				var err error
-				toAddr, err = helpers.ResolveAddress(npa.Network, to)
+				toAddr, err = common.ResolveLocalAccountOrAddress(npa.Network, to)
 				cobra.CheckErr(err)
 			}
 
@@ -396,10 +525,7 @@ var (
 			}
 
 			// Parse amount.
-			// TODO: This should actually query the ParaTime (or config) to check what the consensus
-			//       layer denomination is in the ParaTime. Assume NATIVE for now.
-			amountBaseUnits, err := helpers.ParseParaTimeDenomination(npa.ParaTime, amount, types.NativeDenomination)
-			cobra.CheckErr(err)
+			amountBaseUnits := parseConsensusAccountsAmount(ctx, npa, conn, amount)
 
 			// Prepare transaction.
 			tx := consensusaccounts.NewWithdrawTx(nil, &consensusaccounts.Withdraw{
@@ -411,43 +537,24 @@ var (
 			sigTx, meta, err := common.SignParaTimeTransaction(ctx, npa, acc, conn, tx)
 			cobra.CheckErr(err)
 
-			if txCfg.Offline {
-				common.PrintSignedTransaction(sigTx)
+			if txCfg.Export {
+				common.ExportTransaction(npa, sigTx)
 				return
 			}
 
-			decoder := conn.Runtime(npa.ParaTime).ConsensusAccounts
-			waitCh := common.WaitForEvent(ctx, npa.ParaTime, conn, decoder, func(ev client.DecodedEvent) interface{} {
-				ce, ok := ev.(*consensusaccounts.Event)
-				if !ok || ce.Withdraw == nil {
-					return nil
-				}
-				if !ce.Withdraw.From.Equal(acc.Address()) || ce.Withdraw.Nonce != tx.AuthInfo.SignerInfo[0].Nonce {
-					return nil
-				}
-				return ce.Withdraw
-			})
-
-			common.BroadcastTransaction(ctx, npa.ParaTime, conn, sigTx, meta, nil)
+			// Start tracking the withdraw before submitting it so that no events are missed.
+			tracker := consensusaccounts.NewTracker(conn.Runtime(npa.ParaTime), conn.Staking())
+			op, err := tracker.Watch(ctx, consensusaccounts.OperationWithdraw, acc.Address(), tx.AuthInfo.SignerInfo[0].Nonce)
+			cobra.CheckErr(err)
+			defer op.Stop()
 
-			fmt.Printf("Waiting for withdraw result...\n")
+			common.BroadcastTransaction(ctx, npa, conn, sigTx, meta, nil)
 
-			ev := <-waitCh
-			if ev == nil {
-				cobra.CheckErr("Failed to wait for event.")
-			}
-			we := ev.(*consensusaccounts.WithdrawEvent)
+			common.Infof("Waiting for withdraw result...\n")
 
-			// Check for result.
-			switch we.IsSuccess() {
-			case true:
-				fmt.Printf("Withdraw succeeded.\n")
-			case false:
-				cobra.CheckErr(fmt.Errorf("withdraw failed with error code %d from module %s",
-					we.Error.Code,
-					we.Error.Module,
-				))
-			}
+			result, err := op.Result(ctx)
+			cobra.CheckErr(err)
+			reportConsensusAccountsResult(npa, result)
 		},
 	}
 
@@ -475,7 +582,7 @@ var (
 			}
 
 			// Resolve destination address.
-			toAddr, err := helpers.ResolveAddress(npa.Network, to)
+			toAddr, err := common.ResolveLocalAccountOrAddress(npa.Network, to)
 			cobra.CheckErr(err)
 
 			acc := common.LoadAccount(cfg, npa.AccountName)
@@ -512,7 +619,7 @@ var (
 				cobra.CheckErr(err)
 			}
 
-			common.BroadcastTransaction(ctx, npa.ParaTime, conn, sigTx, meta, nil)
+			common.BroadcastTransaction(ctx, npa, conn, sigTx, meta, nil)
 		},
 	}
 
@@ -557,7 +664,7 @@ var (
 			sigTx, err := common.SignConsensusTransaction(ctx, npa, acc, conn, tx)
 			cobra.CheckErr(err)
 
-			common.BroadcastTransaction(ctx, npa.ParaTime, conn, sigTx, nil, nil)
+			common.BroadcastTransaction(ctx, npa, conn, sigTx, nil, nil)
 		},
 	}
 
@@ -585,7 +692,7 @@ var (
 			}
 
 			// Resolve destination address.
-			toAddr, err := helpers.ResolveAddress(npa.Network, to)
+			toAddr, err := common.ResolveLocalAccountOrAddress(npa.Network, to)
 			cobra.CheckErr(err)
 
 			acc := common.LoadAccount(cfg, npa.AccountName)
@@ -610,7 +717,7 @@ var (
 				cobra.CheckErr("delegations within paratimes are not supported; use --no-paratime")
 			}
 
-			common.BroadcastTransaction(ctx, npa.ParaTime, conn, sigTx, nil, nil)
+			common.BroadcastTransaction(ctx, npa, conn, sigTx, nil, nil)
 		},
 	}
 
@@ -638,7 +745,7 @@ var (
 			}
 
 			// Resolve destination address.
-			fromAddr, err := helpers.ResolveAddress(npa.Network, from)
+			fromAddr, err := common.ResolveLocalAccountOrAddress(npa.Network, from)
 			cobra.CheckErr(err)
 
 			acc := common.LoadAccount(cfg, npa.AccountName)
@@ -664,7 +771,7 @@ var (
 				cobra.CheckErr("delegations within paratimes are not supported; use --no-paratime")
 			}
 
-			common.BroadcastTransaction(ctx, npa.ParaTime, conn, sigTx, nil, nil)
+			common.BroadcastTransaction(ctx, npa, conn, sigTx, nil, nil)
 		},
 	}
 
@@ -705,13 +812,13 @@ var (
 				)
 				cobra.CheckErr(err)
 
-				now, err = conn.Consensus().Beacon().GetEpoch(ctx, height)
+				now, err = conn.Beacon().GetEpoch(ctx, height)
 				cobra.CheckErr(err)
 
 				addr, err := helpers.ResolveAddress(npa.Network, npa.Account.Address)
 				cobra.CheckErr(err)
 
-				stakingConn := conn.Consensus().Staking()
+				stakingConn := conn.Staking()
 
 				params, err := stakingConn.ConsensusParameters(ctx, height)
 				cobra.CheckErr(err)
@@ -763,7 +870,7 @@ var (
 			sigTx, err := common.SignConsensusTransaction(ctx, npa, acc, conn, tx)
 			cobra.CheckErr(err)
 
-			common.BroadcastTransaction(ctx, npa.ParaTime, conn, sigTx, nil, nil)
+			common.BroadcastTransaction(ctx, npa, conn, sigTx, nil, nil)
 		},
 	}
 
@@ -776,7 +883,10 @@ var (
 			err := pk.UnmarshalText([]byte(args[0]))
 			cobra.CheckErr(err)
 
-			fmt.Println(staking.NewAddress(pk))
+			addr := staking.NewAddress(pk)
+			common.Output(addr, func() {
+				fmt.Println(addr)
+			})
 		},
 	}
 
@@ -911,10 +1021,147 @@ func scanBoundStep(
 	return nil
 }
 
+// parseConsensusAccountsAmount parses a deposit or withdrawal amount of the consensus layer
+// denomination in the selected ParaTime.
+//
+// When online, the consensus denomination and scaling factor are queried from the ParaTime and
+// amounts that cannot be exactly represented on the consensus layer are rejected. When offline,
+// the native denomination is assumed.
+func parseConsensusAccountsAmount(ctx context.Context, npa *common.NPASelection, conn connection.Connection, amount string) *types.BaseUnits {
+	if conn == nil {
+		amountBaseUnits, err := helpers.ParseParaTimeDenomination(npa.ParaTime, amount, types.NativeDenomination)
+		cobra.CheckErr(err)
+		return amountBaseUnits
+	}
+
+	params, err := conn.Runtime(npa.ParaTime).Consensus.Parameters(ctx, client.RoundLatest)
+	cobra.CheckErr(err)
+	amountBaseUnits, consensusAmount, err := helpers.ParseParaTimeConsensusAmount(npa.ParaTime, params, amount)
+	cobra.CheckErr(err)
+	common.Infof("Consensus amount: %s\n", helpers.FormatConsensusDenomination(npa.Network, *consensusAmount))
+	return amountBaseUnits
+}
+
+// reportConsensusAccountsResult reports the final result of a deposit or withdrawal.
+func reportConsensusAccountsResult(npa *common.NPASelection, result *consensusaccounts.OperationResult) {
+	switch result.State {
+	case consensusaccounts.OperationSucceeded:
+		for _, ev := range result.ConsensusEvents {
+			switch {
+			case ev.Transfer != nil:
+				common.Infof("Consensus transfer at height %d: %s -> %s (%s)\n",
+					ev.Height,
+					ev.Transfer.From,
+					ev.Transfer.To,
+					helpers.FormatConsensusDenomination(npa.Network, ev.Transfer.Amount),
+				)
+			case ev.AllowanceChange != nil:
+				common.Infof("Consensus allowance change at height %d: %s -> %s (%s)\n",
+					ev.Height,
+					ev.AllowanceChange.Owner,
+					ev.AllowanceChange.Beneficiary,
+					helpers.FormatConsensusDenomination(npa.Network, ev.AllowanceChange.Allowance),
+				)
+			}
+		}
+		switch result.Kind {
+		case consensusaccounts.OperationDeposit:
+			common.Infof("Deposit succeeded.\n")
+		default:
+			common.Infof("Withdraw succeeded.\n")
+		}
+	case consensusaccounts.OperationFailed:
+		if result.ConsensusError != nil {
+			cobra.CheckErr(fmt.Errorf("%s failed with error code %d from module %s: %w",
+				result.Kind,
+				result.ConsensusError.Code,
+				result.ConsensusError.Module,
+				result.Err,
+			))
+		}
+		cobra.CheckErr(fmt.Errorf("%s failed: %w", result.Kind, result.Err))
+	case consensusaccounts.OperationTimedOut:
+		cobra.CheckErr(fmt.Errorf("timed out waiting for %s result", result.Kind))
+	default:
+		cobra.CheckErr(fmt.Errorf("unexpected %s state: %s", result.Kind, result.State))
+	}
+}
+
+// accountShowOutput is the structured output of the accounts show command.
+type accountShowOutput struct {
+	Address types.Address `json:"address"`
+	Network string        `json:"network"`
+	Height  int64         `json:"height"`
+
+	ConsensusAccount             *staking.Account                                       `json:"consensus_account"`
+	OutgoingDelegations          map[staking.Address]*staking.DelegationInfo            `json:"outgoing_delegations,omitempty"`
+	OutgoingDebondingDelegations map[staking.Address][]*staking.DebondingDelegationInfo `json:"outgoing_debonding_delegations,omitempty"`
+	IncomingDelegations          map[staking.Address]*staking.Delegation                `json:"incoming_delegations,omitempty"`
+	IncomingDebondingDelegations map[staking.Address][]*staking.DebondingDelegation     `json:"incoming_debonding_delegations,omitempty"`
+
+	ParaTime         string                                   `json:"paratime,omitempty"`
+	ParaTimeBalances map[types.Denomination]quantity.Quantity `json:"paratime_balances,omitempty"`
+}
+
+// formatSignedAmount formats a possibly negative amount in the given denomination.
+func formatSignedAmount(pt *config.ParaTime, amount *big.Int, denom types.Denomination) string {
+	var sign string
+	abs := new(big.Int).Set(amount)
+	if abs.Sign() < 0 {
+		sign = "-"
+		abs.Neg(abs)
+	}
+	var q quantity.Quantity
+	if err := q.FromBigInt(abs); err != nil {
+		return amount.String()
+	}
+	return sign + helpers.FormatParaTimeDenomination(pt, types.NewBaseUnits(q, denom))
+}
+
+// sortDenominations sorts the given denominations in place and returns them.
+func sortDenominations(denoms []types.Denomination) []types.Denomination {
+	sort.Slice(denoms, func(i, j int) bool { return denoms[i] < denoms[j] })
+	return denoms
+}
+
+func accountHistoryOutput(pt *config.ParaTime, st *accounts.Statement) [][]string {
+	var lines [][]string
+	for _, entry := range st.Entries {
+		var counterparty, txHash, gasUsed string
+		if entry.Counterparty != nil {
+			counterparty = entry.Counterparty.String()
+		}
+		if entry.TxHash != nil {
+			txHash = entry.TxHash.String()
+		}
+		if entry.GasUsed != 0 {
+			gasUsed = strconv.FormatUint(entry.GasUsed, 10)
+		}
+		denom := entry.Amount.Denomination
+		lines = append(lines, []string{
+			strconv.FormatUint(entry.Round, 10),
+			string(entry.Kind),
+			counterparty,
+			txHash,
+			gasUsed,
+			formatSignedAmount(pt, entry.Change, denom),
+			formatSignedAmount(pt, entry.Balance, denom),
+		})
+	}
+	return lines
+}
+
 func init() {
 	accountsShowCmd.Flags().AddFlagSet(common.SelectorFlags)
 	accountsShowCmd.Flags().AddFlagSet(common.HeightFlag)
 
+	historyFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	historyFlags.Uint64Var(&historyStartRound, "start-round", 0, fmt.Sprintf("first round of the history (default: the last %d rounds)", defaultHistoryRounds))
+	historyFlags.Uint64Var(&historyEndRound, "end-round", 0, "last round of the history, 0 for the latest round")
+	historyFlags.StringVar(&historyCSVFile, "csv-file", "", "write the history to the given CSV file")
+	accountsHistoryCmd.Flags().AddFlagSet(common.SelectorFlags)
+	accountsHistoryCmd.Flags().AddFlagSet(historyFlags)
+
 	accountsAllowCmd.Flags().AddFlagSet(common.SelectorFlags)
 	accountsAllowCmd.Flags().AddFlagSet(common.TransactionFlags)
 
@@ -955,6 +1202,7 @@ func init() {
 	accountsEntitySignerCmd.Flags().AddFlagSet(common.SelectorFlags)
 
 	accountsCmd.AddCommand(accountsShowCmd)
+	accountsCmd.AddCommand(accountsHistoryCmd)
 	accountsCmd.AddCommand(accountsAllowCmd)
 	accountsCmd.AddCommand(accountsDepositCmd)
 	accountsCmd.AddCommand(accountsWithdrawCmd)
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-sdk/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/oasis-sdk/cli/config"
+	"github.com/oasisprotocol/oasis-sdk/cli/table"
+)
+
+var (
+	contactDescription string
+
+	addressBookCmd = &cobra.Command{
+		Use:     "addressbook",
+		Aliases: []string{"ab", "contacts"},
+		Short:   "Manage contacts in the address book",
+		Long: "Manage contacts in the address book.\n\n" +
+			"Contacts can be used in place of addresses either by their bare name or in the\n" +
+			"explicit contact:<name> form.",
+	}
+
+	addressBookListCmd = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List contacts",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+
+			var contacts []*contactInfo
+			for name, entry := range cfg.AddressBook.All {
+				contacts = append(contacts, &contactInfo{
+					Name:        name,
+					Address:     entry.Address,
+					Description: entry.Description,
+				})
+			}
+
+			// Sort output by name.
+			sort.Slice(contacts, func(i, j int) bool {
+				return contacts[i].Name < contacts[j].Name
+			})
+
+			common.Output(contacts, func() {
+				table := table.New()
+				table.SetHeader([]string{"Name", "Address", "Description"})
+
+				var output [][]string
+				for _, c := range contacts {
+					output = append(output, []string{
+						c.Name,
+						c.Address,
+						c.Description,
+					})
+				}
+
+				table.AppendBulk(output)
+				table.Render()
+			})
+		},
+	}
+
+	addressBookAddCmd = &cobra.Command{
+		Use:   "add <name> <address>",
+		Short: "Add a new contact",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			name, address := args[0], args[1]
+
+			if _, exists := cfg.Wallet.All[name]; exists {
+				cobra.CheckErr(fmt.Errorf("account '%s' with the same name already exists in the wallet", name))
+			}
+
+			err := cfg.AddressBook.Add(name, &cliConfig.AddressBookEntry{
+				Description: contactDescription,
+				Address:     address,
+			})
+			cobra.CheckErr(err)
+
+			err = cfg.Save()
+			cobra.CheckErr(err)
+		},
+	}
+
+	addressBookRmCmd = &cobra.Command{
+		Use:     "rm <name>",
+		Aliases: []string{"remove"},
+		Short:   "Remove an existing contact",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			name := args[0]
+
+			err := cfg.AddressBook.Remove(name)
+			cobra.CheckErr(err)
+
+			err = cfg.Save()
+			cobra.CheckErr(err)
+		},
+	}
+)
+
+// contactInfo is the structured output of the address book list command.
+type contactInfo struct {
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	Description string `json:"description,omitempty"`
+}
+
+func init() {
+	addressBookAddFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	addressBookAddFlags.StringVar(&contactDescription, "description", "", "contact description")
+	addressBookAddCmd.Flags().AddFlagSet(addressBookAddFlags)
+
+	addressBookCmd.AddCommand(addressBookListCmd)
+	addressBookCmd.AddCommand(addressBookAddCmd)
+	addressBookCmd.AddCommand(addressBookRmCmd)
+}
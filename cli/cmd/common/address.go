@@ -0,0 +1,105 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+
+	cliConfig "github.com/oasisprotocol/oasis-sdk/cli/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+const (
+	addressExplicitAccount = "account:"
+	addressExplicitContact = "contact:"
+)
+
+// resolveLocalName looks up the given address as the name of an account in the wallet or of a
+// contact in the address book. At most one of the returned account name and contact is set. In
+// case the address is not a local name, both are empty.
+func resolveLocalName(cfg *cliConfig.Config, address string) (string, *cliConfig.AddressBookEntry, error) {
+	switch {
+	case strings.HasPrefix(address, addressExplicitAccount):
+		name := strings.TrimPrefix(address, addressExplicitAccount)
+		if _, exists := cfg.Wallet.All[name]; !exists {
+			return "", nil, fmt.Errorf("account '%s' does not exist in the wallet", name)
+		}
+		return name, nil, nil
+	case strings.HasPrefix(address, addressExplicitContact):
+		name := strings.TrimPrefix(address, addressExplicitContact)
+		entry, exists := cfg.AddressBook.All[name]
+		if !exists {
+			return "", nil, fmt.Errorf("contact '%s' does not exist in the address book", name)
+		}
+		return "", entry, nil
+	}
+
+	// Accounts in the wallet take precedence over contacts in the address book.
+	if _, exists := cfg.Wallet.All[address]; exists {
+		return address, nil, nil
+	}
+	if entry, exists := cfg.AddressBook.All[address]; exists {
+		return "", entry, nil
+	}
+	return "", nil, nil
+}
+
+// ResolveLocalAccountOrAddress resolves a string address into the corresponding account address.
+//
+// In addition to the forms supported by helpers.ResolveAddress, the address may also be given as
+// the name of an account in the wallet or a contact in the address book, either bare or using
+// the explicit account:<name> and contact:<name> forms.
+func ResolveLocalAccountOrAddress(net *config.Network, address string) (*types.Address, error) {
+	cfg := cliConfig.Global()
+
+	accName, entry, err := resolveLocalName(cfg, address)
+	switch {
+	case err != nil:
+		return nil, err
+	case accName != "":
+		addr := cfg.Wallet.All[accName].GetAddress()
+		return &addr, nil
+	case entry != nil:
+		addr := entry.GetAddress()
+		return &addr, nil
+	default:
+		return helpers.ResolveAddress(net, address)
+	}
+}
+
+// ResolveLocalAccountOrEthAddress resolves a string address into the corresponding Ethereum
+// address.
+//
+// The address is either a hex-encoded Ethereum address or the name of an account or contact as
+// accepted by ResolveLocalAccountOrAddress. Accounts are loaded to derive their Ethereum address
+// and contacts must have been added with an Ethereum address.
+func ResolveLocalAccountOrEthAddress(address string) (*ethCommon.Address, error) {
+	cfg := cliConfig.Global()
+
+	accName, entry, err := resolveLocalName(cfg, address)
+	switch {
+	case err != nil:
+		return nil, err
+	case accName != "":
+		acc := LoadAccount(cfg, accName)
+		pk := acc.SignatureAddressSpec().Secp256k1Eth
+		if pk == nil {
+			return nil, fmt.Errorf("account '%s' is not an Ethereum-compatible secp256k1 account", accName)
+		}
+		address = helpers.EthAddressFromPubKey(*pk)
+	case entry != nil:
+		if !strings.HasPrefix(entry.Address, "0x") {
+			return nil, fmt.Errorf("contact '%s' does not have an Ethereum address", strings.TrimPrefix(address, addressExplicitContact))
+		}
+		address = entry.Address
+	}
+
+	if !ethCommon.IsHexAddress(address) {
+		return nil, fmt.Errorf("malformed Ethereum address '%s'", address)
+	}
+	addr := ethCommon.HexToAddress(address)
+	return &addr, nil
+}
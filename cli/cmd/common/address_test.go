@@ -0,0 +1,117 @@
+package common
+
+import (
+	"encoding/hex"
+	"testing"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	cliConfig "github.com/oasisprotocol/oasis-sdk/cli/config"
+	walletFile "github.com/oasisprotocol/oasis-sdk/cli/wallet/file"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/rewards"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// setTestAddressConfig replaces the global configuration with one containing the given accounts
+// and contacts for the duration of the test.
+func setTestAddressConfig(t *testing.T, accounts map[string]types.Address, contacts map[string]string) *cliConfig.Config {
+	cfg := cliConfig.Global()
+	saved := *cfg
+	t.Cleanup(func() { *cfg = saved })
+
+	*cfg = cliConfig.Config{Networks: config.DefaultNetworks}
+	for name, addr := range accounts {
+		if cfg.Wallet.All == nil {
+			cfg.Wallet.All = make(map[string]*cliConfig.Account)
+		}
+		cfg.Wallet.All[name] = &cliConfig.Account{Kind: walletFile.Kind, Address: addr.String()}
+	}
+	for name, addr := range contacts {
+		require.NoError(t, cfg.AddressBook.Add(name, &cliConfig.AddressBookEntry{Address: addr}), "Add")
+	}
+	return cfg
+}
+
+func TestResolveLocalAccountOrAddress(t *testing.T) {
+	require := require.New(t)
+
+	daveEth := "0x" + hex.EncodeToString(sdkTesting.Dave.EthAddress[:])
+	cfg := setTestAddressConfig(t,
+		map[string]types.Address{"alice": sdkTesting.Alice.Address},
+		map[string]string{"bob": sdkTesting.Bob.Address.String(), "dave": daveEth},
+	)
+	net := cfg.Networks.All["mainnet"]
+
+	for _, tc := range []struct {
+		address  string
+		valid    bool
+		expected types.Address
+	}{
+		{"alice", true, sdkTesting.Alice.Address},
+		{"account:alice", true, sdkTesting.Alice.Address},
+		{"contact:alice", false, types.Address{}},
+		{"bob", true, sdkTesting.Bob.Address},
+		{"contact:bob", true, sdkTesting.Bob.Address},
+		{"account:bob", false, types.Address{}},
+		{"dave", true, types.NewAddressRaw(types.AddressV0Secp256k1EthContext, sdkTesting.Dave.EthAddress[:])},
+		{"charlie", false, types.Address{}},
+		{sdkTesting.Charlie.Address.String(), true, sdkTesting.Charlie.Address},
+		{"pool:rewards", true, rewards.RewardPoolAddress},
+	} {
+		addr, err := ResolveLocalAccountOrAddress(net, tc.address)
+		if !tc.valid {
+			require.Error(err, "ResolveLocalAccountOrAddress(%s) should fail", tc.address)
+			continue
+		}
+		require.NoError(err, "ResolveLocalAccountOrAddress(%s)", tc.address)
+		require.Equal(tc.expected, *addr, tc.address)
+	}
+
+	// Accounts take precedence over contacts with the same name.
+	cfg.AddressBook.All["alice"] = &cliConfig.AddressBookEntry{Address: sdkTesting.Bob.Address.String()}
+	addr, err := ResolveLocalAccountOrAddress(net, "alice")
+	require.NoError(err)
+	require.Equal(sdkTesting.Alice.Address, *addr)
+	addr, err = ResolveLocalAccountOrAddress(net, "contact:alice")
+	require.NoError(err)
+	require.Equal(sdkTesting.Bob.Address, *addr)
+	err = cfg.Validate()
+	require.Error(err, "contacts shadowing accounts should be rejected")
+	require.Contains(err.Error(), "same name as an account")
+}
+
+func TestResolveLocalAccountOrEthAddress(t *testing.T) {
+	require := require.New(t)
+
+	daveEth := "0x" + hex.EncodeToString(sdkTesting.Dave.EthAddress[:])
+	setTestAddressConfig(t,
+		map[string]types.Address{"alice": sdkTesting.Alice.Address},
+		map[string]string{"bob": sdkTesting.Bob.Address.String(), "dave": daveEth},
+	)
+
+	for _, tc := range []struct {
+		address string
+		valid   bool
+	}{
+		{"dave", true},
+		{"contact:dave", true},
+		{daveEth, true},
+		{"bob", false},
+		{"account:dave", false},
+		{"contact:charlie", false},
+		{"charlie", false},
+		{"0xdeadbeef", false},
+		{sdkTesting.Dave.Address.String(), false},
+	} {
+		addr, err := ResolveLocalAccountOrEthAddress(tc.address)
+		if !tc.valid {
+			require.Error(err, "ResolveLocalAccountOrEthAddress(%s) should fail", tc.address)
+			continue
+		}
+		require.NoError(err, "ResolveLocalAccountOrEthAddress(%s)", tc.address)
+		require.Equal(ethCommon.Address(sdkTesting.Dave.EthAddress), *addr, tc.address)
+	}
+}
@@ -7,13 +7,21 @@ import (
 	flag "github.com/spf13/pflag"
 
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
 )
 
-var selectedHeight int64
+var (
+	selectedHeight int64
+	selectedRound  uint64
+)
 
 // HeightFlag is the flag for specifying block height.
 var HeightFlag *flag.FlagSet
 
+// RoundFlag is the flag for specifying ParaTime round.
+var RoundFlag *flag.FlagSet
+
 // GetHeight returns the user-selected block height.
 func GetHeight() int64 {
 	return selectedHeight
@@ -36,7 +44,15 @@ func GetActualHeight(
 	return blk.Height, nil
 }
 
+// GetRound returns the user-selected ParaTime round.
+func GetRound() uint64 {
+	return selectedRound
+}
+
 func init() {
 	HeightFlag = flag.NewFlagSet("", flag.ContinueOnError)
 	HeightFlag.Int64Var(&selectedHeight, "height", consensus.HeightLatest, "explicitly set block height to use")
+
+	RoundFlag = flag.NewFlagSet("", flag.ContinueOnError)
+	RoundFlag.Uint64Var(&selectedRound, "round", client.RoundLatest, "explicitly set paratime round to use")
 }
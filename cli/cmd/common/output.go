@@ -0,0 +1,131 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+// Supported output formats.
+const (
+	// OutputFormatText is the human-readable output format.
+	OutputFormatText = "text"
+	// OutputFormatJSON is the JSON output format.
+	OutputFormatJSON = "json"
+	// OutputFormatYAML is the YAML output format.
+	OutputFormatYAML = "yaml"
+)
+
+var outputFormat string
+
+// FormatFlag contains the output format flag.
+var FormatFlag *flag.FlagSet
+
+// OutputFormat returns the selected output format.
+func OutputFormat() string {
+	switch outputFormat {
+	case OutputFormatText, OutputFormatJSON, OutputFormatYAML:
+		return outputFormat
+	default:
+		cobra.CheckErr(fmt.Errorf("unsupported output format: %s", outputFormat))
+		return "" // Not reached.
+	}
+}
+
+// IsStructuredOutput returns true iff a machine-readable output format has been selected.
+func IsStructuredOutput() bool {
+	return OutputFormat() != OutputFormatText
+}
+
+// InfoWriter returns the writer that informational messages should be written to.
+//
+// When a structured output format is selected, informational messages are written to standard
+// error so that they do not interfere with the structured output.
+func InfoWriter() io.Writer {
+	if IsStructuredOutput() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// Infof formats and writes an informational message.
+func Infof(format string, a ...interface{}) {
+	fmt.Fprintf(InfoWriter(), format, a...)
+}
+
+// Output outputs v in the selected structured output format. In case the text output format is
+// selected, textFn is called instead.
+func Output(v interface{}, textFn func()) {
+	switch OutputFormat() {
+	case OutputFormatText:
+		textFn()
+	default:
+		formatted, err := MarshalStructured(v)
+		cobra.CheckErr(err)
+		fmt.Println(string(formatted))
+	}
+}
+
+// MarshalStructured returns the encoding of v in the selected structured output format. For
+// the text output format pretty-printed JSON is returned.
+func MarshalStructured(v interface{}) ([]byte, error) {
+	switch OutputFormat() {
+	case OutputFormatYAML:
+		// Go through JSON first so that the same field names are used in both formats.
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal to YAML: %w", err)
+		}
+		var generic interface{}
+		if err = json.Unmarshal(raw, &generic); err != nil {
+			return nil, fmt.Errorf("failed to marshal to YAML: %w", err)
+		}
+		formatted, err := yaml.Marshal(generic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal to YAML: %w", err)
+		}
+		return formatted, nil
+	default:
+		return PrettyJSONMarshal(v)
+	}
+}
+
+// DecodeCBORGeneric decodes arbitrary CBOR into a structure that can be marshalled into any of
+// the structured output formats.
+func DecodeCBORGeneric(data []byte) (interface{}, error) {
+	var v interface{}
+	if err := cbor.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return normalizeGeneric(v), nil
+}
+
+func normalizeGeneric(v interface{}) interface{} {
+	switch tv := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(tv))
+		for k, val := range tv {
+			m[fmt.Sprintf("%v", k)] = normalizeGeneric(val)
+		}
+		return m
+	case []interface{}:
+		for i := range tv {
+			tv[i] = normalizeGeneric(tv[i])
+		}
+		return tv
+	default:
+		return v
+	}
+}
+
+func init() {
+	FormatFlag = flag.NewFlagSet("", flag.ContinueOnError)
+	FormatFlag.StringVar(&outputFormat, "format", OutputFormatText, "output format [text, json, yaml]")
+}
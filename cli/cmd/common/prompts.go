@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/spf13/cobra"
@@ -29,13 +30,24 @@ func Confirm(msg, abortMsg string) {
 	// TODO: Support flag for skipping confirmations.
 
 	var proceed bool
-	err := survey.AskOne(&survey.Confirm{Message: msg}, &proceed)
+	err := survey.AskOne(&survey.Confirm{Message: msg}, &proceed, SurveyStdio())
 	cobra.CheckErr(err)
 	if !proceed {
 		cobra.CheckErr(abortMsg)
 	}
 }
 
+// SurveyStdio returns the survey option for the standard input/output streams to use.
+//
+// When a structured output format is selected, prompts are written to standard error so that
+// they do not interfere with the structured output.
+func SurveyStdio() survey.AskOpt {
+	if IsStructuredOutput() {
+		return survey.WithStdio(os.Stdin, os.Stderr, os.Stderr)
+	}
+	return survey.WithStdio(os.Stdin, os.Stdout, os.Stderr)
+}
+
 // AskNewPassphrase asks the user to create a new passphrase.
 func AskNewPassphrase() string {
 	var answers struct {
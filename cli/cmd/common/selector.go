@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
@@ -10,10 +11,15 @@ import (
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
 )
 
+// ProfileEnvVar is the environment variable that selects the profile when the profile flag is
+// not set.
+const ProfileEnvVar = "OASIS_PROFILE"
+
 var (
 	selectedNetwork  string
 	selectedParaTime string
 	selectedAccount  string
+	selectedProfile  string
 
 	noParaTime bool
 )
@@ -21,6 +27,32 @@ var (
 // SelectorFlags contains the common selector flags for network/paratime/wallet.
 var SelectorFlags *flag.FlagSet
 
+// ProfileFlag contains the profile selector flag.
+var ProfileFlag *flag.FlagSet
+
+// GetProfileSelection returns the name of the user-selected profile and the profile itself.
+//
+// The profile flag takes precedence over the environment variable which takes precedence over the
+// configured default profile. In case no profile is selected, an empty name and nil are returned.
+func GetProfileSelection(cfg *cliConfig.Config) (string, *cliConfig.Profile) {
+	name := cfg.Profiles.Default
+	if envName := os.Getenv(ProfileEnvVar); envName != "" {
+		name = envName
+	}
+	if selectedProfile != "" {
+		name = selectedProfile
+	}
+	if name == "" {
+		return "", nil
+	}
+
+	profile := cfg.Profiles.All[name]
+	if profile == nil {
+		cobra.CheckErr(fmt.Errorf("profile '%s' does not exist", name))
+	}
+	return name, profile
+}
+
 // NPASelection contains the network/paratime/account selection.
 type NPASelection struct {
 	NetworkName string
@@ -35,8 +67,16 @@ type NPASelection struct {
 
 // GetNPASelection returns the user-selected network/paratime/account combination.
 func GetNPASelection(cfg *cliConfig.Config) *NPASelection {
+	_, profile := GetProfileSelection(cfg)
+	if profile == nil {
+		profile = &cliConfig.Profile{}
+	}
+
 	var s NPASelection
 	s.NetworkName = cfg.Networks.Default
+	if profile.Network != "" {
+		s.NetworkName = profile.Network
+	}
 	if selectedNetwork != "" {
 		s.NetworkName = selectedNetwork
 	}
@@ -50,6 +90,10 @@ func GetNPASelection(cfg *cliConfig.Config) *NPASelection {
 
 	if !noParaTime {
 		s.ParaTimeName = s.Network.ParaTimes.Default
+		if profile.ParaTime != "" && profile.Network == s.NetworkName {
+			// Only use the profile's paratime when the network was not overridden.
+			s.ParaTimeName = profile.ParaTime
+		}
 		if selectedParaTime != "" {
 			s.ParaTimeName = selectedParaTime
 		}
@@ -62,6 +106,9 @@ func GetNPASelection(cfg *cliConfig.Config) *NPASelection {
 	}
 
 	s.AccountName = cfg.Wallet.Default
+	if profile.Account != "" {
+		s.AccountName = profile.Account
+	}
 	if selectedAccount != "" {
 		s.AccountName = selectedAccount
 	}
@@ -86,4 +133,7 @@ func init() {
 	SelectorFlags.StringVar(&selectedAccount, "wallet", "", "explicitly set account to use. OBSOLETE, USE --account INSTEAD!")
 	err := SelectorFlags.MarkHidden("wallet")
 	cobra.CheckErr(err)
+
+	ProfileFlag = flag.NewFlagSet("", flag.ContinueOnError)
+	ProfileFlag.StringVar(&selectedProfile, "profile", "", fmt.Sprintf("explicitly set profile to use (overrides %s)", ProfileEnvVar))
 }
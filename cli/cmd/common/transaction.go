@@ -4,13 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"os"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	coreSignature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	consensusPretty "github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
@@ -24,15 +27,18 @@ import (
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 )
 
 var (
-	txOffline   bool
-	txNonce     uint64
-	txGasLimit  uint64
-	txGasPrice  string
-	txEncrypted bool
+	txOffline    bool
+	txNonce      uint64
+	txGasLimit   uint64
+	txGasPrice   string
+	txEncrypted  bool
+	txUnsigned   bool
+	txOutputFile string
 )
 
 const (
@@ -40,30 +46,45 @@ const (
 	invalidGasLimit = math.MaxUint64
 )
 
-// TransactionFlags contains the common transaction flags.
-var TransactionFlags *flag.FlagSet
+var (
+	// TransactionFlags contains the common transaction flags.
+	TransactionFlags *flag.FlagSet
+
+	// TransactionOutputFlags contains the transaction export flags.
+	TransactionOutputFlags *flag.FlagSet
+)
 
 // TransactionConfig contains the transaction-related configuration from flags.
 type TransactionConfig struct {
 	// Offline is a flag indicating that no online queries are allowed.
 	Offline bool
+
+	// Export is a flag indicating that the transaction should be exported instead of broadcast.
+	Export bool
 }
 
 // GetTransactionConfig returns the transaction-related configuration from flags.
 func GetTransactionConfig() *TransactionConfig {
 	return &TransactionConfig{
 		Offline: txOffline,
+		Export:  shouldExportTransaction(),
 	}
 }
 
+func shouldExportTransaction() bool {
+	return txOffline || txUnsigned || txOutputFile != ""
+}
+
 // SignConsensusTransaction signs a consensus transaction.
+//
+// In case the unsigned flag is set, the prepared but unsigned transaction is returned instead.
 func SignConsensusTransaction(
 	ctx context.Context,
 	npa *NPASelection,
 	wallet wallet.Account,
 	conn connection.Connection,
 	tx *consensusTx.Transaction,
-) (*consensusTx.SignedTransaction, error) {
+) (interface{}, error) {
 	// Require consensus signer.
 	signer := wallet.ConsensusSigner()
 	if signer == nil {
@@ -123,13 +144,27 @@ func SignConsensusTransaction(
 	}
 	tx.Fee.Amount = *gasPrice
 
+	if txUnsigned {
+		return tx, nil
+	}
+
 	PrintTransactionBeforeSigning(npa, tx)
 
-	// Sign the transaction.
+	return signConsensusTransaction(npa.Network, signer, tx)
+}
+
+func consensusSignatureContext(net *config.Network) coreSignature.Context {
 	// NOTE: We build our own domain separation context here as we need to support multiple chain
 	//       contexts at the same time. Would be great if chainContextSeparator was exposed in core.
-	sigCtx := coreSignature.Context([]byte(fmt.Sprintf("%s for chain %s", consensusTx.SignatureContext, npa.Network.ChainContext)))
-	signed, err := coreSignature.SignSigned(signer, sigCtx, tx)
+	return coreSignature.Context([]byte(fmt.Sprintf("%s for chain %s", consensusTx.SignatureContext, net.ChainContext)))
+}
+
+func signConsensusTransaction(
+	net *config.Network,
+	signer coreSignature.Signer,
+	tx *consensusTx.Transaction,
+) (*consensusTx.SignedTransaction, error) {
+	signed, err := coreSignature.SignSigned(signer, consensusSignatureContext(net), tx)
 	if err != nil {
 		return nil, err
 	}
@@ -139,23 +174,23 @@ func SignConsensusTransaction(
 
 // SignParaTimeTransaction signs a ParaTime transaction.
 //
-// Returns the signed transaction and call format-specific metadata for result decoding.
+// Returns the signed transaction and call format-specific metadata for result decoding. In case
+// the unsigned flag is set, the prepared but unsigned transaction is returned instead.
 func SignParaTimeTransaction(
 	ctx context.Context,
 	npa *NPASelection,
 	wallet wallet.Account,
 	conn connection.Connection,
 	tx *types.Transaction,
-) (*types.UnverifiedTransaction, interface{}, error) {
+) (interface{}, interface{}, error) {
 	// Default to passed values and do online estimation when possible.
 	nonce := txNonce
 	tx.AuthInfo.Fee.Gas = txGasLimit
 
 	gasPrice := &types.BaseUnits{}
 	if txGasPrice != "" {
-		// TODO: Support different denominations for gas fees.
 		var err error
-		gasPrice, err = helpers.ParseParaTimeDenomination(npa.ParaTime, txGasPrice, types.NativeDenomination)
+		gasPrice, err = helpers.ParseParaTimeAmount(npa.ParaTime, txGasPrice)
 		if err != nil {
 			return nil, nil, fmt.Errorf("bad gas price: %w", err)
 		}
@@ -185,16 +220,22 @@ func SignParaTimeTransaction(
 			}
 		}
 
-		// Gas price determination if not specified.
-		if txGasPrice == "" {
-			mgp, err := conn.Runtime(npa.ParaTime).Core.MinGasPrice(ctx)
+		// Gas price determination if not specified, otherwise make sure that the runtime accepts
+		// fees in the given denomination.
+		mgp, err := conn.Runtime(npa.ParaTime).Core.MinGasPrice(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to query minimum gas price: %w", err)
+		}
+		switch txGasPrice {
+		case "":
+			gasPrice, err = client.SelectGasPrice(mgp, nil)
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to query minimum gas price: %w", err)
+				return nil, nil, err
+			}
+		default:
+			if _, err = client.SelectGasPrice(mgp, &gasPrice.Denomination); err != nil {
+				return nil, nil, fmt.Errorf("bad gas price: %w", err)
 			}
-
-			// TODO: Support different denominations for gas fees.
-			denom := types.NativeDenomination
-			*gasPrice = types.NewBaseUnits(mgp[denom], denom)
 		}
 	}
 
@@ -217,6 +258,10 @@ func SignParaTimeTransaction(
 		if txOffline {
 			return nil, nil, fmt.Errorf("encrypted transactions are not available in offline mode")
 		}
+		// Exported transactions would lose the metadata required to decrypt the results.
+		if shouldExportTransaction() {
+			return nil, nil, fmt.Errorf("encrypted transactions cannot be exported")
+		}
 
 		// Request public key from the runtime.
 		pk, err := conn.Runtime(npa.ParaTime).Core.CallDataPublicKey(ctx)
@@ -236,100 +281,269 @@ func SignParaTimeTransaction(
 		tx.Call = *encCall
 	}
 
+	if txUnsigned {
+		return tx, meta, nil
+	}
+
 	PrintTransactionBeforeSigning(npa, tx)
 
-	// Sign the transaction.
-	sigCtx := signature.DeriveChainContext(npa.ParaTime.Namespace(), npa.Network.ChainContext)
+	sigTx, err := signParaTimeTransaction(npa.Network, npa.ParaTime, wallet.Signer(), tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sigTx, meta, nil
+}
+
+func signParaTimeTransaction(
+	net *config.Network,
+	pt *config.ParaTime,
+	signer signature.Signer,
+	tx *types.Transaction,
+) (*types.UnverifiedTransaction, error) {
+	sigCtx := signature.DeriveChainContext(pt.Namespace(), net.ChainContext)
 	ts := tx.PrepareForSigning()
-	if err := ts.AppendSign(sigCtx, wallet.Signer()); err != nil {
-		return nil, nil, fmt.Errorf("failed to sign transaction: %w", err)
+	if err := ts.AppendSign(sigCtx, signer); err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
 	}
 
-	return ts.UnverifiedTransaction(), meta, nil
+	return ts.UnverifiedTransaction(), nil
 }
 
-// PrintTransactionBeforeSigning prints the transaction and asks the user for confirmation.
-func PrintTransactionBeforeSigning(npa *NPASelection, tx interface{}) {
-	fmt.Printf("You are about to sign the following transaction:\n")
+// checkParaTimeSigner makes sure that an account with the given signature address specification
+// and public key is one of the signers expected by the transaction.
+func checkParaTimeSigner(spec types.SignatureAddressSpec, pk signature.PublicKey, tx *types.Transaction) error {
+	addr := types.NewAddress(spec)
+	for _, si := range tx.AuthInfo.SignerInfo {
+		switch {
+		case si.AddressSpec.Signature != nil:
+			if types.NewAddress(*si.AddressSpec.Signature).Equal(addr) {
+				return nil
+			}
+		case si.AddressSpec.Multisig != nil:
+			for _, mss := range si.AddressSpec.Multisig.Signers {
+				if mss.PublicKey.Equal(pk) {
+					return nil
+				}
+			}
+		}
+	}
+	return fmt.Errorf("account %s is not a signer of the transaction", addr)
+}
+
+// SignTransaction signs a previously prepared consensus or ParaTime transaction.
+func SignTransaction(npa *NPASelection, wallet wallet.Account, tx interface{}) (interface{}, error) {
+	switch rtx := tx.(type) {
+	case *consensusTx.Transaction:
+		signer := wallet.ConsensusSigner()
+		if signer == nil {
+			return nil, fmt.Errorf("account does not support signing consensus transactions")
+		}
+
+		PrintTransactionBeforeSigning(npa, rtx)
+
+		return signConsensusTransaction(npa.Network, signer, rtx)
+	case *types.Transaction:
+		if npa.ParaTime == nil {
+			return nil, fmt.Errorf("no paratime selected for paratime transaction")
+		}
+		if err := checkParaTimeSigner(wallet.SignatureAddressSpec(), wallet.Signer().Public(), rtx); err != nil {
+			return nil, err
+		}
+
+		PrintTransactionBeforeSigning(npa, rtx)
+
+		return signParaTimeTransaction(npa.Network, npa.ParaTime, wallet.Signer(), rtx)
+	default:
+		return nil, fmt.Errorf("unsupported transaction kind: %T", tx)
+	}
+}
+
+// OpenSignedTransaction verifies the signatures of a signed transaction and returns the inner
+// transaction together with the public keys of its signers.
+func OpenSignedTransaction(npa *NPASelection, tx interface{}) (interface{}, []string, error) {
+	switch sigTx := tx.(type) {
+	case *consensusTx.SignedTransaction:
+		if !sigTx.Signature.PublicKey.Verify(consensusSignatureContext(npa.Network), sigTx.Blob, sigTx.Signature.Signature[:]) {
+			return nil, nil, fmt.Errorf("invalid transaction signature")
+		}
+
+		var inner consensusTx.Transaction
+		if err := cbor.Unmarshal(sigTx.Blob, &inner); err != nil {
+			return nil, nil, fmt.Errorf("malformed transaction: %w", err)
+		}
+		return &inner, []string{sigTx.Signature.PublicKey.String()}, nil
+	case *types.UnverifiedTransaction:
+		if npa.ParaTime == nil {
+			return nil, nil, fmt.Errorf("no paratime selected for paratime transaction")
+		}
+
+		sigCtx := signature.DeriveChainContext(npa.ParaTime.Namespace(), npa.Network.ChainContext)
+		inner, err := sigTx.Verify(sigCtx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var signers []string
+		for _, si := range inner.AuthInfo.SignerInfo {
+			addr, err := si.AddressSpec.Address()
+			if err != nil {
+				return nil, nil, err
+			}
+			signers = append(signers, addr.String())
+		}
+		return inner, signers, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported transaction kind: %T", tx)
+	}
+}
+
+// PrintTransaction prints the given (unsigned) transaction.
+func PrintTransaction(npa *NPASelection, tx interface{}) {
+	printTransaction(os.Stdout, npa, tx)
+}
 
+func printTransaction(w io.Writer, npa *NPASelection, tx interface{}) {
 	switch rtx := tx.(type) {
 	case *consensusTx.Transaction:
 		// Consensus transaction.
 		ctx := context.Background()
 		ctx = context.WithValue(ctx, consensusPretty.ContextKeyTokenSymbol, npa.Network.Denomination.Symbol)
 		ctx = context.WithValue(ctx, consensusPretty.ContextKeyTokenValueExponent, npa.Network.Denomination.Decimals)
-		rtx.PrettyPrint(ctx, "", os.Stdout)
+		rtx.PrettyPrint(ctx, "", w)
 	default:
 		// TODO: Add pretty variant for paratime transactions.
 		formatted, err := json.MarshalIndent(tx, "", "  ")
 		cobra.CheckErr(err)
-		fmt.Println(string(formatted))
+		fmt.Fprintln(w, string(formatted))
 	}
-	fmt.Println()
+}
+
+// PrintTransactionBeforeSigning prints the transaction and asks the user for confirmation.
+func PrintTransactionBeforeSigning(npa *NPASelection, tx interface{}) {
+	w := InfoWriter()
+	fmt.Fprintf(w, "You are about to sign the following transaction:\n")
 
-	fmt.Printf("Account:  %s", npa.AccountName)
+	printTransaction(w, npa, tx)
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "Account:  %s", npa.AccountName)
 	if len(npa.Account.Description) > 0 {
-		fmt.Printf(" (%s)", npa.Account.Description)
+		fmt.Fprintf(w, " (%s)", npa.Account.Description)
 	}
-	fmt.Println()
-	fmt.Printf("Network:  %s", npa.NetworkName)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Network:  %s", npa.NetworkName)
 	if len(npa.Network.Description) > 0 {
-		fmt.Printf(" (%s)", npa.Network.Description)
+		fmt.Fprintf(w, " (%s)", npa.Network.Description)
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 	if _, isParaTimeTx := tx.(*types.Transaction); isParaTimeTx && npa.ParaTime != nil {
-		fmt.Printf("Paratime: %s", npa.ParaTimeName)
+		fmt.Fprintf(w, "Paratime: %s", npa.ParaTimeName)
 		if len(npa.ParaTime.Description) > 0 {
-			fmt.Printf(" (%s)", npa.ParaTime.Description)
+			fmt.Fprintf(w, " (%s)", npa.ParaTime.Description)
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	} else {
-		fmt.Println("Paratime: none (consensus layer)")
+		fmt.Fprintln(w, "Paratime: none (consensus layer)")
 	}
 
 	// Ask the user to confirm signing this transaction.
 	Confirm("Sign this transaction?", "signing aborted")
 
-	fmt.Println("(In case you are using a hardware-based signer you may need to confirm on device.)")
+	fmt.Fprintln(w, "(In case you are using a hardware-based signer you may need to confirm on device.)")
 }
 
-// PrintSignedTransaction prints a signed transaction.
-func PrintSignedTransaction(sigTx interface{}) {
-	// TODO: Add some options for controlling output.
-	formatted, err := json.MarshalIndent(sigTx, "", "  ")
+// ExportTransaction exports a (signed or unsigned) transaction into a transaction file.
+//
+// The file is written to the configured output file or to standard output if none is set.
+func ExportTransaction(npa *NPASelection, tx interface{}) {
+	tf, err := NewTransactionFile(npa, tx)
+	cobra.CheckErr(err)
+
+	if txOutputFile == "" {
+		formatted, err := MarshalStructured(tf)
+		cobra.CheckErr(err)
+		fmt.Println(string(formatted))
+		return
+	}
+
+	err = tf.Save(txOutputFile)
 	cobra.CheckErr(err)
-	fmt.Println(string(formatted))
 }
 
 // BroadcastTransaction broadcasts a transaction.
 //
-// When in offline mode, it outputs the transaction instead.
+// When in offline mode or when exporting, it exports the transaction instead.
 func BroadcastTransaction(
 	ctx context.Context,
-	pt *config.ParaTime,
+	npa *NPASelection,
 	conn connection.Connection,
 	tx interface{},
 	meta interface{},
 	result interface{},
 ) {
-	if txOffline {
-		PrintSignedTransaction(tx)
+	BroadcastEVMTransaction(ctx, npa, conn, tx, meta, result, nil)
+}
+
+// BroadcastEVMTransaction broadcasts a transaction calling an EVM contract with the given ABI.
+//
+// The ABI is used to decode custom errors in case the call is reverted. It may be nil.
+func BroadcastEVMTransaction(
+	ctx context.Context,
+	npa *NPASelection,
+	conn connection.Connection,
+	tx interface{},
+	meta interface{},
+	result interface{},
+	contractABI *abi.ABI,
+) {
+	if shouldExportTransaction() {
+		ExportTransaction(npa, tx)
 		return
 	}
 
+	SubmitTransaction(ctx, npa, conn, tx, meta, result, contractABI)
+}
+
+// TransactionResult is the outcome of a submitted transaction.
+type TransactionResult struct {
+	// Hash is the transaction hash.
+	Hash hash.Hash `json:"hash"`
+	// Round is the ParaTime round in which the transaction was included. It is only set for
+	// ParaTime transactions.
+	Round uint64 `json:"round,omitempty"`
+	// Result is the decoded execution result. It is only set for ParaTime transactions.
+	Result interface{} `json:"result,omitempty"`
+}
+
+// SubmitTransaction submits a signed transaction and waits for it to be executed.
+//
+// In case a structured output format is selected, the TransactionResult is output. The optional
+// contract ABI is used to decode custom errors of reverted EVM calls.
+func SubmitTransaction(
+	ctx context.Context,
+	npa *NPASelection,
+	conn connection.Connection,
+	tx interface{},
+	meta interface{},
+	result interface{},
+	contractABI *abi.ABI,
+) {
+	var out TransactionResult
 	switch sigTx := tx.(type) {
 	case *consensusTx.SignedTransaction:
 		// Consensus transaction.
-		fmt.Printf("Broadcasting transaction...\n")
+		Infof("Broadcasting transaction...\n")
 		err := conn.Consensus().SubmitTx(ctx, sigTx)
 		cobra.CheckErr(err)
 
-		fmt.Printf("Transaction executed successfully.\n")
-		fmt.Printf("Transaction hash: %s\n", sigTx.Hash())
+		out.Hash = sigTx.Hash()
+
+		Infof("Transaction executed successfully.\n")
+		Infof("Transaction hash: %s\n", out.Hash)
 	case *types.UnverifiedTransaction:
 		// ParaTime transaction.
-		fmt.Printf("Broadcasting transaction...\n")
-		rawMeta, err := conn.Runtime(pt).SubmitTxRawMeta(ctx, sigTx)
+		Infof("Broadcasting transaction...\n")
+		rawMeta, err := conn.Runtime(npa.ParaTime).SubmitTxRawMeta(ctx, sigTx)
 		cobra.CheckErr(err)
 
 		if rawMeta.CheckTxError != nil {
@@ -340,12 +554,15 @@ func BroadcastTransaction(
 			))
 		}
 
-		fmt.Printf("Transaction included in block successfully.\n")
-		fmt.Printf("Round:            %d\n", rawMeta.Round)
-		fmt.Printf("Transaction hash: %s\n", sigTx.Hash())
+		out.Hash = sigTx.Hash()
+		out.Round = rawMeta.Round
+
+		Infof("Transaction included in block successfully.\n")
+		Infof("Round:            %d\n", out.Round)
+		Infof("Transaction hash: %s\n", out.Hash)
 
 		if rawMeta.Result.IsUnknown() {
-			fmt.Printf("                  (Transaction result is encrypted.)\n")
+			Infof("                  (Transaction result is encrypted.)\n")
 		}
 
 		decResult, err := callformat.DecodeResult(&rawMeta.Result, meta)
@@ -356,63 +573,30 @@ func BroadcastTransaction(
 			// This should never happen as the inner result should not be unknown.
 			cobra.CheckErr(fmt.Sprintf("Execution result unknown: %X", decResult.Unknown))
 		case decResult.IsSuccess():
-			fmt.Printf("Execution successful.\n")
+			Infof("Execution successful.\n")
 
-			if result != nil {
+			switch result {
+			case nil:
+				out.Result, err = DecodeCBORGeneric(decResult.Ok)
+			default:
 				err = cbor.Unmarshal(decResult.Ok, result)
-				cobra.CheckErr(err)
+				out.Result = result
 			}
+			cobra.CheckErr(err)
 		default:
-			cobra.CheckErr(fmt.Sprintf("Execution failed with error: %s", decResult.Failed.Error()))
+			var failErr error = decResult.Failed
+			if rev := evm.DecodeRevert(failErr, contractABI); rev != nil {
+				failErr = rev
+			}
+			cobra.CheckErr(fmt.Sprintf("Execution failed with error: %s", failErr))
 		}
 	default:
 		panic(fmt.Errorf("unsupported transaction kind: %T", tx))
 	}
-}
-
-// WaitForEvent waits for a specific ParaTime event.
-//
-// If no mapFn is specified, the returned channel will contain DecodedEvents, otherwise it will
-// contain whatever mapFn returns.
-//
-// If mapFn is specified it should return a non-nil value when encountering a matching event.
-func WaitForEvent(
-	ctx context.Context,
-	pt *config.ParaTime,
-	conn connection.Connection,
-	decoder client.EventDecoder,
-	mapFn func(client.DecodedEvent) interface{},
-) <-chan interface{} {
-	ctx, cancel := context.WithCancel(ctx)
-
-	// Start watching events.
-	ch, err := conn.Runtime(pt).WatchEvents(ctx, []client.EventDecoder{decoder}, false)
-	cobra.CheckErr(err)
-
-	// Start processing events.
-	resultCh := make(chan interface{})
-	go func() {
-		defer close(resultCh)
-		defer cancel()
-
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case bev := <-ch:
-				for _, ev := range bev.Events {
-					if result := mapFn(ev); result != nil {
-						resultCh <- result
-						return
-					}
-				}
-
-				// TODO: Timeout.
-			}
-		}
-	}()
 
-	return resultCh
+	if IsStructuredOutput() {
+		Output(&out, nil)
+	}
 }
 
 func init() {
@@ -420,6 +604,11 @@ func init() {
 	TransactionFlags.BoolVar(&txOffline, "offline", false, "do not perform any operations requiring network access")
 	TransactionFlags.Uint64Var(&txNonce, "nonce", invalidNonce, "override nonce to use")
 	TransactionFlags.Uint64Var(&txGasLimit, "gas-limit", invalidGasLimit, "override gas limit to use (disable estimation)")
-	TransactionFlags.StringVar(&txGasPrice, "gas-price", "", "override gas price to use")
+	TransactionFlags.StringVar(&txGasPrice, "gas-price", "", "override gas price to use, optionally followed by a paratime denomination")
 	TransactionFlags.BoolVar(&txEncrypted, "encrypted", false, "encrypt transaction call data (requires online mode)")
+	TransactionFlags.BoolVar(&txUnsigned, "unsigned", false, "do not sign transaction, export it instead")
+
+	TransactionOutputFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	TransactionOutputFlags.StringVarP(&txOutputFile, "output-file", "o", "", "export transaction into the given file instead of broadcasting it")
+	TransactionFlags.AddFlagSet(TransactionOutputFlags)
 }
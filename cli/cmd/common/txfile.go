@@ -0,0 +1,210 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	consensusTx "github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+
+	cliConfig "github.com/oasisprotocol/oasis-sdk/cli/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+const (
+	// TransactionFileVersion is the current version of the transaction file format.
+	TransactionFileVersion = 1
+
+	// TransactionFileExtCBOR is the file extension of CBOR-encoded transaction files. Files with
+	// any other extension are JSON-encoded.
+	TransactionFileExtCBOR = ".cbor"
+)
+
+// TransactionFile is the format used for exporting and importing transactions.
+//
+// It can be encoded either as CBOR or as JSON and besides the transaction itself carries the
+// identity of the network and ParaTime that the transaction is meant for.
+type TransactionFile struct {
+	// Version is the version of the transaction file format.
+	Version uint16 `json:"v"`
+	// ChainContext is the chain domain separation context of the network.
+	ChainContext string `json:"chain_context"`
+	// ParaTime is the hex-encoded identifier of the ParaTime. It is empty for consensus layer
+	// transactions.
+	ParaTime string `json:"paratime,omitempty"`
+	// Signed is a flag indicating whether the transaction has been signed.
+	Signed bool `json:"signed"`
+	// Transaction is the CBOR-encoded transaction.
+	//
+	// Depending on the layer and whether the transaction has been signed it is one of
+	// consensus Transaction, consensus SignedTransaction, ParaTime Transaction or ParaTime
+	// UnverifiedTransaction.
+	Transaction []byte `json:"transaction"`
+}
+
+// NewTransactionFile wraps the given transaction into a transaction file for the given network
+// and ParaTime selection.
+func NewTransactionFile(npa *NPASelection, tx interface{}) (*TransactionFile, error) {
+	tf := TransactionFile{
+		Version:      TransactionFileVersion,
+		ChainContext: npa.Network.ChainContext,
+	}
+
+	switch tx.(type) {
+	case *consensusTx.Transaction:
+	case *consensusTx.SignedTransaction:
+		tf.Signed = true
+	case *types.Transaction, *types.UnverifiedTransaction:
+		if npa.ParaTime == nil {
+			return nil, fmt.Errorf("no paratime selected for paratime transaction")
+		}
+		tf.ParaTime = npa.ParaTime.ID
+		_, tf.Signed = tx.(*types.UnverifiedTransaction)
+	default:
+		return nil, fmt.Errorf("unsupported transaction kind: %T", tx)
+	}
+	tf.Transaction = cbor.Marshal(tx)
+
+	return &tf, nil
+}
+
+// IsConsensus returns true iff the transaction file contains a consensus layer transaction.
+func (tf *TransactionFile) IsConsensus() bool {
+	return tf.ParaTime == ""
+}
+
+// Validate performs basic validation of the transaction file.
+func (tf *TransactionFile) Validate() error {
+	if tf.Version != TransactionFileVersion {
+		return fmt.Errorf("unsupported transaction file version: %d", tf.Version)
+	}
+	if tf.ChainContext == "" {
+		return fmt.Errorf("missing chain context")
+	}
+	if len(tf.Transaction) == 0 {
+		return fmt.Errorf("missing transaction")
+	}
+	return nil
+}
+
+// GetTransaction decodes the transaction contained in the transaction file.
+func (tf *TransactionFile) GetTransaction() (interface{}, error) {
+	var tx interface{}
+	switch {
+	case tf.IsConsensus() && tf.Signed:
+		tx = &consensusTx.SignedTransaction{}
+	case tf.IsConsensus():
+		tx = &consensusTx.Transaction{}
+	case tf.Signed:
+		tx = &types.UnverifiedTransaction{}
+	default:
+		tx = &types.Transaction{}
+	}
+
+	if err := cbor.Unmarshal(tf.Transaction, tx); err != nil {
+		return nil, fmt.Errorf("malformed transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// GetNPASelection returns the network/paratime/account selection matching the transaction file.
+//
+// The user-selected network and paratime are preferred in case they match the transaction file,
+// otherwise they are looked up among the configured networks and paratimes.
+func (tf *TransactionFile) GetNPASelection(cfg *cliConfig.Config) (*NPASelection, error) {
+	npa := GetNPASelection(cfg)
+
+	if npa.Network.ChainContext != tf.ChainContext {
+		npa.NetworkName, npa.Network = "", nil
+		var names []string
+		for name := range cfg.Networks.All {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if net := cfg.Networks.All[name]; net.ChainContext == tf.ChainContext {
+				npa.NetworkName, npa.Network = name, net
+				break
+			}
+		}
+		if npa.Network == nil {
+			return nil, fmt.Errorf("no configured network with chain context '%s'", tf.ChainContext)
+		}
+	}
+
+	switch {
+	case tf.IsConsensus():
+		npa.ParaTimeName, npa.ParaTime = "", nil
+	case npa.ParaTime != nil && npa.ParaTime.ID == tf.ParaTime && npa.Network.ParaTimes.All[npa.ParaTimeName] == npa.ParaTime:
+		// Selected paratime matches.
+	default:
+		npa.ParaTimeName, npa.ParaTime = "", nil
+		var names []string
+		for name := range npa.Network.ParaTimes.All {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if pt := npa.Network.ParaTimes.All[name]; pt.ID == tf.ParaTime {
+				npa.ParaTimeName, npa.ParaTime = name, pt
+				break
+			}
+		}
+		if npa.ParaTime == nil {
+			return nil, fmt.Errorf("no paratime with ID '%s' configured in network '%s'", tf.ParaTime, npa.NetworkName)
+		}
+	}
+
+	return npa, nil
+}
+
+// Save writes the transaction file to the given file.
+//
+// Files with the TransactionFileExtCBOR extension are CBOR-encoded, all others are JSON-encoded.
+func (tf *TransactionFile) Save(filename string) error {
+	var data []byte
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case TransactionFileExtCBOR:
+		data = cbor.Marshal(tf)
+	default:
+		var err error
+		if data, err = PrettyJSONMarshal(tf); err != nil {
+			return err
+		}
+	}
+
+	if err := os.WriteFile(filename, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write transaction file: %w", err)
+	}
+	return nil
+}
+
+// LoadTransactionFile loads a transaction file, automatically detecting its encoding.
+func LoadTransactionFile(filename string) (*TransactionFile, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction file: %w", err)
+	}
+
+	var tf TransactionFile
+	switch trimmed := bytes.TrimSpace(data); {
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		err = json.Unmarshal(trimmed, &tf)
+	default:
+		err = cbor.Unmarshal(data, &tf)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("malformed transaction file: %w", err)
+	}
+	if err = tf.Validate(); err != nil {
+		return nil, fmt.Errorf("malformed transaction file: %w", err)
+	}
+	return &tf, nil
+}
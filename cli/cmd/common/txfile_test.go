@@ -0,0 +1,128 @@
+package common
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	coreSignature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensusTx "github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+
+	cliConfig "github.com/oasisprotocol/oasis-sdk/cli/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestTransactionFile(t *testing.T) {
+	require := require.New(t)
+
+	cfg := &cliConfig.Config{Networks: config.DefaultNetworks}
+	npa := GetNPASelection(cfg)
+	require.NotNil(npa.ParaTime, "default paratime should be selected")
+
+	consensusSigner := sdkTesting.Alice.Signer.(interface {
+		Unwrap() coreSignature.Signer
+	}).Unwrap()
+
+	cTx := consensusTx.NewTransaction(1, &consensusTx.Fee{Gas: 1000}, staking.MethodTransfer, &staking.Transfer{
+		To:     sdkTesting.Bob.Address.ConsensusAddress(),
+		Amount: *quantity.NewFromUint64(1000),
+	})
+	sigCTx, err := signConsensusTransaction(npa.Network, consensusSigner, cTx)
+	require.NoError(err, "signConsensusTransaction")
+
+	ptx := accounts.NewTransferTx(&types.Fee{Gas: 1000}, &accounts.Transfer{
+		To:     sdkTesting.Bob.Address,
+		Amount: types.NewBaseUnits(*quantity.NewFromUint64(1000), types.NativeDenomination),
+	})
+	ptx.AppendAuthSignature(sdkTesting.Alice.SigSpec, 1)
+	sigPtx, err := signParaTimeTransaction(npa.Network, npa.ParaTime, sdkTesting.Alice.Signer, ptx)
+	require.NoError(err, "signParaTimeTransaction")
+
+	dir := t.TempDir()
+	for _, tc := range []struct {
+		tx        interface{}
+		consensus bool
+		signed    bool
+	}{
+		{cTx, true, false},
+		{sigCTx, true, true},
+		{ptx, false, false},
+		{sigPtx, false, true},
+	} {
+		tf, err := NewTransactionFile(npa, tc.tx)
+		require.NoError(err, "NewTransactionFile")
+		require.Equal(tc.consensus, tf.IsConsensus())
+		require.Equal(tc.signed, tf.Signed)
+
+		for _, fn := range []string{"tx.json", "tx.cbor"} {
+			fn = filepath.Join(dir, fn)
+			err = tf.Save(fn)
+			require.NoError(err, "Save")
+
+			loaded, err := LoadTransactionFile(fn)
+			require.NoError(err, "LoadTransactionFile")
+			require.EqualValues(tf, loaded, "loaded transaction file should be the same")
+
+			loadedNpa, err := loaded.GetNPASelection(cfg)
+			require.NoError(err, "GetNPASelection")
+			require.Equal(npa.NetworkName, loadedNpa.NetworkName)
+			if tc.consensus {
+				require.Nil(loadedNpa.ParaTime)
+			} else {
+				require.Equal(npa.ParaTimeName, loadedNpa.ParaTimeName)
+			}
+
+			tx, err := loaded.GetTransaction()
+			require.NoError(err, "GetTransaction")
+			require.EqualValues(tc.tx, tx, "decoded transaction should be the same")
+
+			if tc.signed {
+				_, signers, err := OpenSignedTransaction(loadedNpa, tx)
+				require.NoError(err, "OpenSignedTransaction")
+				require.Len(signers, 1)
+			}
+		}
+	}
+
+	// Transactions for an unknown network should be rejected.
+	tf, err := NewTransactionFile(npa, sigCTx)
+	require.NoError(err, "NewTransactionFile")
+	tf.ChainContext = "unknown"
+	_, err = tf.GetNPASelection(cfg)
+	require.Error(err, "GetNPASelection should fail for unknown networks")
+
+	// Signatures for a different network should not verify.
+	testnet := *npa
+	testnet.Network = cfg.Networks.All["testnet"]
+	_, _, err = OpenSignedTransaction(&testnet, sigCTx)
+	require.Error(err, "OpenSignedTransaction should fail for a different network")
+}
+
+func TestCheckParaTimeSigner(t *testing.T) {
+	require := require.New(t)
+
+	tx := accounts.NewTransferTx(&types.Fee{Gas: 1000}, &accounts.Transfer{
+		To:     sdkTesting.Bob.Address,
+		Amount: types.NewBaseUnits(*quantity.NewFromUint64(1000), types.NativeDenomination),
+	})
+	require.Error(checkParaTimeSigner(sdkTesting.Alice.SigSpec, sdkTesting.Alice.Signer.Public(), tx), "transaction without signers")
+
+	tx.AppendAuthSignature(sdkTesting.Alice.SigSpec, 1)
+	require.NoError(checkParaTimeSigner(sdkTesting.Alice.SigSpec, sdkTesting.Alice.Signer.Public(), tx), "expected signer")
+	require.Error(checkParaTimeSigner(sdkTesting.Bob.SigSpec, sdkTesting.Bob.Signer.Public(), tx), "unexpected signer")
+
+	tx.AppendAuthMultisig(&types.MultisigConfig{
+		Signers: []types.MultisigSigner{
+			{PublicKey: types.PublicKey{PublicKey: sdkTesting.Charlie.Signer.Public()}, Weight: 1},
+		},
+		Threshold: 1,
+	}, 1)
+	require.NoError(checkParaTimeSigner(sdkTesting.Charlie.SigSpec, sdkTesting.Charlie.Signer.Public(), tx), "expected multisig signer")
+	require.Error(checkParaTimeSigner(sdkTesting.Bob.SigSpec, sdkTesting.Bob.Signer.Public(), tx), "unexpected signer")
+}
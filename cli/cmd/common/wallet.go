@@ -27,9 +27,9 @@ func LoadAccount(cfg *config.Config, name string) wallet.Account {
 	var passphrase string
 	if af.RequiresPassphrase() {
 		// Ask for passphrase to decrypt the account.
-		fmt.Printf("Unlock your account.\n")
+		Infof("Unlock your account.\n")
 
-		err = survey.AskOne(PromptPassphrase, &passphrase)
+		err = survey.AskOne(PromptPassphrase, &passphrase, SurveyStdio())
 		cobra.CheckErr(err)
 	}
 
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -27,6 +29,7 @@ var (
 	contractsInstantiatePolicy string
 	contractsUpgradesPolicy    string
 	contractsTokens            []string
+	contractsSchemaFile        string
 
 	contractsCmd = &cobra.Command{
 		Use:   "contracts",
@@ -56,10 +59,12 @@ var (
 			inst, err := conn.Runtime(npa.ParaTime).Contracts.Instance(ctx, client.RoundLatest, contracts.InstanceID(instanceID))
 			cobra.CheckErr(err)
 
-			fmt.Printf("ID:              %d\n", inst.ID)
-			fmt.Printf("Code ID:         %d\n", inst.CodeID)
-			fmt.Printf("Creator:         %s\n", inst.Creator)
-			fmt.Printf("Upgrades policy: %s\n", formatPolicy(&inst.UpgradesPolicy))
+			common.Output(inst, func() {
+				fmt.Printf("ID:              %d\n", inst.ID)
+				fmt.Printf("Code ID:         %d\n", inst.CodeID)
+				fmt.Printf("Creator:         %s\n", inst.Creator)
+				fmt.Printf("Upgrades policy: %s\n", formatPolicy(&inst.UpgradesPolicy))
+			})
 		},
 	}
 
@@ -86,11 +91,13 @@ var (
 			code, err := conn.Runtime(npa.ParaTime).Contracts.Code(ctx, client.RoundLatest, contracts.CodeID(codeID))
 			cobra.CheckErr(err)
 
-			fmt.Printf("ID:                 %d\n", code.ID)
-			fmt.Printf("Hash:               %s\n", code.Hash)
-			fmt.Printf("ABI:                %s\n", code.ABI)
-			fmt.Printf("Uploader:           %s\n", code.Uploader)
-			fmt.Printf("Instantiate policy: %s\n", formatPolicy(&code.InstantiatePolicy))
+			common.Output(code, func() {
+				fmt.Printf("ID:                 %d\n", code.ID)
+				fmt.Printf("Hash:               %s\n", code.Hash)
+				fmt.Printf("ABI:                %s\n", code.ABI)
+				fmt.Printf("Uploader:           %s\n", code.Uploader)
+				fmt.Printf("Instantiate policy: %s\n", formatPolicy(&code.InstantiatePolicy))
+			})
 		},
 	}
 
@@ -139,9 +146,9 @@ var (
 			cobra.CheckErr(err)
 
 			var result contracts.UploadResult
-			common.BroadcastTransaction(ctx, npa.ParaTime, conn, sigTx, meta, &result)
+			common.BroadcastTransaction(ctx, npa, conn, sigTx, meta, &result)
 
-			if txCfg.Offline {
+			if txCfg.Export || common.IsStructuredOutput() {
 				return
 			}
 
@@ -150,7 +157,7 @@ var (
 	}
 
 	contractsInstantiateCmd = &cobra.Command{
-		Use:     "instantiate <code-id> <data-yaml> [--tokens TOKENS] [--upgrades-policy POLICY]",
+		Use:     "instantiate <code-id> <data-yaml> [--tokens TOKENS] [--upgrades-policy POLICY] [--schema SCHEMA]",
 		Aliases: []string{"inst"},
 		Short:   "Instantiate WebAssembly smart contract",
 		Args:    cobra.ExactArgs(2),
@@ -172,7 +179,7 @@ var (
 			cobra.CheckErr(err)
 
 			// Parse instantiation arguments.
-			data := parseData(strData)
+			data := validateData(contracts.RequestInstantiate, parseData(strData))
 
 			// When not in offline mode, connect to the given network endpoint.
 			ctx := context.Background()
@@ -201,9 +208,9 @@ var (
 			cobra.CheckErr(err)
 
 			var result contracts.InstantiateResult
-			common.BroadcastTransaction(ctx, npa.ParaTime, conn, sigTx, meta, &result)
+			common.BroadcastTransaction(ctx, npa, conn, sigTx, meta, &result)
 
-			if txCfg.Offline {
+			if txCfg.Export || common.IsStructuredOutput() {
 				return
 			}
 
@@ -212,7 +219,7 @@ var (
 	}
 
 	contractsCallCmd = &cobra.Command{
-		Use:   "call <instance-id> <data-yaml> [--tokens TOKENS]",
+		Use:   "call <instance-id> <data-yaml> [--tokens TOKENS] [--schema SCHEMA]",
 		Short: "Call WebAssembly smart contract",
 		Args:  cobra.ExactArgs(2),
 		Run: func(cmd *cobra.Command, args []string) {
@@ -233,7 +240,7 @@ var (
 			cobra.CheckErr(err)
 
 			// Parse call arguments.
-			data := parseData(strData)
+			data := validateData(contracts.RequestCall, parseData(strData))
 
 			// When not in offline mode, connect to the given network endpoint.
 			ctx := context.Background()
@@ -257,28 +264,252 @@ var (
 			sigTx, meta, err := common.SignParaTimeTransaction(ctx, npa, acc, conn, tx)
 			cobra.CheckErr(err)
 
-			var result contracts.CallResult
-			common.BroadcastTransaction(ctx, npa.ParaTime, conn, sigTx, meta, &result)
+			var result contractCallResult
+			common.BroadcastTransaction(ctx, npa, conn, sigTx, meta, &result)
 
-			if txCfg.Offline {
+			if txCfg.Export || common.IsStructuredOutput() {
 				return
 			}
 
 			fmt.Printf("Call result:\n")
+			printContractResult(result)
+		},
+	}
+
+	contractsUpgradeCmd = &cobra.Command{
+		Use:   "upgrade <instance-id> <code-id> <data-yaml> [--tokens TOKENS]",
+		Short: "Upgrade WebAssembly smart contract instance to new code",
+		Args:  cobra.ExactArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			txCfg := common.GetTransactionConfig()
+			strInstanceID := args[0]
+			strCodeID := args[1]
+			strData := args[2]
+
+			if npa.Account == nil {
+				cobra.CheckErr("no accounts configured in your wallet")
+			}
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no paratimes configured")
+			}
+
+			instanceID, err := strconv.ParseUint(strInstanceID, 10, 64)
+			cobra.CheckErr(err)
+			codeID, err := strconv.ParseUint(strCodeID, 10, 64)
+			cobra.CheckErr(err)
+
+			// Parse upgrade arguments.
+			data := parseData(strData)
+
+			// When not in offline mode, connect to the given network endpoint.
+			ctx := context.Background()
+			var conn connection.Connection
+			if !txCfg.Offline {
+				conn, err = connection.Connect(ctx, npa.Network)
+				cobra.CheckErr(err)
+			}
+
+			// Parse tokens that should be sent to the contract.
+			tokens := parseTokens(npa.ParaTime, contractsTokens)
+
+			// Prepare transaction.
+			tx := contracts.NewUpgradeTx(nil, &contracts.Upgrade{
+				ID:     contracts.InstanceID(instanceID),
+				CodeID: contracts.CodeID(codeID),
+				Data:   cbor.Marshal(data),
+				Tokens: tokens,
+			})
+
+			acc := common.LoadAccount(cfg, npa.AccountName)
+			sigTx, meta, err := common.SignParaTimeTransaction(ctx, npa, acc, conn, tx)
+			cobra.CheckErr(err)
+
+			common.BroadcastTransaction(ctx, npa, conn, sigTx, meta, nil)
+		},
+	}
+
+	contractsQueryCmd = &cobra.Command{
+		Use:   "query <instance-id> <data-yaml> [--schema SCHEMA]",
+		Short: "Query WebAssembly smart contract",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			strInstanceID := args[0]
+			strData := args[1]
+
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no paratimes configured")
+			}
+
+			instanceID, err := strconv.ParseUint(strInstanceID, 10, 64)
+			cobra.CheckErr(err)
+
+			// Parse query arguments.
+			data := validateData(contracts.RequestQuery, parseData(strData))
+
+			ctx := context.Background()
+			conn, err := connection.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
 
-			var decResult interface{}
-			err = cbor.Unmarshal(result, &decResult)
-			if err != nil {
-				cobra.CheckErr(fmt.Errorf("failed to unmarshal call result: %w", err))
+			result, err := conn.Runtime(npa.ParaTime).Contracts.CustomRaw(ctx, common.GetRound(), contracts.InstanceID(instanceID), cbor.Marshal(data))
+			cobra.CheckErr(err)
+
+			common.Output(contractCallResult(result), func() {
+				printContractResult(result)
+			})
+		},
+	}
+
+	contractsStorageCmd = &cobra.Command{
+		Use:   "storage <instance-id> <key>",
+		Short: "Show WebAssembly smart contract storage value",
+		Long: "Show the value stored under the given key in the contract's public storage. Keys " +
+			"starting with 0x are treated as hex-encoded, others are used as-is.\n\n" +
+			"Only public storage can be queried as the runtime does not support querying " +
+			"confidential storage, which is encrypted with keys only available inside the runtime. " +
+			"Use a contract query to access confidential state instead.",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			strInstanceID := args[0]
+			key := []byte(args[1])
+
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no paratimes configured")
 			}
 
-			formatted, err := yaml.Marshal(decResult)
+			instanceID, err := strconv.ParseUint(strInstanceID, 10, 64)
 			cobra.CheckErr(err)
-			fmt.Println(string(formatted))
+
+			if strings.HasPrefix(args[1], "0x") {
+				key, err = hex.DecodeString(args[1][2:])
+				if err != nil {
+					cobra.CheckErr(fmt.Errorf("malformed hex-encoded key: %w", err))
+				}
+			}
+
+			ctx := context.Background()
+			conn, err := connection.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			res, err := conn.Runtime(npa.ParaTime).Contracts.InstanceStorage(ctx, common.GetRound(), contracts.InstanceID(instanceID), key)
+			cobra.CheckErr(err)
+
+			common.Output(res, func() {
+				if res.Value == nil {
+					fmt.Println("<not found>")
+					return
+				}
+				fmt.Printf("Value: 0x%s\n", hex.EncodeToString(res.Value))
+				// Values are usually CBOR-encoded by contracts using the Oasis ABI.
+				if _, err := common.DecodeCBORGeneric(res.Value); err == nil {
+					fmt.Printf("Decoded value:\n")
+					printContractResult(res.Value)
+				}
+			})
+		},
+	}
+
+	contractsEventsCmd = &cobra.Command{
+		Use:   "events <instance-id>",
+		Short: "Watch events emitted by WebAssembly smart contract",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			strInstanceID := args[0]
+
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no paratimes configured")
+			}
+
+			instanceID, err := strconv.ParseUint(strInstanceID, 10, 64)
+			cobra.CheckErr(err)
+
+			ctx := context.Background()
+			conn, err := connection.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			rt := conn.Runtime(npa.ParaTime)
+			ch, sub, err := rt.WatchBlocks(ctx)
+			cobra.CheckErr(err)
+			defer sub.Close()
+
+			for blk := range ch {
+				round := blk.Block.Header.Round
+				rawEvs, err := rt.GetEventsRaw(ctx, round)
+				cobra.CheckErr(err)
+
+				for _, rawEv := range rawEvs {
+					evs, err := contracts.DecodeEvent(rawEv)
+					if err != nil {
+						// Non-fatal, a single malformed event should not stop watching.
+						common.Infof("Warning: failed to decode event in round %d: %v\n", round, err)
+						continue
+					}
+
+					for _, ev := range evs {
+						cev := ev.(*contracts.Event)
+						if cev.ID != contracts.InstanceID(instanceID) {
+							continue
+						}
+
+						out := &contractEvent{
+							Round:  round,
+							Module: rawEv.Module,
+							Code:   rawEv.Code,
+							Data:   contractCallResult(cev.Data),
+						}
+						common.Output(out, func() {
+							fmt.Printf("Round:  %d\n", out.Round)
+							fmt.Printf("Module: %s\n", out.Module)
+							fmt.Printf("Code:   %d\n", out.Code)
+							fmt.Printf("Data:\n")
+							printContractResult(cev.Data)
+						})
+					}
+				}
+			}
 		},
 	}
 )
 
+// contractEvent is a contract event as shown by the events command.
+type contractEvent struct {
+	Round  uint64             `json:"round"`
+	Module string             `json:"module"`
+	Code   uint32             `json:"code"`
+	Data   contractCallResult `json:"data"`
+}
+
+// contractCallResult is the result of a contract call which is decoded for structured output.
+type contractCallResult contracts.CallResult
+
+// MarshalJSON implements json.Marshaler.
+func (cr contractCallResult) MarshalJSON() ([]byte, error) {
+	decResult, err := common.DecodeCBORGeneric(cr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal call result: %w", err)
+	}
+	return json.Marshal(decResult)
+}
+
+// printContractResult prints the given CBOR-encoded contract result as YAML.
+func printContractResult(result []byte) {
+	var decResult interface{}
+	if err := cbor.Unmarshal(result, &decResult); err != nil {
+		cobra.CheckErr(fmt.Errorf("failed to unmarshal call result: %w", err))
+	}
+
+	formatted, err := yaml.Marshal(decResult)
+	cobra.CheckErr(err)
+	fmt.Println(string(formatted))
+}
+
 func formatPolicy(policy *contracts.Policy) string {
 	switch {
 	case policy.Nobody != nil:
@@ -303,7 +534,7 @@ func parsePolicy(net *config.Network, wallet *cliConfig.Account, policy string)
 		return &contracts.Policy{Address: &address}
 	case strings.HasPrefix(policy, "address:"):
 		policy = strings.TrimPrefix(policy, "address:")
-		address, err := helpers.ResolveAddress(net, policy)
+		address, err := common.ResolveLocalAccountOrAddress(net, policy)
 		if err != nil {
 			cobra.CheckErr(fmt.Errorf("malformed address in policy: %w", err))
 		}
@@ -323,6 +554,23 @@ func parseData(data string) interface{} {
 	return result
 }
 
+// validateData validates the given request data against the contract schema in case one has been
+// given and converts it into the form expected by the contract.
+func validateData(kind contracts.RequestKind, data interface{}) interface{} {
+	if contractsSchemaFile == "" {
+		return data
+	}
+
+	rawSchema, err := os.ReadFile(contractsSchemaFile)
+	cobra.CheckErr(err)
+	schema, err := contracts.ParseSchema(rawSchema)
+	cobra.CheckErr(err)
+
+	data, err = schema.EncodeRequest(kind, data)
+	cobra.CheckErr(err)
+	return data
+}
+
 func parseTokens(pt *config.ParaTime, tokens []string) []types.BaseUnits {
 	result := []types.BaseUnits{}
 	for _, raw := range tokens {
@@ -351,6 +599,9 @@ func init() {
 	contractsCallFlags := flag.NewFlagSet("", flag.ContinueOnError)
 	contractsCallFlags.StringSliceVar(&contractsTokens, "tokens", []string{}, "token amounts to send to a contract")
 
+	contractsSchemaFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	contractsSchemaFlags.StringVar(&contractsSchemaFile, "schema", "", "contract schema to validate the data against")
+
 	contractsInstantiateFlags := flag.NewFlagSet("", flag.ContinueOnError)
 	contractsInstantiateFlags.StringVar(&contractsUpgradesPolicy, "upgrades-policy", "owner", "contract upgrades policy")
 
@@ -358,14 +609,33 @@ func init() {
 	contractsInstantiateCmd.Flags().AddFlagSet(common.TransactionFlags)
 	contractsInstantiateCmd.Flags().AddFlagSet(contractsInstantiateFlags)
 	contractsInstantiateCmd.Flags().AddFlagSet(contractsCallFlags)
+	contractsInstantiateCmd.Flags().AddFlagSet(contractsSchemaFlags)
 
 	contractsCallCmd.Flags().AddFlagSet(common.SelectorFlags)
 	contractsCallCmd.Flags().AddFlagSet(common.TransactionFlags)
 	contractsCallCmd.Flags().AddFlagSet(contractsCallFlags)
+	contractsCallCmd.Flags().AddFlagSet(contractsSchemaFlags)
+
+	contractsUpgradeCmd.Flags().AddFlagSet(common.SelectorFlags)
+	contractsUpgradeCmd.Flags().AddFlagSet(common.TransactionFlags)
+	contractsUpgradeCmd.Flags().AddFlagSet(contractsCallFlags)
+
+	contractsQueryCmd.Flags().AddFlagSet(common.SelectorFlags)
+	contractsQueryCmd.Flags().AddFlagSet(common.RoundFlag)
+	contractsQueryCmd.Flags().AddFlagSet(contractsSchemaFlags)
+
+	contractsStorageCmd.Flags().AddFlagSet(common.SelectorFlags)
+	contractsStorageCmd.Flags().AddFlagSet(common.RoundFlag)
+
+	contractsEventsCmd.Flags().AddFlagSet(common.SelectorFlags)
 
 	contractsCmd.AddCommand(contractsShowCmd)
 	contractsCmd.AddCommand(contractsShowCodeCmd)
 	contractsCmd.AddCommand(contractsUploadCmd)
 	contractsCmd.AddCommand(contractsInstantiateCmd)
 	contractsCmd.AddCommand(contractsCallCmd)
+	contractsCmd.AddCommand(contractsUpgradeCmd)
+	contractsCmd.AddCommand(contractsQueryCmd)
+	contractsCmd.AddCommand(contractsStorageCmd)
+	contractsCmd.AddCommand(contractsEventsCmd)
 }
@@ -0,0 +1,593 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+
+	"github.com/oasisprotocol/oasis-sdk/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/oasis-sdk/cli/config"
+	"github.com/oasisprotocol/oasis-sdk/cli/wallet"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+var (
+	evmABIFile     string
+	evmValue       string
+	evmCaller      string
+	evmGasLimit    uint64
+	evmLayoutFile  string
+	evmMaxElements uint64
+
+	evmCmd = &cobra.Command{
+		Use:   "evm",
+		Short: "Ethereum Virtual Machine smart contracts operations",
+	}
+
+	evmDeployCmd = &cobra.Command{
+		Use:   "deploy <bytecode> [<constructor-args>...] [--abi ABI] [--value AMOUNT]",
+		Short: "Deploy EVM smart contract",
+		Long: "Deploy EVM smart contract. The bytecode is given either in hex or as a path to a file " +
+			"containing the hex-encoded bytecode. In case an ABI is given, the remaining arguments are " +
+			"encoded as constructor arguments.",
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			txCfg := common.GetTransactionConfig()
+
+			if npa.Account == nil {
+				cobra.CheckErr("no accounts configured in your wallet")
+			}
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no paratimes configured")
+			}
+
+			initCode := parseBytecode(args[0])
+			contractABI := loadEVMABI()
+			switch {
+			case contractABI != nil:
+				ctorArgs, err := evm.ParseArgs(contractABI.Constructor.Inputs, args[1:])
+				cobra.CheckErr(err)
+				packed, err := contractABI.Pack("", ctorArgs...)
+				cobra.CheckErr(err)
+				initCode = append(initCode, packed...)
+			case len(args) > 1:
+				cobra.CheckErr("constructor arguments require an ABI")
+			}
+
+			// When not in offline mode, connect to the given network endpoint.
+			ctx := context.Background()
+			var conn connection.Connection
+			if !txCfg.Offline {
+				var err error
+				conn, err = connection.Connect(ctx, npa.Network)
+				cobra.CheckErr(err)
+			}
+
+			// Prepare transaction.
+			tx := evm.NewV1(nil).Create(parseEVMValue(npa.ParaTime), initCode).GetTransaction()
+
+			acc := loadEVMAccount(cfg, npa.AccountName)
+			sigTx, meta, err := common.SignParaTimeTransaction(ctx, npa, acc, conn, tx)
+			cobra.CheckErr(err)
+
+			// The deployed address is part of the transaction result in structured output.
+			var result evmDeployResult
+			common.BroadcastEVMTransaction(ctx, npa, conn, sigTx, meta, &result, contractABI)
+
+			if txCfg.Export || common.IsStructuredOutput() {
+				return
+			}
+
+			fmt.Printf("Contract address: %s\n", result.Address)
+			fmt.Printf("Native address:   %s\n", result.NativeAddress)
+		},
+	}
+
+	evmCallCmd = &cobra.Command{
+		Use:   "call <address> <data | method> [<args>...] [--abi ABI] [--value AMOUNT]",
+		Short: "Call EVM smart contract",
+		Long: "Call EVM smart contract. Without an ABI the call data is given in hex, otherwise the " +
+			"method name and its arguments are given and the result is decoded.",
+		Args: cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+			txCfg := common.GetTransactionConfig()
+
+			if npa.Account == nil {
+				cobra.CheckErr("no accounts configured in your wallet")
+			}
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no paratimes configured")
+			}
+
+			address := resolveEthAddress(args[0])
+			contractABI := loadEVMABI()
+			method, data := parseEVMCallData(contractABI, args[1:])
+
+			// When not in offline mode, connect to the given network endpoint.
+			ctx := context.Background()
+			var conn connection.Connection
+			if !txCfg.Offline {
+				var err error
+				conn, err = connection.Connect(ctx, npa.Network)
+				cobra.CheckErr(err)
+			}
+
+			// Prepare transaction.
+			tx := evm.NewV1(nil).Call(address.Bytes(), parseEVMValue(npa.ParaTime), data).GetTransaction()
+
+			acc := loadEVMAccount(cfg, npa.AccountName)
+			sigTx, meta, err := common.SignParaTimeTransaction(ctx, npa, acc, conn, tx)
+			cobra.CheckErr(err)
+
+			var result evmData
+			common.BroadcastEVMTransaction(ctx, npa, conn, sigTx, meta, &result, contractABI)
+
+			if txCfg.Export || common.IsStructuredOutput() {
+				return
+			}
+
+			printEVMResult(method, result)
+		},
+	}
+
+	evmSimulateCmd = &cobra.Command{
+		Use:   "simulate <address> <data | method> [<args>...] [--abi ABI] [--value AMOUNT] [--caller ADDRESS]",
+		Short: "Simulate EVM smart contract call",
+		Long: "Simulate EVM smart contract call without submitting a transaction. Without an ABI the " +
+			"call data is given in hex, otherwise the method name and its arguments are given and " +
+			"the result is decoded.",
+		Args: cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no paratimes configured")
+			}
+
+			address := resolveEthAddress(args[0])
+			contractABI := loadEVMABI()
+			method, data := parseEVMCallData(contractABI, args[1:])
+
+			var caller ethCommon.Address
+			if evmCaller != "" {
+				caller = resolveEthAddress(evmCaller)
+			}
+
+			ctx := context.Background()
+			conn, err := connection.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			result, err := conn.Runtime(npa.ParaTime).EVM.SimulateCall(
+				ctx,
+				common.GetRound(),
+				make([]byte, 32),
+				evmGasLimit,
+				caller.Bytes(),
+				address.Bytes(),
+				parseEVMValue(npa.ParaTime),
+				data,
+			)
+			if rev := evm.DecodeRevert(err, contractABI); rev != nil {
+				err = rev
+			}
+			cobra.CheckErr(err)
+
+			printEVMResult(method, result)
+		},
+	}
+
+	evmStorageCmd = &cobra.Command{
+		Use:   "storage <address> [<slot | variable>] [--layout LAYOUT]",
+		Short: "Show EVM contract storage",
+		Long: "Show EVM contract storage. Without a storage layout the raw value of the given slot is " +
+			"shown. In case a Solidity storage layout is given, the given state variable (e.g. " +
+			"balances[0x5FbDB...] or users[1].name) or all non-mapping state variables are decoded.",
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no paratimes configured")
+			}
+
+			address := resolveEthAddress(args[0])
+
+			if evmLayoutFile != "" {
+				showEVMStorageLayout(npa, address, args[1:])
+				return
+			}
+			if len(args) < 2 {
+				cobra.CheckErr("storage slot must be specified when no storage layout is given")
+			}
+
+			slot, ok := new(big.Int).SetString(args[1], 0)
+			if !ok || slot.Sign() < 0 || slot.BitLen() > 256 {
+				cobra.CheckErr(fmt.Sprintf("malformed storage slot: %s", args[1]))
+			}
+
+			ctx := context.Background()
+			conn, err := connection.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			value, err := conn.Runtime(npa.ParaTime).EVM.Storage(ctx, common.GetRound(), address.Bytes(), ethCommon.BigToHash(slot).Bytes())
+			cobra.CheckErr(err)
+
+			out := evmData(ethCommon.LeftPadBytes(value, 32))
+			common.Output(out, func() {
+				fmt.Println(out)
+			})
+		},
+	}
+
+	evmCodeCmd = &cobra.Command{
+		Use:   "code <address>",
+		Short: "Show EVM contract runtime bytecode",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no paratimes configured")
+			}
+
+			address := resolveEthAddress(args[0])
+
+			ctx := context.Background()
+			conn, err := connection.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			code, err := conn.Runtime(npa.ParaTime).EVM.Code(ctx, common.GetRound(), address.Bytes())
+			cobra.CheckErr(err)
+
+			out := evmData(code)
+			common.Output(out, func() {
+				fmt.Println(out)
+			})
+		},
+	}
+
+	evmBalanceCmd = &cobra.Command{
+		Use:   "balance <address>",
+		Short: "Show EVM account balance",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			npa := common.GetNPASelection(cfg)
+
+			if npa.ParaTime == nil {
+				cobra.CheckErr("no paratimes configured")
+			}
+
+			address := resolveEthAddress(args[0])
+
+			ctx := context.Background()
+			conn, err := connection.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			balance, err := conn.Runtime(npa.ParaTime).EVM.Balance(ctx, common.GetRound(), address.Bytes())
+			cobra.CheckErr(err)
+
+			amount := types.NewBaseUnits(*balance, types.NativeDenomination)
+			common.Output(&amount, func() {
+				fmt.Printf("Balance: %s\n", helpers.FormatParaTimeDenomination(npa.ParaTime, amount))
+			})
+		},
+	}
+)
+
+// evmData is raw EVM data which is hex-encoded for output.
+type evmData []byte
+
+// String returns the 0x-prefixed hex representation of the data.
+func (d evmData) String() string {
+	return "0x" + hex.EncodeToString(d)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d evmData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// evmDeployResult is the result of an EVM contract deployment.
+type evmDeployResult struct {
+	Address       string        `json:"address"`
+	NativeAddress types.Address `json:"native_address"`
+}
+
+// UnmarshalCBOR decodes the contract address returned by the EVM module.
+func (r *evmDeployResult) UnmarshalCBOR(data []byte) error {
+	var address []byte
+	if err := cbor.Unmarshal(data, &address); err != nil {
+		return err
+	}
+	dep := evm.NewDeployment(address, 0)
+	r.Address = dep.Hex
+	r.NativeAddress = dep.NativeAddress
+	return nil
+}
+
+// evmCallOutput is the decoded result of an EVM call.
+type evmCallOutput struct {
+	Data   evmData           `json:"data"`
+	Method string            `json:"method,omitempty"`
+	Result map[string]string `json:"result,omitempty"`
+}
+
+// resolveEthAddress resolves a hex-encoded Ethereum address which may also be given as the name of
+// an account or a contact.
+func resolveEthAddress(address string) ethCommon.Address {
+	addr, err := common.ResolveLocalAccountOrEthAddress(address)
+	cobra.CheckErr(err)
+	return *addr
+}
+
+// loadEVMAccount loads the given account and makes sure it can sign EVM transactions.
+func loadEVMAccount(cfg *cliConfig.Config, name string) wallet.Account {
+	acc := common.LoadAccount(cfg, name)
+	if acc.SignatureAddressSpec().Secp256k1Eth == nil {
+		cobra.CheckErr(fmt.Sprintf("account '%s' is not an Ethereum-compatible secp256k1 account", name))
+	}
+	return acc
+}
+
+// showEVMStorageLayout decodes contract state variables using a Solidity storage layout.
+func showEVMStorageLayout(npa *common.NPASelection, address ethCommon.Address, paths []string) {
+	data, err := os.ReadFile(evmLayoutFile)
+	cobra.CheckErr(err)
+	layout, err := evm.ParseStorageLayout(data)
+	cobra.CheckErr(err)
+
+	// Show all state variables that can be read as a whole unless one is explicitly given.
+	if len(paths) == 0 {
+		for _, item := range layout.Storage {
+			if layout.Types[item.Type].Encoding == evm.StorageEncodingMapping {
+				continue
+			}
+			paths = append(paths, item.Label)
+		}
+	}
+
+	ctx := context.Background()
+	conn, err := connection.Connect(ctx, npa.Network)
+	cobra.CheckErr(err)
+
+	round := common.GetRound()
+	if round == client.RoundLatest {
+		// Make sure all variables are read at the same round.
+		blk, err := conn.Runtime(npa.ParaTime).GetBlock(ctx, client.RoundLatest)
+		cobra.CheckErr(err)
+		round = blk.Header.Round
+	}
+
+	si := evm.NewStorageInspector(conn.Runtime(npa.ParaTime).EVM, address.Bytes(), layout).SetMaxElements(evmMaxElements)
+	out := make(map[string]string)
+	for _, path := range paths {
+		v, err := si.Read(ctx, round, path)
+		cobra.CheckErr(err)
+		out[path] = formatEVMValue(v)
+	}
+
+	common.Output(out, func() {
+		fmt.Printf("Round: %d\n", round)
+		for _, path := range paths {
+			fmt.Printf("%s: %s\n", path, out[path])
+		}
+	})
+}
+
+// loadEVMABI loads the contract ABI if one has been specified.
+func loadEVMABI() *abi.ABI {
+	if evmABIFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(evmABIFile)
+	cobra.CheckErr(err)
+	contractABI, err := evm.ParseABI(data)
+	cobra.CheckErr(err)
+	return contractABI
+}
+
+// parseBytecode parses hex-encoded bytecode which may also be given as a path to a file.
+func parseBytecode(bytecode string) []byte {
+	if _, err := os.Stat(bytecode); err == nil {
+		data, err := os.ReadFile(bytecode)
+		cobra.CheckErr(err)
+		bytecode = strings.TrimSpace(string(data))
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(bytecode, "0x"))
+	if err != nil {
+		cobra.CheckErr(fmt.Errorf("malformed bytecode: %w", err))
+	}
+	return data
+}
+
+// parseEVMCallData returns the call data, either given in hex or packed using the ABI.
+func parseEVMCallData(contractABI *abi.ABI, args []string) (*abi.Method, []byte) {
+	if contractABI == nil {
+		if len(args) > 1 {
+			cobra.CheckErr("method arguments require an ABI")
+		}
+		data, err := hex.DecodeString(strings.TrimPrefix(args[0], "0x"))
+		if err != nil {
+			cobra.CheckErr(fmt.Errorf("malformed call data: %w", err))
+		}
+		return nil, data
+	}
+
+	method, ok := contractABI.Methods[args[0]]
+	if !ok {
+		cobra.CheckErr(fmt.Sprintf("method '%s' not found in ABI", args[0]))
+	}
+	methodArgs, err := evm.ParseArgs(method.Inputs, args[1:])
+	cobra.CheckErr(err)
+	data, err := contractABI.Pack(method.Name, methodArgs...)
+	cobra.CheckErr(err)
+	return &method, data
+}
+
+// parseEVMValue parses the amount of native tokens to send with the call.
+func parseEVMValue(pt *config.ParaTime) []byte {
+	value := make([]byte, 32)
+	if evmValue == "" {
+		return value
+	}
+	amount, err := helpers.ParseParaTimeDenomination(pt, evmValue, types.NativeDenomination)
+	cobra.CheckErr(err)
+	v := amount.Amount.ToBigInt()
+	if v.BitLen() > 256 {
+		cobra.CheckErr(fmt.Errorf("value '%s' does not fit into 256 bits", evmValue))
+	}
+	return v.FillBytes(value)
+}
+
+// printEVMResult outputs the result of an EVM call, decoding it in case the method is known.
+func printEVMResult(method *abi.Method, data []byte) {
+	out := evmCallOutput{Data: data}
+	if method != nil {
+		values, err := method.Outputs.Unpack(data)
+		cobra.CheckErr(err)
+
+		out.Method = method.Name
+		out.Result = make(map[string]string)
+		for i, output := range method.Outputs {
+			name := output.Name
+			if name == "" {
+				name = fmt.Sprintf("%d", i)
+			}
+			out.Result[name] = formatEVMValue(values[i])
+		}
+	}
+
+	common.Output(&out, func() {
+		if method == nil {
+			fmt.Printf("Result: %s\n", out.Data)
+			return
+		}
+		fmt.Printf("Result:\n")
+		for i, output := range method.Outputs {
+			name := output.Name
+			if name == "" {
+				name = fmt.Sprintf("%d", i)
+			}
+			fmt.Printf("  %s (%s): %s\n", name, output.Type, out.Result[name])
+		}
+	})
+}
+
+// formatEVMValue formats a decoded ABI value.
+func formatEVMValue(v interface{}) string {
+	switch vv := v.(type) {
+	case []byte:
+		return evmData(vv).String()
+	case ethCommon.Address:
+		return vv.Hex()
+	case *big.Int:
+		return vv.String()
+	case map[string]interface{}:
+		// Struct members.
+		names := make([]string, 0, len(vv))
+		for name := range vv {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		members := make([]string, 0, len(vv))
+		for _, name := range names {
+			members = append(members, fmt.Sprintf("%s: %s", name, formatEVMValue(vv[name])))
+		}
+		return "{" + strings.Join(members, ", ") + "}"
+	case nil:
+		return "<mapping>"
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			// Fixed-size byte arrays.
+			data := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(data), rv)
+			return evmData(data).String()
+		}
+		elems := make([]string, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			elems = append(elems, formatEVMValue(rv.Index(i).Interface()))
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func init() {
+	evmABIFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	evmABIFlags.StringVar(&evmABIFile, "abi", "", "path to the Solidity JSON ABI of the contract")
+
+	evmValueFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	evmValueFlags.StringVar(&evmValue, "value", "", "amount of native tokens to send to the contract")
+
+	evmSimulateFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	evmSimulateFlags.StringVar(&evmCaller, "caller", "", "address of the simulated caller")
+	evmSimulateFlags.Uint64Var(&evmGasLimit, "gas-limit", evm.DefaultSimulateCallGasLimit, "gas limit of the simulated call")
+
+	evmStorageFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	evmStorageFlags.StringVar(&evmLayoutFile, "layout", "", "path to the Solidity storage layout of the contract")
+	evmStorageFlags.Uint64Var(&evmMaxElements, "max-elements", evm.DefaultStorageMaxElements, "maximum number of array elements to decode with --layout")
+
+	evmDeployCmd.Flags().AddFlagSet(common.SelectorFlags)
+	evmDeployCmd.Flags().AddFlagSet(common.TransactionFlags)
+	evmDeployCmd.Flags().AddFlagSet(evmABIFlags)
+	evmDeployCmd.Flags().AddFlagSet(evmValueFlags)
+
+	evmCallCmd.Flags().AddFlagSet(common.SelectorFlags)
+	evmCallCmd.Flags().AddFlagSet(common.TransactionFlags)
+	evmCallCmd.Flags().AddFlagSet(evmABIFlags)
+	evmCallCmd.Flags().AddFlagSet(evmValueFlags)
+
+	evmSimulateCmd.Flags().AddFlagSet(common.SelectorFlags)
+	evmSimulateCmd.Flags().AddFlagSet(common.RoundFlag)
+	evmSimulateCmd.Flags().AddFlagSet(evmABIFlags)
+	evmSimulateCmd.Flags().AddFlagSet(evmValueFlags)
+	evmSimulateCmd.Flags().AddFlagSet(evmSimulateFlags)
+
+	evmStorageCmd.Flags().AddFlagSet(common.SelectorFlags)
+	evmStorageCmd.Flags().AddFlagSet(common.RoundFlag)
+	evmStorageCmd.Flags().AddFlagSet(evmStorageFlags)
+
+	evmCodeCmd.Flags().AddFlagSet(common.SelectorFlags)
+	evmCodeCmd.Flags().AddFlagSet(common.RoundFlag)
+
+	evmBalanceCmd.Flags().AddFlagSet(common.SelectorFlags)
+	evmBalanceCmd.Flags().AddFlagSet(common.RoundFlag)
+
+	evmCmd.AddCommand(evmDeployCmd)
+	evmCmd.AddCommand(evmCallCmd)
+	evmCmd.AddCommand(evmSimulateCmd)
+	evmCmd.AddCommand(evmStorageCmd)
+	evmCmd.AddCommand(evmCodeCmd)
+	evmCmd.AddCommand(evmBalanceCmd)
+}
@@ -40,11 +40,11 @@ var governanceProposalCmd = &cobra.Command{
 		cobra.CheckErr(err)
 
 		consensusConn := conn.Consensus()
-		governanceConn := consensusConn.Governance()
-		beaconConn := consensusConn.Beacon()
-		schedulerConn := consensusConn.Scheduler()
-		registryConn := consensusConn.Registry()
-		stakingConn := consensusConn.Staking()
+		governanceConn := conn.Governance()
+		beaconConn := conn.Beacon()
+		schedulerConn := conn.Scheduler()
+		registryConn := conn.Registry()
+		stakingConn := conn.Staking()
 
 		// Figure out the height to use if "latest".
 		height, err := common.GetActualHeight(
@@ -155,7 +155,13 @@ var governanceProposalCmd = &cobra.Command{
 			voters[vote.Voter] = *escrow.Clone()
 		}
 
-		// Display the high-level summary of the proposal status.
+		out := governanceProposalInfo{
+			ID:             proposalID,
+			Height:         height,
+			ClosesAt:       proposal.ClosesAt,
+			InvalidVotes:   invalidVotes,
+			StakeThreshold: governanceParams.StakeThreshold,
+		}
 
 		switch proposal.State {
 		case governance.StateActive:
@@ -174,59 +180,34 @@ var governanceProposalCmd = &cobra.Command{
 			)
 			cobra.CheckErr(err)
 
-			fmt.Println(
-				"Proposal active, vote outcome if ended now:",
-				proposal.State,
-			)
-			fmt.Printf(
-				"Voting ends in %d epochs\n",
-				proposal.ClosesAt-epoch,
-			)
+			out.Active = true
+			out.EpochsRemaining = proposal.ClosesAt - epoch
 		case governance.StatePassed, governance.StateFailed, governance.StateRejected:
-			fmt.Printf("Proposal %s, results: %v\n",
-				proposal.State,
-				proposal.Results,
-			)
 		default:
 			cobra.CheckErr(fmt.Errorf("unexpected proposal state: %v", proposal.State))
 		}
+		out.State = proposal.State
+		out.Results = proposal.Results
 
 		// Calculate voting percentages.
 		votedStake, err := proposal.VotedSum()
 		cobra.CheckErr(err)
 
-		voteStakePercentage := new(big.Float).SetInt(votedStake.Clone().ToBigInt())
-		voteStakePercentage = voteStakePercentage.Mul(voteStakePercentage, new(big.Float).SetInt64(100))
-		voteStakePercentage = voteStakePercentage.Quo(voteStakePercentage, new(big.Float).SetInt(totalVotingStake.ToBigInt()))
-		fmt.Printf(
-			"\nVoted stake: %s (%.2f%%), total voting stake: %s\n",
-			votedStake,
-			voteStakePercentage,
-			totalVotingStake,
-		)
+		out.TotalVotingStake = *totalVotingStake
+		out.VotedStake = *votedStake
+		out.VotedStakePercentage = stakePercentage(votedStake, totalVotingStake)
 
-		votedYes := proposal.Results[governance.VoteYes]
-		votedYesPercentage := new(big.Float).SetInt(votedYes.Clone().ToBigInt())
-		votedYesPercentage = votedYesPercentage.Mul(votedYesPercentage, new(big.Float).SetInt64(100))
-		if votedStake.Cmp(quantity.NewFromUint64(0)) > 0 {
-			votedYesPercentage = votedYesPercentage.Quo(votedYesPercentage, new(big.Float).SetInt(votedStake.ToBigInt()))
-		}
-		fmt.Printf(
-			"Voted yes stake: %s (%.2f%%), voted stake: %s, threshold: %d%%\n",
-			votedYes,
-			votedYesPercentage,
-			votedStake,
-			governanceParams.StakeThreshold,
-		)
+		out.VotedYesStake = proposal.Results[governance.VoteYes]
+		out.VotedYesStakePercentage = stakePercentage(&out.VotedYesStake, votedStake)
 
 		// Try to figure out the human readable names for all the entities.
 		fromRegistry, err := metadata.EntitiesFromRegistry(ctx)
 		if err != nil {
-			fmt.Printf("\nWarning: failed to query metadata registry: %v\n", err)
+			common.Infof("\nWarning: failed to query metadata registry: %v\n", err)
 		}
 		fromOasisscan, err := metadata.EntitiesFromOasisscan(ctx)
 		if err != nil {
-			fmt.Printf("\nWarning: failed to query oasisscan: %v\n", err)
+			common.Infof("\nWarning: failed to query oasisscan: %v\n", err)
 		}
 
 		getName := func(addr staking.Address) string {
@@ -247,27 +228,108 @@ var governanceProposalCmd = &cobra.Command{
 			return "<none>"
 		}
 
-		fmt.Println("\nValidators voted:")
-		votersList := entitiesByDescendingStake(voters)
-		for _, val := range votersList {
-			name := getName(val.Address)
-			stakePercentage := new(big.Float).SetInt(val.Stake.Clone().ToBigInt())
-			stakePercentage = stakePercentage.Mul(stakePercentage, new(big.Float).SetInt64(100))
-			stakePercentage = stakePercentage.Quo(stakePercentage, new(big.Float).SetInt(totalVotingStake.ToBigInt()))
-			fmt.Printf("%s,%s,%s (%.2f%%)\n", val.Address, name, val.Stake, stakePercentage)
-		}
-		fmt.Println("\nValidators not voted:")
-		nonVotersList := entitiesByDescendingStake(nonVoters)
-		for _, val := range nonVotersList {
-			name := getName(val.Address)
-			stakePercentage := new(big.Float).SetInt(val.Stake.Clone().ToBigInt())
-			stakePercentage = stakePercentage.Mul(stakePercentage, new(big.Float).SetInt64(100))
-			stakePercentage = stakePercentage.Quo(stakePercentage, new(big.Float).SetInt(totalVotingStake.ToBigInt()))
-			fmt.Printf("%s,%s,%s (%.2f%%)\n", val.Address, name, val.Stake, stakePercentage)
+		validatorVotes := func(m map[staking.Address]quantity.Quantity) []*validatorVoteInfo {
+			list := make([]*validatorVoteInfo, 0, len(m))
+			for _, val := range entitiesByDescendingStake(m) {
+				list = append(list, &validatorVoteInfo{
+					Address:         val.Address,
+					Name:            getName(val.Address),
+					Stake:           val.Stake,
+					StakePercentage: stakePercentage(&val.Stake, totalVotingStake),
+				})
+			}
+			return list
 		}
+		out.Voters = validatorVotes(voters)
+		out.NonVoters = validatorVotes(nonVoters)
+
+		common.Output(&out, func() {
+			// Display the high-level summary of the proposal status.
+			if out.Active {
+				fmt.Println(
+					"Proposal active, vote outcome if ended now:",
+					out.State,
+				)
+				fmt.Printf(
+					"Voting ends in %d epochs\n",
+					out.EpochsRemaining,
+				)
+			} else {
+				fmt.Printf("Proposal %s, results: %v\n",
+					out.State,
+					out.Results,
+				)
+			}
+
+			fmt.Printf(
+				"\nVoted stake: %s (%.2f%%), total voting stake: %s\n",
+				out.VotedStake,
+				out.VotedStakePercentage,
+				out.TotalVotingStake,
+			)
+			fmt.Printf(
+				"Voted yes stake: %s (%.2f%%), voted stake: %s, threshold: %d%%\n",
+				out.VotedYesStake,
+				out.VotedYesStakePercentage,
+				out.VotedStake,
+				out.StakeThreshold,
+			)
+
+			fmt.Println("\nValidators voted:")
+			for _, val := range out.Voters {
+				fmt.Printf("%s,%s,%s (%.2f%%)\n", val.Address, val.Name, val.Stake, val.StakePercentage)
+			}
+			fmt.Println("\nValidators not voted:")
+			for _, val := range out.NonVoters {
+				fmt.Printf("%s,%s,%s (%.2f%%)\n", val.Address, val.Name, val.Stake, val.StakePercentage)
+			}
+		})
 	},
 }
 
+// governanceProposalInfo is the structured output of the governance-proposal command.
+type governanceProposalInfo struct {
+	ID     uint64 `json:"id"`
+	Height int64  `json:"height"`
+	// State is the proposal state. For active proposals it is the outcome if voting ended now.
+	State           governance.ProposalState              `json:"state"`
+	Active          bool                                  `json:"active"`
+	ClosesAt        beacon.EpochTime                      `json:"closes_at"`
+	EpochsRemaining beacon.EpochTime                      `json:"epochs_remaining,omitempty"`
+	Results         map[governance.Vote]quantity.Quantity `json:"results"`
+	InvalidVotes    uint64                                `json:"invalid_votes"`
+	StakeThreshold  uint8                                 `json:"stake_threshold"`
+
+	TotalVotingStake        quantity.Quantity `json:"total_voting_stake"`
+	VotedStake              quantity.Quantity `json:"voted_stake"`
+	VotedStakePercentage    float64           `json:"voted_stake_percentage"`
+	VotedYesStake           quantity.Quantity `json:"voted_yes_stake"`
+	VotedYesStakePercentage float64           `json:"voted_yes_stake_percentage"`
+
+	Voters    []*validatorVoteInfo `json:"voters"`
+	NonVoters []*validatorVoteInfo `json:"non_voters"`
+}
+
+// validatorVoteInfo is the structured output of a validator's voting stake.
+type validatorVoteInfo struct {
+	Address         staking.Address   `json:"address"`
+	Name            string            `json:"name"`
+	Stake           quantity.Quantity `json:"stake"`
+	StakePercentage float64           `json:"stake_percentage"`
+}
+
+// stakePercentage returns the given stake as a percentage of the total stake.
+func stakePercentage(stake, total *quantity.Quantity) float64 {
+	if total.IsZero() {
+		return 0
+	}
+	pct := new(big.Float).SetInt(stake.Clone().ToBigInt())
+	pct = pct.Mul(pct, new(big.Float).SetInt64(100))
+	pct = pct.Quo(pct, new(big.Float).SetInt(total.ToBigInt()))
+	f, _ := pct.Float64()
+	return f
+}
+
 func entitiesByDescendingStake(m map[staking.Address]quantity.Quantity) entityStakes {
 	pl := make(entityStakes, 0, len(m))
 	for k, v := range m {
@@ -2,6 +2,10 @@ package inspect
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
@@ -95,3 +99,53 @@ func newNodeLookup(
 	}
 	return nl, nil
 }
+
+// parseHeightRange parses the optional [<start-height> [<end-height>]] command line arguments.
+//
+// A missing (or zero) end height means the latest height. A missing (or zero) start height means
+// the last retained height and a negative start height is relative to the end height.
+func parseHeightRange(
+	ctx context.Context,
+	consensusConn consensus.ClientBackend,
+	args []string,
+) (uint64, uint64) {
+	var (
+		startHeightArg int64
+		endHeight      uint64
+	)
+	if argLen := len(args); argLen > 0 {
+		var err error
+
+		// Start height is present for 1 and 2 args.
+		startHeightArg, err = strconv.ParseInt(args[0], 10, 64)
+		cobra.CheckErr(err)
+
+		if argLen == 2 {
+			endHeight, err = strconv.ParseUint(args[1], 10, 64)
+			cobra.CheckErr(err)
+		}
+	}
+
+	// Fixup the start/end heights if they were not specified (or are 0)
+	if endHeight == 0 {
+		blk, err := consensusConn.GetBlock(ctx, consensus.HeightLatest)
+		cobra.CheckErr(err)
+		endHeight = uint64(blk.Height)
+	}
+	var startHeight uint64
+	switch {
+	case startHeightArg < 0:
+		delta := uint64(-startHeightArg)
+		if endHeight <= delta {
+			cobra.CheckErr(fmt.Errorf("start-height %d will underflow end-height %d", startHeightArg, endHeight))
+		}
+		startHeight = endHeight - delta
+	case startHeightArg == 0:
+		status, err := consensusConn.GetStatus(ctx)
+		cobra.CheckErr(err)
+		startHeight = uint64(status.LastRetainedHeight)
+	default:
+		startHeight = uint64(startHeightArg)
+	}
+	return startHeight, endHeight
+}
@@ -19,6 +19,12 @@ func init() {
 	runtimeStatsCmd.Flags().AddFlagSet(common.SelectorFlags)
 	runtimeStatsCmd.Flags().AddFlagSet(csvFlags)
 
+	rewardsCmd.Flags().AddFlagSet(common.SelectorFlags)
+	rewardsCmd.Flags().AddFlagSet(csvFlags)
+
+	snapshotCmd.Flags().AddFlagSet(common.SelectorFlags)
+	snapshotCmd.Flags().AddFlagSet(snapshotFlags)
+
 	nativeTokenCmd.Flags().AddFlagSet(common.SelectorFlags)
 	nativeTokenCmd.Flags().AddFlagSet(common.HeightFlag)
 
@@ -29,6 +35,8 @@ func init() {
 
 	Cmd.AddCommand(governanceProposalCmd)
 	Cmd.AddCommand(runtimeStatsCmd)
+	Cmd.AddCommand(rewardsCmd)
+	Cmd.AddCommand(snapshotCmd)
 	Cmd.AddCommand(nativeTokenCmd)
 	Cmd.AddCommand(nodeStatusCmd)
 	Cmd.AddCommand(registryCmd)
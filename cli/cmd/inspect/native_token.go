@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	consensusPretty "github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 
@@ -30,7 +31,7 @@ var nativeTokenCmd = &cobra.Command{
 		cobra.CheckErr(err)
 
 		consensusConn := conn.Consensus()
-		stakingConn := consensusConn.Staking()
+		stakingConn := conn.Staking()
 
 		tokenSymbol, err := stakingConn.TokenSymbol(ctx)
 		cobra.CheckErr(err)
@@ -48,9 +49,6 @@ var nativeTokenCmd = &cobra.Command{
 			tokenValueExponent,
 		)
 
-		fmt.Printf("Token's ticker symbol: %s\n", tokenSymbol)
-		fmt.Printf("Token's value base-10 exponent: %d\n", tokenValueExponent)
-
 		// Figure out the height to use if "latest".
 		height, err := common.GetActualHeight(
 			ctx,
@@ -58,29 +56,21 @@ var nativeTokenCmd = &cobra.Command{
 		)
 		cobra.CheckErr(err)
 
-		totalSupply, err := stakingConn.TotalSupply(ctx, height)
-		cobra.CheckErr(err)
-		fmt.Print("Total supply: ")
-		token.PrettyPrintAmount(ctx, *totalSupply, os.Stdout)
-		fmt.Println()
+		out := nativeTokenInfo{
+			Height:             height,
+			TokenSymbol:        tokenSymbol,
+			TokenValueExponent: tokenValueExponent,
+			Thresholds:         make(map[staking.ThresholdKind]*quantity.Quantity),
+		}
 
-		commonPool, err := stakingConn.CommonPool(ctx, height)
+		out.TotalSupply, err = stakingConn.TotalSupply(ctx, height)
 		cobra.CheckErr(err)
-		fmt.Print("Common pool: ")
-		token.PrettyPrintAmount(ctx, *commonPool, os.Stdout)
-		fmt.Println()
-
-		lastBlockFees, err := stakingConn.LastBlockFees(ctx, height)
+		out.CommonPool, err = stakingConn.CommonPool(ctx, height)
 		cobra.CheckErr(err)
-		fmt.Print("Last block fees: ")
-		token.PrettyPrintAmount(ctx, *lastBlockFees, os.Stdout)
-		fmt.Println()
-
-		governanceDeposits, err := stakingConn.GovernanceDeposits(ctx, height)
+		out.LastBlockFees, err = stakingConn.LastBlockFees(ctx, height)
+		cobra.CheckErr(err)
+		out.GovernanceDeposits, err = stakingConn.GovernanceDeposits(ctx, height)
 		cobra.CheckErr(err)
-		fmt.Print("Governance deposits: ")
-		token.PrettyPrintAmount(ctx, *governanceDeposits, os.Stdout)
-		fmt.Println()
 
 		thresholdsToQuery := []staking.ThresholdKind{
 			staking.KindEntity,
@@ -99,9 +89,46 @@ var nativeTokenCmd = &cobra.Command{
 				},
 			)
 			cobra.CheckErr(err)
-			fmt.Printf("Staking threshold (%s): ", kind)
-			token.PrettyPrintAmount(ctx, *threshold, os.Stdout)
-			fmt.Println()
+			out.Thresholds[kind] = threshold
 		}
+
+		common.Output(&out, func() {
+			fmt.Printf("Token's ticker symbol: %s\n", tokenSymbol)
+			fmt.Printf("Token's value base-10 exponent: %d\n", tokenValueExponent)
+
+			fmt.Print("Total supply: ")
+			token.PrettyPrintAmount(ctx, *out.TotalSupply, os.Stdout)
+			fmt.Println()
+
+			fmt.Print("Common pool: ")
+			token.PrettyPrintAmount(ctx, *out.CommonPool, os.Stdout)
+			fmt.Println()
+
+			fmt.Print("Last block fees: ")
+			token.PrettyPrintAmount(ctx, *out.LastBlockFees, os.Stdout)
+			fmt.Println()
+
+			fmt.Print("Governance deposits: ")
+			token.PrettyPrintAmount(ctx, *out.GovernanceDeposits, os.Stdout)
+			fmt.Println()
+
+			for _, kind := range thresholdsToQuery {
+				fmt.Printf("Staking threshold (%s): ", kind)
+				token.PrettyPrintAmount(ctx, *out.Thresholds[kind], os.Stdout)
+				fmt.Println()
+			}
+		})
 	},
 }
+
+// nativeTokenInfo is the structured output of the native-token command.
+type nativeTokenInfo struct {
+	Height             int64                                        `json:"height"`
+	TokenSymbol        string                                       `json:"token_symbol"`
+	TokenValueExponent uint8                                        `json:"token_value_exponent"`
+	TotalSupply        *quantity.Quantity                           `json:"total_supply"`
+	CommonPool         *quantity.Quantity                           `json:"common_pool"`
+	LastBlockFees      *quantity.Quantity                           `json:"last_block_fees"`
+	GovernanceDeposits *quantity.Quantity                           `json:"governance_deposits"`
+	Thresholds         map[staking.ThresholdKind]*quantity.Quantity `json:"thresholds"`
+}
@@ -29,7 +29,8 @@ var nodeStatusCmd = &cobra.Command{
 		nodeStatus, err := ctrlConn.GetStatus(ctx)
 		cobra.CheckErr(err)
 
-		nodeStr, err := common.PrettyJSONMarshal(nodeStatus)
+		// Node status is always shown in a structured format, defaulting to JSON.
+		nodeStr, err := common.MarshalStructured(nodeStatus)
 		cobra.CheckErr(err)
 
 		fmt.Println(string(nodeStr))
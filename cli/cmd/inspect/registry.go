@@ -2,7 +2,6 @@ package inspect
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -60,7 +59,7 @@ var registryCmd = &cobra.Command{
 		cobra.CheckErr(err)
 
 		consensusConn := conn.Consensus()
-		registryConn := consensusConn.Registry()
+		registryConn := conn.Registry()
 
 		// Figure out the height to use if "latest".
 		height, err := common.GetActualHeight(
@@ -74,7 +73,7 @@ var registryCmd = &cobra.Command{
 		// looking for.
 
 		prettyPrint := func(b interface{}) error {
-			data, err := json.MarshalIndent(b, "", "  ")
+			data, err := common.MarshalStructured(b)
 			if err != nil {
 				return err
 			}
@@ -167,7 +166,7 @@ var registryCmd = &cobra.Command{
 			case selValidators:
 				// Yes, this is a scheduler query, not a registry query
 				// but this also is a reasonable place for this.
-				schedulerConn := consensusConn.Scheduler()
+				schedulerConn := conn.Scheduler()
 				validators, err := schedulerConn.GetValidators(ctx, height)
 				cobra.CheckErr(err)
 				for _, validator := range validators {
@@ -0,0 +1,270 @@
+package inspect
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+
+	"github.com/oasisprotocol/oasis-sdk/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/oasis-sdk/cli/config"
+	"github.com/oasisprotocol/oasis-sdk/cli/metadata"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/rewards"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// disbursementRounds is the number of runtime rounds at the start of an epoch that are checked
+// for reward disbursements. The runtime may observe the epoch transition one round after it has
+// been finalized in the consensus layer.
+const disbursementRounds = 2
+
+var rewardsHeader = []string{
+	"Epoch",
+	"Entity Addr",
+	"Entity Name",
+	"Good rounds",
+	"Bad rounds",
+	"Threshold",
+	"Status",
+	"Amount",
+	"Disbursed",
+}
+
+func rewardsOutput(
+	pt *config.ParaTime,
+	report []*rewards.EpochReward,
+	metadataLookup map[types.Address]*metadata.Entity,
+) [][]string {
+	addrToName := func(addr types.Address) string {
+		if metadataLookup != nil {
+			if entry, ok := metadataLookup[addr]; ok {
+				return entry.Name
+			}
+		}
+		return ""
+	}
+
+	var lines [][]string
+	for _, epoch := range report {
+		for _, entity := range epoch.Entities {
+			var disbursed string
+			if entity.Disbursed != nil {
+				disbursed = helpers.FormatParaTimeDenomination(pt, *entity.Disbursed)
+			}
+			lines = append(lines, []string{
+				strconv.FormatUint(uint64(epoch.Epoch), 10),
+				entity.Address.String(),
+				addrToName(entity.Address),
+				strconv.FormatUint(entity.GoodRounds, 10),
+				strconv.FormatUint(entity.BadRounds, 10),
+				strconv.FormatUint(epoch.Threshold, 10),
+				string(entity.Status),
+				helpers.FormatParaTimeDenomination(pt, entity.Amount),
+				disbursed,
+			})
+		}
+	}
+	return lines
+}
+
+var rewardsCmd = &cobra.Command{
+	Use:   "rewards [<start-height> [<end-height>]]",
+	Short: "Show per-epoch, per-entity ParaTime reward accounting",
+	Args:  cobra.MaximumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := cliConfig.Global()
+		npa := common.GetNPASelection(cfg)
+		if npa.ParaTime == nil {
+			cobra.CheckErr("no paratime selected")
+		}
+		runtimeID := npa.ParaTime.Namespace()
+
+		// Establish connection with the target network.
+		ctx := context.Background()
+		conn, err := connection.Connect(ctx, npa.Network)
+		cobra.CheckErr(err)
+
+		consensusConn := conn.Consensus()
+		roothashConn := conn.RootHash()
+		beaconConn := conn.Beacon()
+		rt := conn.Runtime(npa.ParaTime)
+
+		startHeight, endHeight := parseHeightRange(ctx, consensusConn, args)
+
+		// The reward schedule may change over time so the parameters are queried at the first
+		// analysed round of each epoch.
+		analyzer := rewards.NewAnalyzer(nil)
+		var latestParams *rewards.Parameters
+		paramsAt := func(round uint64) *rewards.Parameters {
+			params, perr := rt.Rewards.Parameters(ctx, round)
+			if perr == nil {
+				return params
+			}
+			if latestParams == nil {
+				// Non-fatal, the node may not have the runtime state for the round.
+				common.Infof("\nWarning: failed to query rewards parameters at round %d, using latest: %v\n", round, perr)
+				latestParams, perr = rt.Rewards.Parameters(ctx, client.RoundLatest)
+				cobra.CheckErr(perr)
+			}
+			return latestParams
+		}
+
+		common.Infof(
+			"gathering rewards: runtime-id: %s, start-height: %d, end-height: %d\n",
+			runtimeID,
+			startHeight,
+			endHeight,
+		)
+
+		var (
+			paramsEpoch        = beacon.EpochInvalid
+			lastEpoch          beacon.EpochTime
+			disbursementEpoch  beacon.EpochTime
+			disbursementChecks int
+			transfers          []*accounts.TransferEvent
+			checkDisbursements = true
+		)
+		for height := int64(startHeight); height < int64(endHeight); height++ {
+			if height%1000 == 0 {
+				common.Infof("progressed: height: %d\n", height)
+			}
+
+			var evs []*roothash.Event
+			evs, err = roothashConn.GetEvents(ctx, height)
+			cobra.CheckErr(err)
+
+			for _, ev := range evs {
+				if ev.RuntimeID != runtimeID || ev.Finalized == nil {
+					continue
+				}
+
+				rtRequest := &roothash.RuntimeRequest{
+					RuntimeID: runtimeID,
+					Height:    height,
+				}
+				var blk *block.Block
+				blk, err = roothashConn.GetLatestBlock(ctx, rtRequest)
+				cobra.CheckErr(err)
+				if blk.Header.HeaderType != block.Normal {
+					continue
+				}
+
+				// The round results are processed by the runtime in the epoch in which the
+				// round has been finalized.
+				var epoch beacon.EpochTime
+				epoch, err = beaconConn.GetEpoch(ctx, height)
+				cobra.CheckErr(err)
+				if epoch != paramsEpoch {
+					analyzer.SetParameters(paramsAt(blk.Header.Round))
+					paramsEpoch = epoch
+				}
+
+				var rtResults *roothash.RoundResults
+				rtResults, err = roothashConn.GetLastRoundResults(ctx, rtRequest)
+				cobra.CheckErr(err)
+				analyzer.AddRoundResults(epoch, rtResults)
+
+				if !checkDisbursements {
+					continue
+				}
+
+				// Rewards for previous epochs are disbursed at the start of a new epoch.
+				if epoch != lastEpoch && lastEpoch != 0 {
+					if disbursementChecks > 0 {
+						analyzer.AddDisbursements(disbursementEpoch, transfers)
+					}
+					disbursementEpoch = epoch
+					disbursementChecks = disbursementRounds
+					transfers = nil
+				}
+				lastEpoch = epoch
+				if disbursementChecks == 0 {
+					continue
+				}
+
+				var accEvs []*accounts.Event
+				accEvs, err = rt.Accounts.GetEvents(ctx, blk.Header.Round)
+				if err != nil {
+					// Non-fatal, the node may not have the runtime state for the round.
+					common.Infof("\nWarning: failed to query runtime events, disbursements will not be checked: %v\n", err)
+					checkDisbursements = false
+					disbursementChecks = 0
+					continue
+				}
+				for _, accEv := range accEvs {
+					if accEv.Transfer != nil && accEv.Transfer.From.Equal(rewards.RewardPoolAddress) {
+						transfers = append(transfers, accEv.Transfer)
+					}
+				}
+				disbursementChecks--
+				if disbursementChecks == 0 {
+					analyzer.AddDisbursements(disbursementEpoch, transfers)
+				}
+			}
+		}
+
+		if disbursementChecks > 0 && len(transfers) > 0 {
+			// The range ended before all disbursement rounds were checked.
+			analyzer.AddDisbursements(disbursementEpoch, transfers)
+		}
+		report := analyzer.Report()
+
+		entityMetadataLookup, err := metadata.EntitiesFromRegistry(ctx)
+		if err != nil {
+			// Non-fatal, this is informative and gathering rewards is time consuming.
+			common.Infof("\nWarning: failed to query metadata registry: %v\n", err)
+		}
+		lines := rewardsOutput(npa.ParaTime, report, entityMetadataLookup)
+
+		common.Output(report, func() {
+			for _, epoch := range report {
+				fmt.Printf("Epoch %d: rounds: %d, reward: %s, max good rounds: %d, threshold: %d\n",
+					epoch.Epoch,
+					epoch.Rounds,
+					helpers.FormatParaTimeDenomination(npa.ParaTime, epoch.RewardAmount),
+					epoch.MaxGoodRounds,
+					epoch.Threshold,
+				)
+			}
+			fmt.Println()
+
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+			table.SetCenterSeparator("|")
+			table.SetHeader(rewardsHeader)
+			table.AppendBulk(lines)
+			table.Render()
+		})
+
+		if !writeCSV {
+			return
+		}
+
+		// Also save per-entity rewards in a csv.
+		if fileCSV == "" {
+			fileCSV = fmt.Sprintf("runtime-%s-%d-%d-rewards.csv", runtimeID, startHeight, endHeight)
+		}
+		fout, err := os.Create(fileCSV)
+		cobra.CheckErr(err)
+		defer fout.Close()
+
+		w := csv.NewWriter(fout)
+		err = w.Write(rewardsHeader)
+		cobra.CheckErr(err)
+		err = w.WriteAll(lines)
+		cobra.CheckErr(err)
+	},
+}
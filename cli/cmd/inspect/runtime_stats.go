@@ -5,12 +5,14 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
+	coreCommon "github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
@@ -179,6 +181,94 @@ func (s *runtimeStats) printStats() {
 	table.Render()
 }
 
+// info returns the structured output of the gathered statistics.
+func (s *runtimeStats) info(
+	runtimeID coreCommon.Namespace,
+	startHeight, endHeight uint64,
+	metadataLookup map[types.Address]*metadata.Entity,
+) *runtimeStatsInfo {
+	out := &runtimeStatsInfo{
+		RuntimeID:                  runtimeID,
+		StartHeight:                startHeight,
+		EndHeight:                  endHeight,
+		Rounds:                     s.rounds,
+		SuccessfulRounds:           s.successfulRounds,
+		EpochTransitionRounds:      s.epochTransitionRounds,
+		ProposerTimeoutedRounds:    s.proposerTimeoutedRounds,
+		FailedRounds:               s.failedRounds,
+		DiscrepancyDetected:        s.discrepancyDetected,
+		DiscrepancyDetectedTimeout: s.discrepancyDetectedTimeout,
+		SuspendedRounds:            s.suspendedRounds,
+		Entities:                   make([]*runtimeEntityStatsInfo, 0, len(s.entities)),
+	}
+	for entity, stats := range s.entities {
+		entityAddr := types.NewAddressFromConsensusPublicKey(entity)
+		var name string
+		if entry, ok := metadataLookup[entityAddr]; ok {
+			name = entry.Name
+		}
+
+		out.Entities = append(out.Entities, &runtimeEntityStatsInfo{
+			Address:                     entityAddr,
+			Name:                        name,
+			RoundsElected:               stats.roundsElected,
+			RoundsPrimary:               stats.roundsPrimary,
+			RoundsBackup:                stats.roundsBackup,
+			RoundsProposer:              stats.roundsProposer,
+			RoundsPrimaryRequired:       stats.roundsPrimaryRequired,
+			CommitteedGoodBlocksPrimary: stats.committeedGoodBlocksPrimary,
+			CommitteedBadBlocksPrimary:  stats.committeedBadBlocksPrimary,
+			RoundsBackupRequired:        stats.roundsBackupRequired,
+			CommitteedGoodBlocksBackup:  stats.committeedGoodBlocksBackup,
+			CommitteedBadBlocksBackup:   stats.committeedBadBlocksBackup,
+			MissedPrimary:               stats.missedPrimary,
+			MissedBackup:                stats.missedBackup,
+			MissedProposer:              stats.missedProposer,
+			ProposedTimeout:             stats.proposedTimeout,
+		})
+	}
+	sort.Slice(out.Entities, func(i, j int) bool {
+		return out.Entities[i].Address.String() < out.Entities[j].Address.String()
+	})
+	return out
+}
+
+// runtimeStatsInfo is the structured output of the runtime-stats command.
+type runtimeStatsInfo struct {
+	RuntimeID                  coreCommon.Namespace      `json:"runtime_id"`
+	StartHeight                uint64                    `json:"start_height"`
+	EndHeight                  uint64                    `json:"end_height"`
+	Rounds                     uint64                    `json:"rounds"`
+	SuccessfulRounds           uint64                    `json:"successful_rounds"`
+	EpochTransitionRounds      uint64                    `json:"epoch_transition_rounds"`
+	ProposerTimeoutedRounds    uint64                    `json:"proposer_timeouted_rounds"`
+	FailedRounds               uint64                    `json:"failed_rounds"`
+	DiscrepancyDetected        uint64                    `json:"discrepancy_detected"`
+	DiscrepancyDetectedTimeout uint64                    `json:"discrepancy_detected_timeout"`
+	SuspendedRounds            uint64                    `json:"suspended_rounds"`
+	Entities                   []*runtimeEntityStatsInfo `json:"entities"`
+}
+
+// runtimeEntityStatsInfo is the structured output of the per-entity runtime statistics.
+type runtimeEntityStatsInfo struct {
+	Address                     types.Address `json:"address"`
+	Name                        string        `json:"name,omitempty"`
+	RoundsElected               uint64        `json:"rounds_elected"`
+	RoundsPrimary               uint64        `json:"rounds_primary"`
+	RoundsBackup                uint64        `json:"rounds_backup"`
+	RoundsProposer              uint64        `json:"rounds_proposer"`
+	RoundsPrimaryRequired       uint64        `json:"rounds_primary_required"`
+	CommitteedGoodBlocksPrimary uint64        `json:"committeed_good_blocks_primary"`
+	CommitteedBadBlocksPrimary  uint64        `json:"committeed_bad_blocks_primary"`
+	RoundsBackupRequired        uint64        `json:"rounds_backup_required"`
+	CommitteedGoodBlocksBackup  uint64        `json:"committeed_good_blocks_backup"`
+	CommitteedBadBlocksBackup   uint64        `json:"committeed_bad_blocks_backup"`
+	MissedPrimary               uint64        `json:"missed_primary"`
+	MissedBackup                uint64        `json:"missed_backup"`
+	MissedProposer              uint64        `json:"missed_proposer"`
+	ProposedTimeout             uint64        `json:"proposed_timeout"`
+}
+
 var runtimeStatsCmd = &cobra.Command{
 	Use:   "runtime-stats [<start-height> [<end-height>]]",
 	Short: "Show runtime statistics",
@@ -188,60 +278,19 @@ var runtimeStatsCmd = &cobra.Command{
 		npa := common.GetNPASelection(cfg)
 		runtimeID := npa.ParaTime.Namespace()
 
-		// Parse command line arguments
-		var (
-			startHeightArg int64
-			endHeight      uint64
-		)
-		if argLen := len(args); argLen > 0 {
-			var err error
-
-			// Start height is present for 1 and 2 args.
-			startHeightArg, err = strconv.ParseInt(args[0], 10, 64)
-			cobra.CheckErr(err)
-
-			if argLen == 2 {
-				endHeight, err = strconv.ParseUint(args[1], 10, 64)
-				cobra.CheckErr(err)
-			}
-		}
-
 		// Establish connection with the target network.
 		ctx := context.Background()
 		conn, err := connection.Connect(ctx, npa.Network)
 		cobra.CheckErr(err)
 
 		consensusConn := conn.Consensus()
-
-		// Fixup the start/end heights if they were not specified (or are 0)
-		if endHeight == 0 {
-			var blk *consensus.Block
-			blk, err = consensusConn.GetBlock(ctx, consensus.HeightLatest)
-			cobra.CheckErr(err)
-			endHeight = uint64(blk.Height)
-		}
-		var startHeight uint64
-		switch {
-		case startHeightArg < 0:
-			delta := uint64(-startHeightArg)
-			if endHeight <= delta {
-				cobra.CheckErr(fmt.Errorf("start-height %d will underflow end-height %d", startHeightArg, endHeight))
-			}
-			startHeight = endHeight - delta
-		case startHeightArg == 0:
-			var status *consensus.Status
-			status, err = consensusConn.GetStatus(ctx)
-			cobra.CheckErr(err)
-			startHeight = uint64(status.LastRetainedHeight)
-		default:
-			startHeight = uint64(startHeightArg)
-		}
+		startHeight, endHeight := parseHeightRange(ctx, consensusConn, args)
 
 		chainCtx, err := consensusConn.GetChainContext(ctx)
 		cobra.CheckErr(err)
 		signature.SetChainContext(chainCtx)
 
-		fmt.Printf(
+		common.Infof(
 			"gathering statistics: runtime-id: %s, start-height: %d, end-height: %d\n",
 			runtimeID,
 			startHeight,
@@ -260,8 +309,8 @@ var runtimeStatsCmd = &cobra.Command{
 			roundDiscrepancy bool
 		)
 
-		roothashConn := consensusConn.RootHash()
-		registryConn := consensusConn.Registry()
+		roothashConn := conn.RootHash()
+		registryConn := conn.Registry()
 
 		nl, err := newNodeLookup(ctx, consensusConn, registryConn, int64(startHeight))
 		cobra.CheckErr(err)
@@ -285,7 +334,7 @@ var runtimeStatsCmd = &cobra.Command{
 
 		for height := int64(startHeight); height < int64(endHeight); height++ {
 			if height%1000 == 0 {
-				fmt.Printf("progressed: height: %d\n", height)
+				common.Infof("progressed: height: %d\n", height)
 			}
 			err = nl.SetHeight(ctx, height)
 			cobra.CheckErr(err)
@@ -483,7 +532,7 @@ var runtimeStatsCmd = &cobra.Command{
 				cobra.CheckErr(err)
 				if state.ExecutorPool == nil {
 					// No committee - election failed(?)
-					fmt.Printf("\nWarning: unexpected or missing committee for runtime: height: %d\n", height)
+					common.Infof("\nWarning: unexpected or missing committee for runtime: height: %d\n", height)
 					currentCommittee = nil
 					currentScheduler = nil
 					continue
@@ -527,11 +576,11 @@ var runtimeStatsCmd = &cobra.Command{
 		if err != nil {
 			// Non-fatal, this is informative and gathering stats is time
 			// consuming.
-			fmt.Printf("\nWarning: failed to query metadata registry: %v\n", err)
+			common.Infof("\nWarning: failed to query metadata registry: %v\n", err)
 		}
 
 		stats.prepareEntitiesOutput(entityMetadataLookup)
-		stats.printStats()
+		common.Output(stats.info(runtimeID, startHeight, endHeight, entityMetadataLookup), stats.printStats)
 
 		if !writeCSV {
 			return
@@ -0,0 +1,269 @@
+package inspect
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/oasis-sdk/cli/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+const (
+	snapshotFormatCSV  = "csv"
+	snapshotFormatJSON = "json"
+)
+
+var (
+	snapshotRound          uint64
+	snapshotDenomination   string
+	snapshotFile           string
+	snapshotFormat         string
+	snapshotCheckpointFile string
+	snapshotConcurrency    int
+	snapshotRateLimit      uint64
+	snapshotExpectedSupply string
+
+	snapshotFlags *flag.FlagSet = func() *flag.FlagSet {
+		fs := flag.NewFlagSet("", flag.ContinueOnError)
+		fs.Uint64Var(&snapshotRound, "round", 0, "round to take the snapshot at, 0 for the latest round")
+		fs.StringVar(&snapshotDenomination, "denomination", config.NativeDenominationKey, "denomination to snapshot")
+		fs.StringVar(&snapshotFile, "snapshot-file", "", "snapshot file path (default runtime-<id>-<round>-snapshot.<format>)")
+		fs.StringVar(&snapshotFormat, "snapshot-format", snapshotFormatCSV, "snapshot file format [csv, json]")
+		fs.StringVar(&snapshotCheckpointFile, "checkpoint-file", "", "checkpoint file path used to resume an interrupted snapshot (default <snapshot-file>.checkpoint)")
+		fs.IntVar(&snapshotConcurrency, "concurrency", accounts.DefaultSnapshotConcurrency, "number of concurrent balance queries")
+		fs.Uint64Var(&snapshotRateLimit, "rate-limit", 0, "maximum number of balance queries per second, 0 for no limit")
+		fs.StringVar(&snapshotExpectedSupply, "expected-supply", "", "expected total supply in base units to reconcile the snapshot against")
+		return fs
+	}()
+)
+
+// snapshotCheckpointHeader is the first line of a snapshot checkpoint file. It is followed by one
+// JSON-encoded holder balance per line.
+type snapshotCheckpointHeader struct {
+	Round        uint64             `json:"round"`
+	Denomination types.Denomination `json:"denomination"`
+}
+
+// snapshotSummary is the summary of a written snapshot.
+type snapshotSummary struct {
+	Round        uint64             `json:"round"`
+	Denomination types.Denomination `json:"denomination"`
+	Decimals     uint8              `json:"decimals"`
+	Holders      int                `json:"holders"`
+	TotalSupply  quantity.Quantity  `json:"total_supply"`
+	File         string             `json:"file"`
+}
+
+func loadSnapshotCheckpoint(fn string) (*snapshotCheckpointHeader, []*accounts.HolderBalance, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		return nil, nil, fmt.Errorf("malformed checkpoint: missing header")
+	}
+	var hdr snapshotCheckpointHeader
+	if err = json.Unmarshal(scanner.Bytes(), &hdr); err != nil {
+		return nil, nil, fmt.Errorf("malformed checkpoint header: %w", err)
+	}
+	var completed []*accounts.HolderBalance
+	for scanner.Scan() {
+		var hb accounts.HolderBalance
+		if err = json.Unmarshal(scanner.Bytes(), &hb); err != nil {
+			// The last line may be incomplete in case the snapshot was interrupted.
+			break
+		}
+		completed = append(completed, &hb)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	return &hdr, completed, nil
+}
+
+func writeSnapshot(fn, format string, snapshot *accounts.Snapshot) error {
+	fout, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+	defer fout.Close()
+
+	switch format {
+	case snapshotFormatJSON:
+		enc := json.NewEncoder(fout)
+		enc.SetIndent("", "  ")
+		if err = enc.Encode(snapshot); err != nil {
+			return err
+		}
+	case snapshotFormatCSV:
+		w := csv.NewWriter(fout)
+		if err = w.Write([]string{"Address", "Balance", "Amount"}); err != nil {
+			return err
+		}
+		for _, hb := range snapshot.Holders {
+			if err = w.Write([]string{
+				hb.Address.String(),
+				hb.Balance.String(),
+				prettyprint.QuantityFrac(hb.Balance, snapshot.Decimals),
+			}); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		if err = w.Error(); err != nil {
+			return err
+		}
+	}
+	return fout.Sync()
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Export a snapshot of all ParaTime token holders",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := cliConfig.Global()
+		npa := common.GetNPASelection(cfg)
+		if npa.ParaTime == nil {
+			cobra.CheckErr("no paratime selected")
+		}
+		switch snapshotFormat {
+		case snapshotFormatCSV, snapshotFormatJSON:
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported snapshot format: %s", snapshotFormat))
+		}
+
+		var expectedSupply *quantity.Quantity
+		if snapshotExpectedSupply != "" {
+			expectedSupply = quantity.NewQuantity()
+			if err := expectedSupply.UnmarshalText([]byte(snapshotExpectedSupply)); err != nil {
+				cobra.CheckErr(fmt.Errorf("malformed expected supply: %w", err))
+			}
+		}
+
+		denomination := types.NativeDenomination
+		if snapshotDenomination != config.NativeDenominationKey {
+			denomination = types.Denomination(snapshotDenomination)
+		}
+
+		// Establish connection with the target network.
+		ctx := context.Background()
+		conn, err := connection.Connect(ctx, npa.Network)
+		cobra.CheckErr(err)
+		rc := conn.Runtime(npa.ParaTime)
+
+		if snapshotCheckpointFile == "" {
+			switch snapshotFile {
+			case "":
+				snapshotCheckpointFile = fmt.Sprintf("runtime-%s-snapshot.checkpoint", npa.ParaTime.ID)
+			default:
+				snapshotCheckpointFile = snapshotFile + ".checkpoint"
+			}
+		}
+
+		// Resume from an existing checkpoint, if any.
+		round := snapshotRound
+		hdr, completed, err := loadSnapshotCheckpoint(snapshotCheckpointFile)
+		switch {
+		case err == nil:
+			if round == 0 {
+				// Continue the interrupted snapshot at the same round.
+				round = hdr.Round
+			}
+			if hdr.Round != round || hdr.Denomination != denomination {
+				cobra.CheckErr(fmt.Errorf("checkpoint %s is for round %d and denomination '%s', remove it to start a new snapshot",
+					snapshotCheckpointFile, hdr.Round, hdr.Denomination))
+			}
+			common.Infof("resuming snapshot from checkpoint %s: %d holders already fetched\n", snapshotCheckpointFile, len(completed))
+		case errors.Is(err, os.ErrNotExist):
+			if round == 0 {
+				blk, err := rc.GetBlock(ctx, client.RoundLatest)
+				cobra.CheckErr(err)
+				round = blk.Header.Round
+			}
+		default:
+			cobra.CheckErr(fmt.Errorf("failed to load checkpoint: %w", err))
+		}
+		if snapshotFile == "" {
+			snapshotFile = fmt.Sprintf("runtime-%s-%d-snapshot.%s", npa.ParaTime.ID, round, snapshotFormat)
+		}
+
+		// (Re)write the checkpoint so that a partially written last entry is discarded.
+		checkpoint, err := os.Create(snapshotCheckpointFile)
+		cobra.CheckErr(err)
+		defer checkpoint.Close()
+		enc := json.NewEncoder(checkpoint)
+		cobra.CheckErr(enc.Encode(&snapshotCheckpointHeader{Round: round, Denomination: denomination}))
+		for _, hb := range completed {
+			cobra.CheckErr(enc.Encode(hb))
+		}
+
+		opts := &accounts.SnapshotOptions{
+			Concurrency: snapshotConcurrency,
+			Completed:   completed,
+		}
+		if snapshotRateLimit > 0 {
+			opts.QueryInterval = time.Second / time.Duration(snapshotRateLimit)
+		}
+		fetched := len(completed)
+		opts.OnHolder = func(hb *accounts.HolderBalance) error {
+			fetched++
+			if fetched%1000 == 0 {
+				common.Infof("progressed: holders: %d\n", fetched)
+			}
+			return enc.Encode(hb)
+		}
+
+		common.Infof("taking snapshot: runtime-id: %s, round: %d, denomination: '%s'\n", npa.ParaTime.ID, round, denomination)
+		snapshot, err := accounts.TakeSnapshot(ctx, rc, round, denomination, opts)
+		cobra.CheckErr(err)
+
+		cobra.CheckErr(writeSnapshot(snapshotFile, snapshotFormat, snapshot))
+		checkpoint.Close()
+		cobra.CheckErr(os.Remove(snapshotCheckpointFile))
+
+		di := npa.ParaTime.GetDenominationInfo(denomination)
+		summary := &snapshotSummary{
+			Round:        snapshot.Round,
+			Denomination: snapshot.Denomination,
+			Decimals:     snapshot.Decimals,
+			Holders:      len(snapshot.Holders),
+			TotalSupply:  snapshot.TotalSupply,
+			File:         snapshotFile,
+		}
+		common.Output(summary, func() {
+			fmt.Printf("Round:        %d\n", summary.Round)
+			fmt.Printf("Holders:      %d\n", summary.Holders)
+			fmt.Printf("Total supply: %s %s\n", prettyprint.QuantityFrac(summary.TotalSupply, summary.Decimals), di.Symbol)
+			fmt.Printf("Snapshot:     %s\n", summary.File)
+		})
+
+		if di.Decimals != snapshot.Decimals {
+			common.Infof("\nWarning: the ParaTime uses %d decimals for '%s', but %d are configured\n", snapshot.Decimals, denomination, di.Decimals)
+		}
+		if expectedSupply != nil {
+			cobra.CheckErr(snapshot.Reconcile(expectedSupply))
+			common.Infof("total supply matches the expected total supply\n")
+		}
+	},
+}
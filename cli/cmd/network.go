@@ -2,11 +2,20 @@ package cmd
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 
 	"github.com/oasisprotocol/oasis-sdk/cli/cmd/common"
 	cliConfig "github.com/oasisprotocol/oasis-sdk/cli/config"
@@ -15,7 +24,53 @@ import (
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
 )
 
+const (
+	// networkManifestFetchTimeout is the timeout for fetching network manifests.
+	networkManifestFetchTimeout = 30 * time.Second
+	// networkManifestMaxSize is the maximum size of fetched network manifests.
+	networkManifestMaxSize = 1 << 20
+)
+
 var (
+	networkSecurityMode       string
+	networkSecurityCACert     string
+	networkSecurityClientCert string
+	networkSecurityClientKey  string
+	networkSecurityServerName string
+	networkSecurityPins       []string
+	networkSecurityPinCerts   []string
+	networkSecurityHeaders    []string
+	networkSecurityReset      bool
+
+	networkDiscoverName string
+
+	networkExportOutputFile      string
+	networkExportFormat          string
+	networkExportAccount         string
+	networkExportUnsigned        bool
+	networkExportIncludeSecurity bool
+
+	networkImportName           string
+	networkImportSigner         string
+	networkImportAllowUnsigned  bool
+	networkImportVerifyNode     bool
+	networkImportReplace        bool
+	networkImportAcceptSecurity bool
+
+	networkSecurityFlags = func() *flag.FlagSet {
+		fs := flag.NewFlagSet("", flag.ContinueOnError)
+		fs.StringVar(&networkSecurityMode, "security-mode", "", fmt.Sprintf("connection security mode [%s, %s]", config.SecurityModeTLS, config.SecurityModeInsecure))
+		fs.StringVar(&networkSecurityCACert, "ca-cert", "", "path to PEM-encoded CA certificates to trust instead of the system roots")
+		fs.StringVar(&networkSecurityClientCert, "client-cert", "", "path to PEM-encoded client certificate")
+		fs.StringVar(&networkSecurityClientKey, "client-key", "", "path to PEM-encoded client certificate key")
+		fs.StringVar(&networkSecurityServerName, "server-name", "", "server name used to verify the node certificate")
+		fs.StringSliceVar(&networkSecurityPins, "pin", nil, "base64-encoded SHA-256 hash of a pinned certificate public key (without --ca-cert the node certificate itself must be pinned). Multiple of this flag is allowed")
+		fs.StringSliceVar(&networkSecurityPinCerts, "pin-cert", nil, "path to a PEM-encoded certificate whose public key to pin. Multiple of this flag is allowed")
+		fs.StringSliceVar(&networkSecurityHeaders, "header", nil, "header sent with each request in the format name=value, "+
+			"where the value may reference environment variables (e.g. x-api-key=$API_KEY). An empty value removes the header. Multiple of this flag is allowed")
+		return fs
+	}()
+
 	networkCmd = &cobra.Command{
 		Use:   "network",
 		Short: "Manage network endpoints",
@@ -28,30 +83,44 @@ var (
 		Args:    cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg := cliConfig.Global()
-			table := table.New()
-			table.SetHeader([]string{"Name", "Chain Context", "RPC"})
 
-			var output [][]string
+			var networks []*networkInfo
 			for name, net := range cfg.Networks.All {
-				displayName := name
-				if cfg.Networks.Default == name {
-					displayName += defaultMarker
-				}
-
-				output = append(output, []string{
-					displayName,
-					net.ChainContext,
-					net.RPC,
+				networks = append(networks, &networkInfo{
+					Name:         name,
+					Default:      cfg.Networks.Default == name,
+					Description:  net.Description,
+					ChainContext: net.ChainContext,
+					RPC:          net.RPC,
 				})
 			}
 
 			// Sort output by name.
-			sort.Slice(output, func(i, j int) bool {
-				return output[i][0] < output[j][0]
+			sort.Slice(networks, func(i, j int) bool {
+				return networks[i].Name < networks[j].Name
 			})
 
-			table.AppendBulk(output)
-			table.Render()
+			common.Output(networks, func() {
+				table := table.New()
+				table.SetHeader([]string{"Name", "Chain Context", "RPC"})
+
+				var output [][]string
+				for _, net := range networks {
+					displayName := net.Name
+					if net.Default {
+						displayName += defaultMarker
+					}
+
+					output = append(output, []string{
+						displayName,
+						net.ChainContext,
+						net.RPC,
+					})
+				}
+
+				table.AppendBulk(output)
+				table.Render()
+			})
 		},
 	}
 
@@ -67,6 +136,7 @@ var (
 				ChainContext: chainContext,
 				RPC:          rpc,
 			}
+			applyNetworkSecurityFlags(cmd, &net)
 			// Validate initial network configuration early.
 			cobra.CheckErr(config.ValidateIdentifier(name))
 			cobra.CheckErr(net.Validate())
@@ -140,6 +210,258 @@ var (
 		},
 	}
 
+	networkDiscoverCmd = &cobra.Command{
+		Use:   "discover <rpc-endpoint>",
+		Short: "Add a new network with the configuration discovered from the given node",
+		Long: "Add a new network with the configuration discovered from the given node.\n\n" +
+			"The chain context and the native token are queried from the consensus layer while all compute " +
+			"runtimes registered in the consensus layer and served by the node are added as ParaTimes.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			rpc := args[0]
+
+			net := config.Network{
+				RPC: rpc,
+			}
+			applyNetworkSecurityFlags(cmd, &net)
+			if networkDiscoverName != "" {
+				cobra.CheckErr(config.ValidateIdentifier(networkDiscoverName))
+			}
+
+			ctx := context.Background()
+			d, err := connection.Discover(ctx, &net)
+			cobra.CheckErr(err)
+
+			name := networkDiscoverName
+			if name == "" {
+				// Default to the name of a known network.
+				for defaultName, defaultNet := range config.DefaultNetworks.All {
+					if defaultNet.ChainContext == d.Network.ChainContext {
+						name = defaultName
+						break
+					}
+				}
+				if name == "" {
+					cobra.CheckErr(fmt.Errorf("unknown network with chain context '%s', use --name to name it", d.Network.ChainContext))
+				}
+				// Known networks are usually configured already, so don't collide with them.
+				if _, exists := cfg.Networks.All[name]; exists {
+					baseName := name
+					for i := 2; ; i++ {
+						name = fmt.Sprintf("%s_%d", baseName, i)
+						if _, exists = cfg.Networks.All[name]; !exists {
+							break
+						}
+					}
+					common.Infof("Network '%s' already exists, using name '%s' instead. Use --name to choose a different one.\n", baseName, name)
+				}
+			}
+
+			out := networkDiscoverOutput{
+				Name:         name,
+				ChainContext: d.Network.ChainContext,
+				RPC:          d.Network.RPC,
+				Symbol:       d.Network.Denomination.Symbol,
+				Decimals:     d.Network.Denomination.Decimals,
+			}
+			for id, reason := range d.Skipped {
+				out.Skipped = append(out.Skipped, skippedRuntimeInfo{ID: id, Reason: reason.Error()})
+			}
+			sort.Slice(out.Skipped, func(i, j int) bool { return out.Skipped[i].ID < out.Skipped[j].ID })
+			for _, sr := range out.Skipped {
+				common.Infof("WARNING: Skipping runtime '%s': %s\n", sr.ID, sr.Reason)
+			}
+
+			err = cfg.Networks.Add(name, d.Network)
+			cobra.CheckErr(err)
+
+			err = cfg.Save()
+			cobra.CheckErr(err)
+
+			common.Infof("Added network '%s' (chain context %s, %s with %d decimals) with %d paratime(s).\n",
+				name, d.Network.ChainContext, d.Network.Denomination.Symbol, d.Network.Denomination.Decimals, len(d.Network.ParaTimes.All))
+
+			for ptName, pt := range d.Network.ParaTimes.All {
+				pti := discoveredParaTimeInfo{
+					Name:    ptName,
+					Default: d.Network.ParaTimes.Default == ptName,
+					ID:      pt.ID,
+				}
+				for denom, di := range pt.Denominations {
+					pti.Denominations = append(pti.Denominations, denominationInfo{
+						Denomination: denom,
+						Symbol:       di.Symbol,
+						Decimals:     di.Decimals,
+					})
+				}
+				sort.Slice(pti.Denominations, func(i, j int) bool {
+					return pti.Denominations[i].Denomination < pti.Denominations[j].Denomination
+				})
+				out.ParaTimes = append(out.ParaTimes, pti)
+			}
+			sort.Slice(out.ParaTimes, func(i, j int) bool { return out.ParaTimes[i].Name < out.ParaTimes[j].Name })
+
+			common.Output(&out, func() {
+				ptTable := table.New()
+				ptTable.SetHeader([]string{"ParaTime", "ID", "Denomination(s)"})
+				var output [][]string
+				for _, pti := range out.ParaTimes {
+					var denoms []string
+					for _, di := range pti.Denominations {
+						denoms = append(denoms, fmt.Sprintf("%s: %s (%d)", di.Denomination, di.Symbol, di.Decimals))
+					}
+
+					displayName := pti.Name
+					if pti.Default {
+						displayName += defaultMarker
+					}
+					output = append(output, []string{displayName, pti.ID, strings.Join(denoms, "\n")})
+				}
+				ptTable.AppendBulk(output)
+				ptTable.Render()
+			})
+		},
+	}
+
+	networkExportCmd = &cobra.Command{
+		Use:   "export <name>",
+		Short: "Export the given network as a signed manifest",
+		Long: "Export the given network as a manifest that can be shared with others and imported\n" +
+			"with 'network import'. The manifest is signed by the selected account unless --unsigned\n" +
+			"is passed. The connection security profile is only included when requested as it may\n" +
+			"contain local file paths and secrets.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			name := args[0]
+
+			net := cfg.Networks.All[name]
+			if net == nil {
+				cobra.CheckErr(fmt.Errorf("network '%s' does not exist", name))
+				return // To make staticcheck happy as it doesn't know CheckErr exits.
+			}
+
+			exported := *net
+			if !networkExportIncludeSecurity {
+				exported.Security = nil
+			}
+			m := cliConfig.NewNetworkManifest(name, &exported)
+
+			format := networkExportFormat
+			if format == "" {
+				format = cliConfig.NetworkManifestFormatJSON
+				if strings.EqualFold(filepath.Ext(networkExportOutputFile), ".toml") {
+					format = cliConfig.NetworkManifestFormatTOML
+				}
+			}
+
+			if !networkExportUnsigned {
+				accName := networkExportAccount
+				if accName == "" {
+					accName = cfg.Wallet.Default
+				}
+				if accName == "" {
+					cobra.CheckErr(fmt.Errorf("no account to sign the manifest with, use --account or --unsigned"))
+				}
+				acc := common.LoadAccount(cfg, accName)
+				err := m.Sign(acc.Signer(), acc.SignatureAddressSpec())
+				cobra.CheckErr(err)
+			}
+
+			data, err := m.Marshal(format)
+			cobra.CheckErr(err)
+
+			if networkExportOutputFile == "" {
+				fmt.Printf("%s\n", strings.TrimSpace(string(data)))
+				return
+			}
+			err = os.WriteFile(networkExportOutputFile, data, 0o600)
+			cobra.CheckErr(err)
+		},
+	}
+
+	networkImportCmd = &cobra.Command{
+		Use:   "import <file-or-url>",
+		Short: "Import a network from a manifest",
+		Long: "Import a network from a manifest created with 'network export'.\n\n" +
+			"The manifest signature is verified and the signer is either checked against --signer or\n" +
+			"confirmed interactively. With --verify-node the manifest is additionally compared with the\n" +
+			"configuration discovered from the network's RPC endpoint.\n\n" +
+			"The connection security profile in the manifest is ignored and the local one is kept unless\n" +
+			"--accept-security is passed, as it may reference local files and environment variables.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			source := args[0]
+
+			data, err := readNetworkManifest(source)
+			cobra.CheckErr(err)
+			m, err := cliConfig.UnmarshalNetworkManifest(data)
+			cobra.CheckErr(err)
+
+			name := m.Name
+			if networkImportName != "" {
+				name = networkImportName
+			}
+			cobra.CheckErr(config.ValidateIdentifier(name))
+			if _, exists := cfg.Networks.All[name]; exists && !networkImportReplace {
+				cobra.CheckErr(fmt.Errorf("network '%s' already exists, use --replace to replace it", name))
+			}
+
+			switch {
+			case m.IsSigned():
+				cobra.CheckErr(m.Verify())
+
+				signer := m.SignerAddress()
+				if networkImportSigner != "" {
+					expected, err := common.ResolveLocalAccountOrAddress(m.Network, networkImportSigner)
+					cobra.CheckErr(err)
+					if !expected.Equal(signer) {
+						cobra.CheckErr(fmt.Errorf("manifest signed by %s instead of %s", signer, expected))
+					}
+				} else {
+					common.Infof("Manifest signed by %s.\n", signer)
+					common.Confirm("Do you trust the signer of the manifest?", "not importing network")
+				}
+			case networkImportAllowUnsigned:
+				common.Infof("WARNING: Importing unsigned manifest.\n")
+			default:
+				cobra.CheckErr(fmt.Errorf("manifest is not signed, use --allow-unsigned to import it anyway"))
+			}
+
+			if sec := m.Network.Security; sec != nil {
+				switch networkImportAcceptSecurity {
+				case true:
+					printNetworkSecurity(sec)
+					common.Confirm("Do you want to use the connection security profile from the manifest?", "not importing network")
+				case false:
+					common.Infof("WARNING: Ignoring the connection security profile in the manifest, use --accept-security to import it.\n")
+				}
+			}
+
+			existing, exists := cfg.Networks.All[name]
+			net := m.ImportNetwork(existing, networkImportAcceptSecurity)
+
+			// Only connect to the node using the accepted security profile as the one from the
+			// manifest may leak local secrets through header values.
+			if networkImportVerifyNode {
+				err = verifyNetworkWithNode(context.Background(), net)
+				cobra.CheckErr(err)
+			}
+
+			if exists {
+				cfg.Networks.All[name] = net
+			} else {
+				err = cfg.Networks.Add(name, net)
+				cobra.CheckErr(err)
+			}
+
+			err = cfg.Save()
+			cobra.CheckErr(err)
+		},
+	}
+
 	networkRmCmd = &cobra.Command{
 		Use:     "rm <name>",
 		Aliases: []string{"remove"},
@@ -203,8 +525,265 @@ var (
 			cobra.CheckErr(err)
 		},
 	}
+
+	networkSetSecurityCmd = &cobra.Command{
+		Use:   "set-security <name>",
+		Short: "Sets the connection security profile of the given network",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			name := args[0]
+
+			net := cfg.Networks.All[name]
+			if net == nil {
+				cobra.CheckErr(fmt.Errorf("network '%s' does not exist", name))
+				return // To make staticcheck happy as it doesn't know CheckErr exits.
+			}
+
+			if networkSecurityReset {
+				net.Security = nil
+			}
+			applyNetworkSecurityFlags(cmd, net)
+			cobra.CheckErr(net.Validate())
+
+			err := cfg.Save()
+			cobra.CheckErr(err)
+		},
+	}
 )
 
+// applyNetworkSecurityFlags updates the security profile of the given network based on the
+// explicitly set security flags.
+//
+// Only the resulting security profile is validated as the rest of the network descriptor may
+// not be complete yet (e.g. before its chain context has been discovered). Callers are
+// responsible for validating the network itself.
+func applyNetworkSecurityFlags(cmd *cobra.Command, net *config.Network) {
+	changed := func(name string) bool {
+		return cmd.Flags().Changed(name)
+	}
+	var anyChanged bool
+	networkSecurityFlags.VisitAll(func(f *flag.Flag) {
+		anyChanged = anyChanged || changed(f.Name)
+	})
+	if !anyChanged {
+		return
+	}
+
+	sec := net.Security
+	if sec == nil {
+		sec = &config.NetworkSecurity{}
+	}
+	if changed("security-mode") {
+		sec.Mode = networkSecurityMode
+	}
+	if changed("ca-cert") {
+		sec.CACert = networkSecurityCACert
+	}
+	if changed("client-cert") {
+		sec.ClientCert = networkSecurityClientCert
+	}
+	if changed("client-key") {
+		sec.ClientKey = networkSecurityClientKey
+	}
+	if changed("server-name") {
+		sec.ServerName = networkSecurityServerName
+	}
+	if changed("pin") || changed("pin-cert") {
+		pins := append([]string{}, networkSecurityPins...)
+		for _, fn := range networkSecurityPinCerts {
+			pin, err := publicKeyPinFromFile(fn)
+			cobra.CheckErr(err)
+			pins = append(pins, pin)
+		}
+		sec.PinnedPublicKeys = pins
+	}
+	for _, header := range networkSecurityHeaders {
+		kv := strings.SplitN(header, "=", 2)
+		if len(kv) != 2 {
+			cobra.CheckErr(fmt.Errorf("malformed header '%s'", header))
+		}
+		name := strings.ToLower(strings.TrimSpace(kv[0]))
+		if kv[1] == "" {
+			delete(sec.Headers, name)
+			continue
+		}
+		if sec.Headers == nil {
+			sec.Headers = make(map[string]string)
+		}
+		sec.Headers[name] = kv[1]
+	}
+
+	net.Security = sec
+	if sec.Mode == "" && sec.CACert == "" && sec.ClientCert == "" && sec.ClientKey == "" && sec.ServerName == "" &&
+		len(sec.PinnedPublicKeys) == 0 && len(sec.Headers) == 0 {
+		// Use the default profile.
+		net.Security = nil
+		return
+	}
+	if err := sec.Validate(); err != nil {
+		cobra.CheckErr(fmt.Errorf("invalid security profile: %w", err))
+	}
+}
+
+// publicKeyPinFromFile computes the public key pin of the PEM-encoded certificate in the given
+// file.
+func publicKeyPinFromFile(fn string) (string, error) {
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return "", err
+	}
+	blk, _ := pem.Decode(data)
+	if blk == nil || blk.Type != "CERTIFICATE" {
+		return "", fmt.Errorf("no certificate found in '%s'", fn)
+	}
+	cert, err := x509.ParseCertificate(blk.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("malformed certificate in '%s': %w", fn, err)
+	}
+	return connection.PublicKeyPin(cert), nil
+}
+
+// printNetworkSecurity prints the given security profile, including all referenced local files
+// and the unexpanded header values, for confirmation.
+func printNetworkSecurity(sec *config.NetworkSecurity) {
+	common.Infof("The manifest contains the following connection security profile:\n")
+	if sec.Mode != "" {
+		common.Infof("  Mode: %s\n", sec.Mode)
+	}
+	for _, f := range []struct {
+		name, path string
+	}{
+		{"CA certificate", sec.CACert},
+		{"Client certificate", sec.ClientCert},
+		{"Client key", sec.ClientKey},
+	} {
+		if f.path != "" {
+			common.Infof("  %s file: %s\n", f.name, f.path)
+		}
+	}
+	if sec.ServerName != "" {
+		common.Infof("  Server name: %s\n", sec.ServerName)
+	}
+	for _, pin := range sec.PinnedPublicKeys {
+		common.Infof("  Pinned public key: %s\n", pin)
+	}
+
+	var headers []string
+	for name := range sec.Headers {
+		headers = append(headers, name)
+	}
+	sort.Strings(headers)
+	for _, name := range headers {
+		common.Infof("  Header: %s=%s\n", name, sec.Headers[name])
+	}
+	if len(headers) > 0 {
+		common.Infof("WARNING: Environment variables referenced in header values are expanded and sent to the node.\n")
+	}
+}
+
+// readNetworkManifest reads the network manifest from the given file or HTTP(S) URL.
+func readNetworkManifest(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return os.ReadFile(source)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), networkManifestFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch network manifest: %w", err)
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch network manifest: %s", rsp.Status)
+	}
+	return io.ReadAll(io.LimitReader(rsp.Body, networkManifestMaxSize))
+}
+
+// verifyNetworkWithNode compares the given network configuration with the configuration
+// discovered from its RPC endpoint.
+func verifyNetworkWithNode(ctx context.Context, net *config.Network) error {
+	d, err := connection.Discover(ctx, net)
+	if err != nil {
+		return err
+	}
+
+	if d.Network.ChainContext != net.ChainContext {
+		return fmt.Errorf("chain context mismatch (expected: %s got: %s)", net.ChainContext, d.Network.ChainContext)
+	}
+	if d.Network.Denomination != net.Denomination {
+		return fmt.Errorf("denomination mismatch (expected: %s with %d decimals got: %s with %d decimals)",
+			net.Denomination.Symbol, net.Denomination.Decimals, d.Network.Denomination.Symbol, d.Network.Denomination.Decimals)
+	}
+
+	discovered := make(map[string]*config.ParaTime)
+	for _, pt := range d.Network.ParaTimes.All {
+		discovered[pt.ID] = pt
+	}
+	for name, pt := range net.ParaTimes.All {
+		dpt := discovered[pt.ID]
+		if dpt == nil {
+			if reason, skipped := d.Skipped[pt.ID]; skipped {
+				return fmt.Errorf("paratime '%s': %w", name, reason)
+			}
+			return fmt.Errorf("paratime '%s' with ID '%s' is not registered", name, pt.ID)
+		}
+		for denom, di := range pt.Denominations {
+			ddi := dpt.Denominations[denom]
+			if ddi == nil || ddi.Decimals != di.Decimals {
+				return fmt.Errorf("paratime '%s': denomination '%s' does not match the node", name, denom)
+			}
+		}
+	}
+	return nil
+}
+
+// networkDiscoverOutput is the structured output of the network discover command.
+type networkDiscoverOutput struct {
+	Name         string                   `json:"name"`
+	ChainContext string                   `json:"chain_context"`
+	RPC          string                   `json:"rpc"`
+	Symbol       string                   `json:"symbol"`
+	Decimals     uint8                    `json:"decimals"`
+	ParaTimes    []discoveredParaTimeInfo `json:"paratimes"`
+	Skipped      []skippedRuntimeInfo     `json:"skipped,omitempty"`
+}
+
+// discoveredParaTimeInfo is a ParaTime added by the network discover command.
+type discoveredParaTimeInfo struct {
+	Name          string             `json:"name"`
+	Default       bool               `json:"default"`
+	ID            string             `json:"id"`
+	Denominations []denominationInfo `json:"denominations,omitempty"`
+}
+
+// denominationInfo is the structured output of a configured denomination.
+type denominationInfo struct {
+	Denomination string `json:"denomination"`
+	Symbol       string `json:"symbol"`
+	Decimals     uint8  `json:"decimals"`
+}
+
+// skippedRuntimeInfo is a runtime that has not been added by the network discover command.
+type skippedRuntimeInfo struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
+
+// networkInfo is the structured output of the network list command.
+type networkInfo struct {
+	Name         string `json:"name"`
+	Default      bool   `json:"default"`
+	Description  string `json:"description,omitempty"`
+	ChainContext string `json:"chain_context"`
+	RPC          string `json:"rpc"`
+}
+
 func networkDetailsFromSurvey(net *config.Network) {
 	// Ask user for some additional parameters.
 	questions := []*survey.Question{
@@ -239,10 +818,40 @@ func networkDetailsFromSurvey(net *config.Network) {
 }
 
 func init() {
+	networkAddCmd.Flags().AddFlagSet(networkSecurityFlags)
+
+	networkDiscoverCmd.Flags().AddFlagSet(networkSecurityFlags)
+	networkDiscoverCmd.Flags().StringVar(&networkDiscoverName, "name", "", "name of the new network (defaults to the name of a known network, suffixed with a number if it already exists)")
+
+	networkExportFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	networkExportFlags.StringVarP(&networkExportOutputFile, "output-file", "o", "", "write the manifest into the given file instead of standard output")
+	networkExportFlags.StringVar(&networkExportFormat, "manifest-format", "", fmt.Sprintf("manifest format [%s, %s] (defaults to the output file extension or %s)",
+		cliConfig.NetworkManifestFormatJSON, cliConfig.NetworkManifestFormatTOML, cliConfig.NetworkManifestFormatJSON))
+	networkExportFlags.StringVar(&networkExportAccount, "account", "", "account to sign the manifest with (defaults to the default account)")
+	networkExportFlags.BoolVar(&networkExportUnsigned, "unsigned", false, "do not sign the manifest")
+	networkExportFlags.BoolVar(&networkExportIncludeSecurity, "include-security", false, "include the connection security profile")
+	networkExportCmd.Flags().AddFlagSet(networkExportFlags)
+
+	networkImportFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	networkImportFlags.StringVar(&networkImportName, "name", "", "name of the imported network (defaults to the name in the manifest)")
+	networkImportFlags.StringVar(&networkImportSigner, "signer", "", "address, account or contact that must have signed the manifest")
+	networkImportFlags.BoolVar(&networkImportAllowUnsigned, "allow-unsigned", false, "allow importing unsigned manifests")
+	networkImportFlags.BoolVar(&networkImportVerifyNode, "verify-node", false, "verify the manifest against the network's RPC endpoint")
+	networkImportFlags.BoolVar(&networkImportReplace, "replace", false, "replace an existing network with the same name")
+	networkImportFlags.BoolVar(&networkImportAcceptSecurity, "accept-security", false, "use the connection security profile from the manifest after confirmation")
+	networkImportCmd.Flags().AddFlagSet(networkImportFlags)
+
+	networkSetSecurityCmd.Flags().AddFlagSet(networkSecurityFlags)
+	networkSetSecurityCmd.Flags().BoolVar(&networkSecurityReset, "reset", false, "reset the security profile to the default before applying other flags")
+
 	networkCmd.AddCommand(networkListCmd)
 	networkCmd.AddCommand(networkAddCmd)
 	networkCmd.AddCommand(networkAddLocalCmd)
+	networkCmd.AddCommand(networkDiscoverCmd)
+	networkCmd.AddCommand(networkExportCmd)
+	networkCmd.AddCommand(networkImportCmd)
 	networkCmd.AddCommand(networkRmCmd)
 	networkCmd.AddCommand(networkSetDefaultCmd)
 	networkCmd.AddCommand(networkSetRPCCmd)
+	networkCmd.AddCommand(networkSetSecurityCmd)
 }
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	cliConfig "github.com/oasisprotocol/oasis-sdk/cli/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+)
+
+func TestNetworkImportVerifyNodeHeaders(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	cfgFn := filepath.Join(dir, "cli.toml")
+	require.NoError(os.WriteFile(cfgFn, nil, 0o600))
+
+	// Start a node that records the headers of all requests and fails them.
+	sockFn := filepath.Join(dir, "node.sock")
+	listener, err := net.Listen("unix", sockFn)
+	require.NoError(err, "Listen")
+	var (
+		headersLock sync.Mutex
+		headers     []metadata.MD
+	)
+	srv := grpc.NewServer(grpc.UnknownServiceHandler(func(_ interface{}, stream grpc.ServerStream) error {
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		headersLock.Lock()
+		headers = append(headers, md)
+		headersLock.Unlock()
+		return status.Error(codes.Unimplemented, "not implemented")
+	}))
+	go func() { _ = srv.Serve(listener) }()
+	defer srv.Stop()
+
+	// The manifest tries to exfiltrate a local secret through a header.
+	t.Setenv("OASIS_TEST_SECRET", "secret")
+	m := cliConfig.NewNetworkManifest("evil", &config.Network{
+		ChainContext: "2bd8b38ef1c1b24e6ea0dd6c84f3b2bbd2b16e9e81bd5e0f4bd0b2c77d32eb5d",
+		RPC:          "unix:" + sockFn,
+		Denomination: config.DenominationInfo{Symbol: "EVIL", Decimals: 9},
+		Security: &config.NetworkSecurity{
+			Mode:    config.SecurityModeInsecure,
+			Headers: map[string]string{"x-secret": "$OASIS_TEST_SECRET"},
+		},
+	})
+	data, err := m.Marshal(cliConfig.NetworkManifestFormatJSON)
+	require.NoError(err, "Marshal")
+	manifestFn := filepath.Join(dir, "manifest.json")
+	require.NoError(os.WriteFile(manifestFn, data, 0o600))
+
+	output, err := runCommand("--config", cfgFn, "network", "import", manifestFn, "--allow-unsigned", "--verify-node")
+	require.Error(err, "verification against a failing node should fail: %s", output)
+
+	headersLock.Lock()
+	defer headersLock.Unlock()
+	require.NotEmpty(headers, "node should have been queried")
+	for _, md := range headers {
+		require.Empty(md.Get("x-secret"), "manifest headers must not be sent to the node")
+	}
+}
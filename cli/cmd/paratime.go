@@ -7,6 +7,7 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/spf13/cobra"
 
+	"github.com/oasisprotocol/oasis-sdk/cli/cmd/common"
 	cliConfig "github.com/oasisprotocol/oasis-sdk/cli/config"
 	"github.com/oasisprotocol/oasis-sdk/cli/table"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
@@ -25,35 +26,49 @@ var (
 		Args:    cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg := cliConfig.Global()
-			table := table.New()
-			table.SetHeader([]string{"Network", "Paratime", "ID"})
 
-			var output [][]string
+			var paratimes []*paratimeInfo
 			for netName, net := range cfg.Networks.All {
 				for ptName, pt := range net.ParaTimes.All {
-					displayPtName := ptName
-					if net.ParaTimes.Default == ptName {
+					paratimes = append(paratimes, &paratimeInfo{
+						Network:     netName,
+						Name:        ptName,
+						Default:     net.ParaTimes.Default == ptName,
+						Description: pt.Description,
+						ID:          pt.ID,
+					})
+				}
+			}
+
+			// Sort output by network name and paratime name.
+			sort.Slice(paratimes, func(i, j int) bool {
+				if paratimes[i].Network != paratimes[j].Network {
+					return paratimes[i].Network < paratimes[j].Network
+				}
+				return paratimes[i].Name < paratimes[j].Name
+			})
+
+			common.Output(paratimes, func() {
+				table := table.New()
+				table.SetHeader([]string{"Network", "Paratime", "ID"})
+
+				var output [][]string
+				for _, pt := range paratimes {
+					displayPtName := pt.Name
+					if pt.Default {
 						displayPtName += defaultMarker
 					}
 
 					output = append(output, []string{
-						netName,
+						pt.Network,
 						displayPtName,
 						pt.ID,
 					})
 				}
-			}
 
-			// Sort output by network name and paratime name.
-			sort.Slice(output, func(i, j int) bool {
-				if output[i][0] != output[j][0] {
-					return output[i][0] < output[j][0]
-				}
-				return output[i][1] < output[j][1]
+				table.AppendBulk(output)
+				table.Render()
 			})
-
-			table.AppendBulk(output)
-			table.Render()
 		},
 	}
 
@@ -167,6 +182,15 @@ var (
 	}
 )
 
+// paratimeInfo is the structured output of the paratime list command.
+type paratimeInfo struct {
+	Network     string `json:"network"`
+	Name        string `json:"name"`
+	Default     bool   `json:"default"`
+	Description string `json:"description,omitempty"`
+	ID          string `json:"id"`
+}
+
 func init() {
 	paratimeCmd.AddCommand(paratimeListCmd)
 	paratimeCmd.AddCommand(paratimeAddCmd)
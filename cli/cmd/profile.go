@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"sort"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/oasisprotocol/oasis-sdk/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/oasis-sdk/cli/config"
+	"github.com/oasisprotocol/oasis-sdk/cli/table"
+)
+
+var (
+	profileDescription string
+	profileNetwork     string
+	profileParaTime    string
+	profileAccount     string
+
+	profileCmd = &cobra.Command{
+		Use:   "profile",
+		Short: "Manage profiles",
+		Long: "Manage profiles.\n\n" +
+			"A profile is a named selection of the network, paratime and account used by default.\n" +
+			"The profile is selected with the --profile flag, the " + common.ProfileEnvVar + " environment\n" +
+			"variable or the default profile, in that order. Explicit selector flags always take precedence.",
+	}
+
+	profileListCmd = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List configured profiles",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			selected, _ := common.GetProfileSelection(cfg)
+
+			var profiles []*profileInfo
+			for name, profile := range cfg.Profiles.All {
+				profiles = append(profiles, &profileInfo{
+					Name:        name,
+					Default:     cfg.Profiles.Default == name,
+					Selected:    selected == name,
+					Description: profile.Description,
+					Network:     profile.Network,
+					ParaTime:    profile.ParaTime,
+					Account:     profile.Account,
+				})
+			}
+
+			// Sort output by name.
+			sort.Slice(profiles, func(i, j int) bool {
+				return profiles[i].Name < profiles[j].Name
+			})
+
+			common.Output(profiles, func() {
+				table := table.New()
+				table.SetHeader([]string{"Name", "Network", "ParaTime", "Account", "Description"})
+
+				var output [][]string
+				for _, p := range profiles {
+					displayName := p.Name
+					if p.Selected {
+						displayName += defaultMarker
+					}
+
+					output = append(output, []string{
+						displayName,
+						p.Network,
+						p.ParaTime,
+						p.Account,
+						p.Description,
+					})
+				}
+
+				table.AppendBulk(output)
+				table.Render()
+			})
+		},
+	}
+
+	profileAddCmd = &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add a new profile",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			name := args[0]
+
+			profile := &cliConfig.Profile{
+				Description: profileDescription,
+				Network:     profileNetwork,
+				ParaTime:    profileParaTime,
+				Account:     profileAccount,
+			}
+			cobra.CheckErr(profile.Validate(cfg))
+
+			err := cfg.Profiles.Add(name, profile)
+			cobra.CheckErr(err)
+
+			err = cfg.Save()
+			cobra.CheckErr(err)
+		},
+	}
+
+	profileRmCmd = &cobra.Command{
+		Use:     "rm <name>",
+		Aliases: []string{"remove"},
+		Short:   "Remove an existing profile",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			name := args[0]
+
+			err := cfg.Profiles.Remove(name)
+			cobra.CheckErr(err)
+
+			err = cfg.Save()
+			cobra.CheckErr(err)
+		},
+	}
+
+	profileSetDefaultCmd = &cobra.Command{
+		Use:   "set-default [name]",
+		Short: "Sets the given profile as the default profile or clears the default if no name is given",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+			var name string
+			if len(args) > 0 {
+				name = args[0]
+			}
+
+			err := cfg.Profiles.SetDefault(name)
+			cobra.CheckErr(err)
+
+			err = cfg.Save()
+			cobra.CheckErr(err)
+		},
+	}
+)
+
+// profileInfo is the structured output of the profile list command.
+type profileInfo struct {
+	Name        string `json:"name"`
+	Default     bool   `json:"default"`
+	Selected    bool   `json:"selected"`
+	Description string `json:"description,omitempty"`
+	Network     string `json:"network,omitempty"`
+	ParaTime    string `json:"paratime,omitempty"`
+	Account     string `json:"account,omitempty"`
+}
+
+func init() {
+	profileAddFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	profileAddFlags.StringVar(&profileDescription, "description", "", "profile description")
+	profileAddFlags.StringVar(&profileNetwork, "network", "", "network to use (defaults to the default network)")
+	profileAddFlags.StringVar(&profileParaTime, "paratime", "", "paratime to use, requires --network (defaults to the network's default paratime)")
+	profileAddFlags.StringVar(&profileAccount, "account", "", "account to use (defaults to the default account)")
+	profileAddCmd.Flags().AddFlagSet(profileAddFlags)
+
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileAddCmd)
+	profileCmd.AddCommand(profileRmCmd)
+	profileCmd.AddCommand(profileSetDefaultCmd)
+}
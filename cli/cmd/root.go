@@ -10,10 +10,12 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/oasisprotocol/oasis-sdk/cli/cmd/common"
 	"github.com/oasisprotocol/oasis-sdk/cli/cmd/inspect"
 	"github.com/oasisprotocol/oasis-sdk/cli/config"
 	_ "github.com/oasisprotocol/oasis-sdk/cli/wallet/file"   // Register file wallet backend.
 	_ "github.com/oasisprotocol/oasis-sdk/cli/wallet/ledger" // Register ledger wallet backend.
+	"github.com/oasisprotocol/oasis-sdk/cli/wallet/plugin"
 )
 
 const (
@@ -32,15 +34,20 @@ var (
 
 // Execute executes the root command.
 func Execute() error {
+	// Make sure that any spawned wallet plugins are terminated once the command finishes.
+	defer plugin.CloseAll()
+
 	return rootCmd.Execute()
 }
 
 func initConfig() {
 	v := viper.New()
 
+	pluginDir := plugin.Directory()
 	if cfgFile != "" {
 		// Use config file from the flag.
 		v.SetConfigFile(cfgFile)
+		pluginDir = filepath.Join(filepath.Dir(cfgFile), "plugins")
 	} else {
 		const configFilename = "cli.toml"
 		configDir := config.Directory()
@@ -65,22 +72,42 @@ func initConfig() {
 
 	_ = v.ReadInConfig()
 
+	// Register wallet plugin backends from the selected configuration directory. This must happen
+	// before the configuration is validated as accounts may use plugin kinds.
+	plugin.DiscoverAndRegister(pluginDir)
+
 	// Load and validate global configuration.
 	err := config.Load(v)
 	cobra.CheckErr(err)
 	err = config.Global().Validate()
 	cobra.CheckErr(err)
+
+	// Persist migrated configuration, keeping a backup of the original.
+	if from, migrated := config.Global().Migrated(); migrated {
+		backupFn, err := config.Global().Backup()
+		cobra.CheckErr(err)
+		err = config.Global().Save()
+		cobra.CheckErr(err)
+
+		common.Infof("Migrated configuration from version %d to %d (backup saved to '%s').\n", from, config.Version, backupFn)
+	}
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file to use")
+	rootCmd.PersistentFlags().AddFlagSet(common.FormatFlag)
+	rootCmd.PersistentFlags().AddFlagSet(common.ProfileFlag)
 
 	rootCmd.AddCommand(networkCmd)
 	rootCmd.AddCommand(paratimeCmd)
+	rootCmd.AddCommand(profileCmd)
 	rootCmd.AddCommand(walletCmd)
+	rootCmd.AddCommand(addressBookCmd)
 	rootCmd.AddCommand(accountsCmd)
 	rootCmd.AddCommand(contractsCmd)
+	rootCmd.AddCommand(evmCmd)
+	rootCmd.AddCommand(txCmd)
 	rootCmd.AddCommand(inspect.Cmd)
 }
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/cli/wallet/plugin"
+)
+
+// helperProcessEnv is the environment variable that marks the helper process.
+const helperProcessEnv = "OASIS_CLI_TEST_HELPER_PROCESS"
+
+// TestHelperProcess is not a real test, it runs the CLI in a separate process for runCommand.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv(helperProcessEnv) != "1" {
+		return
+	}
+
+	// The CLI arguments follow the first "--" argument.
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	rootCmd.SetArgs(args[1:])
+	if err := Execute(); err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// runCommand runs the CLI with the given arguments in a fresh process so that no state (e.g.
+// registered wallet backends) is shared between commands.
+func runCommand(args ...string) (string, error) {
+	cmd := exec.Command(os.Args[0], append([]string{"-test.run=^TestHelperProcess$", "--"}, args...)...)
+	cmd.Env = append(os.Environ(), helperProcessEnv+"=1")
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+func TestPluginAccountConfig(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	cfgFn := filepath.Join(dir, "cli.toml")
+	require.NoError(os.WriteFile(cfgFn, nil, 0o600))
+
+	// Build the reference plugin into the plugins directory next to the configuration file.
+	pluginFn := filepath.Join(dir, "plugins", plugin.ExecutablePrefix+"test")
+	out, err := exec.Command("go", "build", "-o", pluginFn, "../wallet/plugin/testplugin/oasis-wallet-test").CombinedOutput()
+	require.NoError(err, "go build: %s", out)
+
+	output, err := runCommand("--config", cfgFn, "wallet", "create", "plug", "--kind", "test")
+	require.NoError(err, "wallet create: %s", output)
+
+	// Subsequent commands must be able to load a configuration with plugin accounts.
+	output, err = runCommand("--config", cfgFn, "wallet", "list")
+	require.NoError(err, "wallet list: %s", output)
+	require.Contains(output, "plug")
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/oasis-sdk/cli/cmd/common"
+	cliConfig "github.com/oasisprotocol/oasis-sdk/cli/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+)
+
+var (
+	txCmd = &cobra.Command{
+		Use:   "tx",
+		Short: "Raw transaction operations",
+		Long: "Raw transaction operations.\n\n" +
+			"Transactions can be exported into a file by passing --unsigned or --output-file to any\n" +
+			"command that generates transactions. The file contains the identity of the network and\n" +
+			"paratime the transaction is meant for.",
+	}
+
+	txShowCmd = &cobra.Command{
+		Use:   "show <filename>",
+		Short: "Pretty print a transaction",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+
+			tf, err := common.LoadTransactionFile(args[0])
+			cobra.CheckErr(err)
+			npa, err := tf.GetNPASelection(cfg)
+			cobra.CheckErr(err)
+			tx, err := tf.GetTransaction()
+			cobra.CheckErr(err)
+
+			out := txShowOutput{
+				Network:  npa.NetworkName,
+				ParaTime: npa.ParaTimeName,
+				Signed:   tf.Signed,
+			}
+			if tf.Signed {
+				tx, out.Signers, err = common.OpenSignedTransaction(npa, tx)
+				cobra.CheckErr(err)
+			}
+			out.Transaction = tx
+
+			common.Output(&out, func() {
+				fmt.Printf("Network:  %s", npa.NetworkName)
+				if len(npa.Network.Description) > 0 {
+					fmt.Printf(" (%s)", npa.Network.Description)
+				}
+				fmt.Println()
+				if npa.ParaTime != nil {
+					fmt.Printf("Paratime: %s", npa.ParaTimeName)
+					if len(npa.ParaTime.Description) > 0 {
+						fmt.Printf(" (%s)", npa.ParaTime.Description)
+					}
+					fmt.Println()
+				} else {
+					fmt.Println("Paratime: none (consensus layer)")
+				}
+
+				if out.Signed {
+					fmt.Printf("Signed:   yes (signatures valid)\n")
+					fmt.Printf("Signers:  %s\n", strings.Join(out.Signers, ", "))
+				} else {
+					fmt.Printf("Signed:   no\n")
+				}
+				fmt.Println()
+
+				common.PrintTransaction(npa, tx)
+			})
+		},
+	}
+
+	txSignCmd = &cobra.Command{
+		Use:   "sign <filename>",
+		Short: "Sign an exported unsigned transaction",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+
+			tf, err := common.LoadTransactionFile(args[0])
+			cobra.CheckErr(err)
+			if tf.Signed {
+				cobra.CheckErr("transaction is already signed")
+			}
+			npa, err := tf.GetNPASelection(cfg)
+			cobra.CheckErr(err)
+			tx, err := tf.GetTransaction()
+			cobra.CheckErr(err)
+
+			if npa.Account == nil {
+				cobra.CheckErr("no accounts configured in your wallet")
+			}
+
+			acc := common.LoadAccount(cfg, npa.AccountName)
+			sigTx, err := common.SignTransaction(npa, acc, tx)
+			cobra.CheckErr(err)
+
+			common.ExportTransaction(npa, sigTx)
+		},
+	}
+
+	txSubmitCmd = &cobra.Command{
+		Use:   "submit <filename>",
+		Short: "Submit an exported signed transaction",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := cliConfig.Global()
+
+			tf, err := common.LoadTransactionFile(args[0])
+			cobra.CheckErr(err)
+			if !tf.Signed {
+				cobra.CheckErr("transaction is not signed")
+			}
+			npa, err := tf.GetNPASelection(cfg)
+			cobra.CheckErr(err)
+			tx, err := tf.GetTransaction()
+			cobra.CheckErr(err)
+
+			// Make sure the transaction is properly signed before submitting it.
+			_, _, err = common.OpenSignedTransaction(npa, tx)
+			cobra.CheckErr(err)
+
+			ctx := context.Background()
+			conn, err := connection.Connect(ctx, npa.Network)
+			cobra.CheckErr(err)
+
+			common.SubmitTransaction(ctx, npa, conn, tx, nil, nil, nil)
+		},
+	}
+)
+
+// txShowOutput is the structured output of the tx show command.
+type txShowOutput struct {
+	Network     string      `json:"network"`
+	ParaTime    string      `json:"paratime,omitempty"`
+	Signed      bool        `json:"signed"`
+	Signers     []string    `json:"signers,omitempty"`
+	Transaction interface{} `json:"transaction"`
+}
+
+func init() {
+	txShowCmd.Flags().AddFlagSet(common.SelectorFlags)
+
+	txSignCmd.Flags().AddFlagSet(common.SelectorFlags)
+	txSignCmd.Flags().AddFlagSet(common.TransactionOutputFlags)
+
+	txSubmitCmd.Flags().AddFlagSet(common.SelectorFlags)
+
+	txCmd.AddCommand(txShowCmd)
+	txCmd.AddCommand(txSignCmd)
+	txCmd.AddCommand(txSubmitCmd)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/oasisprotocol/oasis-sdk/cli/table"
 	"github.com/oasisprotocol/oasis-sdk/cli/wallet"
 	walletFile "github.com/oasisprotocol/oasis-sdk/cli/wallet/file"
+	"github.com/oasisprotocol/oasis-sdk/cli/wallet/plugin"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
 )
 
@@ -32,28 +33,43 @@ var (
 		Args:    cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg := config.Global()
-			table := table.New()
-			table.SetHeader([]string{"Name", "Kind", "Address"})
 
-			var output [][]string
+			var accounts []*accountInfo
 			for name, acc := range cfg.Wallet.All {
-				if cfg.Wallet.Default == name {
-					name += defaultMarker
-				}
-				output = append(output, []string{
-					name,
-					acc.PrettyKind(),
-					acc.Address,
+				accounts = append(accounts, &accountInfo{
+					Name:        name,
+					Default:     cfg.Wallet.Default == name,
+					Description: acc.Description,
+					Kind:        acc.PrettyKind(),
+					Address:     acc.Address,
 				})
 			}
 
 			// Sort output by name.
-			sort.Slice(output, func(i, j int) bool {
-				return output[i][0] < output[j][0]
+			sort.Slice(accounts, func(i, j int) bool {
+				return accounts[i].Name < accounts[j].Name
 			})
 
-			table.AppendBulk(output)
-			table.Render()
+			common.Output(accounts, func() {
+				table := table.New()
+				table.SetHeader([]string{"Name", "Kind", "Address"})
+
+				var output [][]string
+				for _, acc := range accounts {
+					name := acc.Name
+					if acc.Default {
+						name += defaultMarker
+					}
+					output = append(output, []string{
+						name,
+						acc.Kind,
+						acc.Address,
+					})
+				}
+
+				table.AppendBulk(output)
+				table.Render()
+			})
 		},
 	}
 
@@ -258,12 +274,38 @@ var (
 	}
 )
 
+// accountInfo is the structured output of the wallet list command.
+type accountInfo struct {
+	Name        string `json:"name"`
+	Default     bool   `json:"default"`
+	Description string `json:"description,omitempty"`
+	Kind        string `json:"kind"`
+	Address     string `json:"address"`
+}
+
+// publicAccountInfo is the structured output of the wallet show command.
+type publicAccountInfo struct {
+	PublicKey  string `json:"public_key"`
+	Address    string `json:"address"`
+	EthAddress string `json:"eth_address,omitempty"`
+}
+
 func showPublicWalletInfo(wallet wallet.Account) {
-	fmt.Printf("Public Key:       %s\n", wallet.Signer().Public())
-	fmt.Printf("Address:          %s\n", wallet.Address())
+	info := publicAccountInfo{
+		PublicKey: wallet.Signer().Public().String(),
+		Address:   wallet.Address().String(),
+	}
 	if wallet.SignatureAddressSpec().Secp256k1Eth != nil {
-		fmt.Printf("Ethereum address: %s\n", helpers.EthAddressFromPubKey(*wallet.SignatureAddressSpec().Secp256k1Eth))
+		info.EthAddress = helpers.EthAddressFromPubKey(*wallet.SignatureAddressSpec().Secp256k1Eth)
 	}
+
+	common.Output(&info, func() {
+		fmt.Printf("Public Key:       %s\n", info.PublicKey)
+		fmt.Printf("Address:          %s\n", info.Address)
+		if info.EthAddress != "" {
+			fmt.Printf("Ethereum address: %s\n", info.EthAddress)
+		}
+	})
 }
 
 func init() {
@@ -274,12 +316,15 @@ func init() {
 	for _, w := range wallet.AvailableKinds() {
 		kinds = append(kinds, w.Kind())
 	}
+	kinds = append(kinds, "<plugin>")
 	walletFlags.StringVar(&accKind, "kind", "file", fmt.Sprintf("Account kind [%s]", strings.Join(kinds, ", ")))
 
 	// TODO: Group flags in usage by tweaking the usage template/function.
 	for _, af := range wallet.AvailableKinds() {
 		walletFlags.AddFlagSet(af.Flags())
 	}
+	// Plugins are only discovered once the configuration is loaded so add their flags explicitly.
+	walletFlags.AddFlagSet(plugin.Flags)
 
 	walletCreateCmd.Flags().AddFlagSet(walletFlags)
 
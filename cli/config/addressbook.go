@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// AddressBook contains the configuration of the address book.
+type AddressBook struct {
+	// All is a map of all configured address book entries.
+	All map[string]*AddressBookEntry `mapstructure:",remain"`
+}
+
+// Validate performs config validation.
+func (ab *AddressBook) Validate() error {
+	for name, entry := range ab.All {
+		if err := config.ValidateIdentifier(name); err != nil {
+			return fmt.Errorf("malformed contact name '%s': %w", name, err)
+		}
+
+		if err := entry.Validate(); err != nil {
+			return fmt.Errorf("contact '%s': %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Add adds a new address book entry.
+func (ab *AddressBook) Add(name string, entry *AddressBookEntry) error {
+	if _, exists := ab.All[name]; exists {
+		return fmt.Errorf("contact '%s' already exists", name)
+	}
+
+	if err := config.ValidateIdentifier(name); err != nil {
+		return fmt.Errorf("malformed contact name '%s': %w", name, err)
+	}
+
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	if ab.All == nil {
+		ab.All = make(map[string]*AddressBookEntry)
+	}
+	ab.All[name] = entry
+
+	return nil
+}
+
+// Remove removes the given address book entry.
+func (ab *AddressBook) Remove(name string) error {
+	if _, exists := ab.All[name]; !exists {
+		return fmt.Errorf("contact '%s' does not exist", name)
+	}
+
+	delete(ab.All, name)
+
+	return nil
+}
+
+// AddressBookEntry is an address book entry.
+type AddressBookEntry struct {
+	Description string `mapstructure:"description"`
+	// Address is either an Oasis Bech32 address or a hex-encoded Ethereum address.
+	Address string `mapstructure:"address"`
+}
+
+// Validate performs config validation.
+func (e *AddressBookEntry) Validate() error {
+	if _, err := e.resolveAddress(); err != nil {
+		return fmt.Errorf("malformed address '%s': %w", e.Address, err)
+	}
+	return nil
+}
+
+func (e *AddressBookEntry) resolveAddress() (*types.Address, error) {
+	// Only accept plain addresses as explicit forms depend on the selected network.
+	if !strings.HasPrefix(e.Address, "oasis1") && !strings.HasPrefix(e.Address, "0x") {
+		return nil, fmt.Errorf("unsupported address format")
+	}
+	return helpers.ResolveAddress(nil, e.Address)
+}
+
+// GetAddress returns the parsed address of the entry.
+func (e *AddressBookEntry) GetAddress() types.Address {
+	address, err := e.resolveAddress()
+	if err != nil {
+		panic(err)
+	}
+	return *address
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestAddressBook(t *testing.T) {
+	require := require.New(t)
+
+	daveEth := "0x" + hex.EncodeToString(sdkTesting.Dave.EthAddress[:])
+
+	var ab AddressBook
+	require.NoError(ab.Validate(), "empty address book should be valid")
+
+	for _, tc := range []struct {
+		name    string
+		address string
+		valid   bool
+	}{
+		{"alice", sdkTesting.Alice.Address.String(), true},
+		{"dave", daveEth, true},
+		{"alice", sdkTesting.Bob.Address.String(), false},
+		{"Bob", sdkTesting.Bob.Address.String(), false},
+		{"bob", "", false},
+		{"bob", "0xdeadbeef", false},
+		{"bob", "paratime:emerald", false},
+		{"bob", "pool:rewards", false},
+	} {
+		err := ab.Add(tc.name, &AddressBookEntry{Address: tc.address})
+		if tc.valid {
+			require.NoError(err, "Add(%s, %s)", tc.name, tc.address)
+		} else {
+			require.Error(err, "Add(%s, %s) should fail", tc.name, tc.address)
+		}
+	}
+	require.NoError(ab.Validate())
+	require.Len(ab.All, 2)
+
+	require.Equal(sdkTesting.Alice.Address, ab.All["alice"].GetAddress())
+	require.Equal(
+		types.NewAddressRaw(types.AddressV0Secp256k1EthContext, sdkTesting.Dave.EthAddress[:]),
+		ab.All["dave"].GetAddress(),
+	)
+
+	require.NoError(ab.Remove("alice"), "Remove")
+	require.Error(ab.Remove("alice"), "Remove should fail for missing contacts")
+	require.NotContains(ab.All, "alice")
+
+	// Malformed entries should be caught by validation.
+	ab.All["invalid"] = &AddressBookEntry{Address: "foo"}
+	require.Error(ab.Validate())
+	delete(ab.All, "invalid")
+	ab.All["Invalid"] = &AddressBookEntry{Address: daveEth}
+	require.Error(ab.Validate())
+}
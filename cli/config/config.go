@@ -43,15 +43,51 @@ func ResetDefaults() {
 
 // Config contains the CLI configuration.
 type Config struct {
-	viper *viper.Viper
+	viper        *viper.Viper
+	migrated     bool
+	migratedFrom uint32
 
-	Networks config.Networks `mapstructure:"networks"`
-	Wallet   Wallet          `mapstructure:"wallets"`
+	// Version is the version of the configuration schema.
+	Version uint32 `mapstructure:"version"`
+
+	Networks    config.Networks `mapstructure:"networks"`
+	Wallet      Wallet          `mapstructure:"wallets"`
+	AddressBook AddressBook     `mapstructure:"address_book"`
+	Profiles    Profiles        `mapstructure:"profiles,omitempty"`
 }
 
 // Load loads the configuration structure from viper.
+//
+// Configurations using an older schema version are migrated in memory. Use Migrated to check
+// whether a migration took place and Save to persist the migrated configuration.
 func (cfg *Config) Load(v *viper.Viper) error {
 	cfg.viper = v
+
+	raw := v.AllSettings()
+	if len(raw) == 0 {
+		// Nothing to migrate in an empty configuration, it uses the current schema.
+		cfg.migrated = false
+		if err := v.Unmarshal(cfg); err != nil {
+			return err
+		}
+		cfg.Version = Version
+		return nil
+	}
+	from, err := migrate(raw)
+	if err != nil {
+		return err
+	}
+	cfg.migratedFrom = from
+	cfg.migrated = from != Version
+	if cfg.migrated {
+		// Replace the configuration with the migrated one.
+		var buf bytes.Buffer
+		_ = v.ReadConfig(&buf)
+		if err = v.MergeConfigMap(raw); err != nil {
+			return err
+		}
+	}
+
 	return v.Unmarshal(cfg)
 }
 
@@ -61,6 +97,9 @@ func encode(in interface{}) (interface{}, error) {
 
 	v := reflect.ValueOf(in)
 	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
 		v = v.Elem()
 	}
 
@@ -87,6 +126,11 @@ func encode(in interface{}) (interface{}, error) {
 				}
 			}
 
+			// Skip empty values when requested.
+			if attributes["omitempty"] && v.Field(i).IsZero() {
+				continue
+			}
+
 			// Encode value.
 			value, err := encode(v.Field(i).Interface())
 			if err != nil {
@@ -168,5 +212,24 @@ func (cfg *Config) Validate() error {
 	if err := cfg.Wallet.Validate(); err != nil {
 		return fmt.Errorf("failed to validate wallet configuration: %w", err)
 	}
+	if err := cfg.AddressBook.Validate(); err != nil {
+		return fmt.Errorf("failed to validate address book configuration: %w", err)
+	}
+	if err := cfg.Profiles.Validate(); err != nil {
+		return fmt.Errorf("failed to validate profile configuration: %w", err)
+	}
+	for name, profile := range cfg.Profiles.All {
+		if err := profile.Validate(cfg); err != nil {
+			return fmt.Errorf("failed to validate profile configuration: profile '%s': %w", name, err)
+		}
+	}
+
+	// Make sure contact names do not shadow account names as both can be used in place of an
+	// address.
+	for name := range cfg.AddressBook.All {
+		if _, exists := cfg.Wallet.All[name]; exists {
+			return fmt.Errorf("contact '%s' has the same name as an account in the wallet", name)
+		}
+	}
 	return nil
 }
@@ -6,5 +6,6 @@ import (
 
 // Default is the default config that should be used in case no configuration file exists.
 var Default = Config{
+	Version:  Version,
 	Networks: config.DefaultNetworks,
 }
@@ -0,0 +1,272 @@
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/pelletier/go-toml"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+const (
+	// NetworkManifestVersion is the current version of the network manifest format.
+	NetworkManifestVersion = 1
+
+	// NetworkManifestFormatJSON is the JSON network manifest encoding.
+	NetworkManifestFormatJSON = "json"
+	// NetworkManifestFormatTOML is the TOML network manifest encoding.
+	NetworkManifestFormatTOML = "toml"
+)
+
+// networkManifestSignatureContextBase is the base of the network manifest signature context. The
+// chain context of the described network is appended to it.
+var networkManifestSignatureContextBase = []byte("oasis-cli/network-manifest: v1")
+
+// NetworkManifest is a shareable description of a network, including its RPC endpoint,
+// denomination and paratimes, signed by its publisher.
+//
+// It can be encoded either as JSON or as TOML.
+type NetworkManifest struct {
+	// Version is the version of the network manifest format.
+	Version uint16
+	// Name is the suggested name of the network.
+	Name string
+	// Network is the network configuration.
+	Network *config.Network
+
+	// Signer is the signature address specification of the publisher. It is nil for unsigned
+	// manifests.
+	Signer *types.SignatureAddressSpec
+	// Signature is the publisher's signature over the manifest.
+	Signature []byte
+}
+
+// NewNetworkManifest creates a new unsigned manifest for the given network.
+func NewNetworkManifest(name string, net *config.Network) *NetworkManifest {
+	return &NetworkManifest{
+		Version: NetworkManifestVersion,
+		Name:    name,
+		Network: net,
+	}
+}
+
+// Validate performs manifest validation. It does not verify the signature.
+func (m *NetworkManifest) Validate() error {
+	if m.Version != NetworkManifestVersion {
+		return fmt.Errorf("unsupported network manifest version: %d", m.Version)
+	}
+	if err := config.ValidateIdentifier(m.Name); err != nil {
+		return fmt.Errorf("malformed network name '%s': %w", m.Name, err)
+	}
+	if m.Network == nil {
+		return fmt.Errorf("missing network")
+	}
+	if err := m.Network.Validate(); err != nil {
+		return fmt.Errorf("network '%s': %w", m.Name, err)
+	}
+	return nil
+}
+
+// ImportNetwork returns the network configuration to import from the manifest.
+//
+// The connection security profile may reference local files and environment variables which are
+// expanded into request headers, so the one from the manifest is only used when acceptSecurity
+// is set. Otherwise the profile of the existing local network (which may be nil) is kept.
+func (m *NetworkManifest) ImportNetwork(existing *config.Network, acceptSecurity bool) *config.Network {
+	net := *m.Network
+	if !acceptSecurity {
+		net.Security = nil
+		if existing != nil {
+			net.Security = existing.Security
+		}
+	}
+	return &net
+}
+
+// body returns the manifest content without the signature.
+func (m *NetworkManifest) body() (map[string]interface{}, error) {
+	net, err := encode(m.Network)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"version": m.Version,
+		"name":    m.Name,
+		"network": net,
+	}, nil
+}
+
+// signatureContext returns the signature context of the manifest.
+func (m *NetworkManifest) signatureContext() []byte {
+	return signature.Context(m.Network.ChainContext).New(networkManifestSignatureContextBase)
+}
+
+// signedMessage returns the canonical encoding of the manifest content that is signed.
+func (m *NetworkManifest) signedMessage() ([]byte, error) {
+	body, err := m.body()
+	if err != nil {
+		return nil, err
+	}
+	// Map keys are sorted when encoding to JSON so the encoding is canonical.
+	return json.Marshal(body)
+}
+
+// Sign signs the manifest with the given signer.
+func (m *NetworkManifest) Sign(signer signature.Signer, spec types.SignatureAddressSpec) error {
+	if !spec.PublicKey().Equal(signer.Public()) {
+		return fmt.Errorf("signature address specification does not match the signer")
+	}
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	msg, err := m.signedMessage()
+	if err != nil {
+		return err
+	}
+	sig, err := signer.ContextSign(m.signatureContext(), msg)
+	if err != nil {
+		return fmt.Errorf("failed to sign network manifest: %w", err)
+	}
+	m.Signer = &spec
+	m.Signature = sig
+	return nil
+}
+
+// IsSigned returns true iff the manifest carries a signature.
+func (m *NetworkManifest) IsSigned() bool {
+	return m.Signer != nil
+}
+
+// SignerAddress returns the address of the manifest's signer.
+//
+// Panics if the manifest is not signed.
+func (m *NetworkManifest) SignerAddress() types.Address {
+	return types.NewAddress(*m.Signer)
+}
+
+// Verify verifies the manifest's signature.
+func (m *NetworkManifest) Verify() error {
+	if !m.IsSigned() {
+		return fmt.Errorf("network manifest is not signed")
+	}
+	msg, err := m.signedMessage()
+	if err != nil {
+		return err
+	}
+	if !m.Signer.PublicKey().Verify(m.signatureContext(), msg, m.Signature) {
+		return fmt.Errorf("invalid network manifest signature")
+	}
+	return nil
+}
+
+// Marshal encodes the manifest in the given format.
+func (m *NetworkManifest) Marshal(format string) ([]byte, error) {
+	doc, err := m.body()
+	if err != nil {
+		return nil, err
+	}
+	if m.IsSigned() {
+		var rawSigner []byte
+		if rawSigner, err = json.Marshal(m.Signer); err != nil {
+			return nil, err
+		}
+		var signer map[string]interface{}
+		if err = json.Unmarshal(rawSigner, &signer); err != nil {
+			return nil, err
+		}
+		doc["signature"] = map[string]interface{}{
+			"signer":    signer,
+			"signature": base64.StdEncoding.EncodeToString(m.Signature),
+		}
+	}
+
+	switch format {
+	case NetworkManifestFormatJSON:
+		return json.MarshalIndent(doc, "", "  ")
+	case NetworkManifestFormatTOML:
+		tree, err := toml.TreeFromMap(doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode network manifest: %w", err)
+		}
+		s, err := tree.ToTomlString()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode network manifest: %w", err)
+		}
+		return []byte(s), nil
+	default:
+		return nil, fmt.Errorf("unsupported network manifest format '%s'", format)
+	}
+}
+
+// UnmarshalNetworkManifest decodes a network manifest, automatically detecting its encoding.
+//
+// The manifest is validated but its signature is not verified.
+func UnmarshalNetworkManifest(data []byte) (*NetworkManifest, error) {
+	var doc map[string]interface{}
+	switch trimmed := bytes.TrimSpace(data); {
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		if err := json.Unmarshal(trimmed, &doc); err != nil {
+			return nil, fmt.Errorf("malformed network manifest: %w", err)
+		}
+	default:
+		tree, err := toml.LoadBytes(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("malformed network manifest: %w", err)
+		}
+		doc = tree.ToMap()
+	}
+
+	var raw struct {
+		Version   uint16                 `mapstructure:"version"`
+		Name      string                 `mapstructure:"name"`
+		Network   map[string]interface{} `mapstructure:"network"`
+		Signature *struct {
+			Signer    map[string]interface{} `mapstructure:"signer"`
+			Signature string                 `mapstructure:"signature"`
+		} `mapstructure:"signature"`
+	}
+	if err := mapstructure.Decode(doc, &raw); err != nil {
+		return nil, fmt.Errorf("malformed network manifest: %w", err)
+	}
+
+	m := NetworkManifest{
+		Version: raw.Version,
+		Name:    raw.Name,
+	}
+	if raw.Network != nil {
+		m.Network = &config.Network{}
+		if err := mapstructure.Decode(raw.Network, m.Network); err != nil {
+			return nil, fmt.Errorf("malformed network manifest: %w", err)
+		}
+	}
+	if raw.Signature != nil {
+		rawSigner, err := json.Marshal(raw.Signature.Signer)
+		if err != nil {
+			return nil, err
+		}
+		var signer types.SignatureAddressSpec
+		if err = json.Unmarshal(rawSigner, &signer); err != nil {
+			return nil, fmt.Errorf("malformed network manifest signer: %w", err)
+		}
+		if signer.PublicKey().PublicKey == nil {
+			return nil, fmt.Errorf("malformed network manifest signer")
+		}
+		m.Signer = &signer
+
+		if m.Signature, err = base64.StdEncoding.DecodeString(raw.Signature.Signature); err != nil {
+			return nil, fmt.Errorf("malformed network manifest signature: %w", err)
+		}
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
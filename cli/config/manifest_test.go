@@ -0,0 +1,109 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+)
+
+func testManifestNetwork() *config.Network {
+	return &config.Network{
+		Description:  "Development network",
+		ChainContext: "2bd8b38ef1c1b24e6ea0dd6c84f3b2bbd2b16e9e81bd5e0f4bd0b2c77d32eb5d",
+		RPC:          "devnet.example.com:443",
+		Denomination: config.DenominationInfo{Symbol: "DEV", Decimals: 9},
+		ParaTimes: config.ParaTimes{
+			Default: "evm",
+			All: map[string]*config.ParaTime{
+				"evm": {
+					Description: "EVM ParaTime",
+					ID:          "8000000000000000000000000000000000000000000000000000000000000001",
+					Denominations: map[string]*config.DenominationInfo{
+						config.NativeDenominationKey: {Symbol: "DEV", Decimals: 18},
+						"USDT":                       {Symbol: "USDT", Decimals: 6},
+					},
+				},
+				"plain": {
+					ID: "8000000000000000000000000000000000000000000000000000000000000002",
+				},
+			},
+		},
+		Security: &config.NetworkSecurity{
+			Headers: map[string]string{"x-api-key": "$DEVNET_API_KEY"},
+		},
+	}
+}
+
+func TestNetworkManifest(t *testing.T) {
+	require := require.New(t)
+
+	for _, format := range []string{NetworkManifestFormatJSON, NetworkManifestFormatTOML} {
+		m := NewNetworkManifest("devnet", testManifestNetwork())
+		require.NoError(m.Sign(sdkTesting.Alice.Signer, sdkTesting.Alice.SigSpec), "Sign")
+
+		data, err := m.Marshal(format)
+		require.NoError(err, "Marshal %s", format)
+
+		decoded, err := UnmarshalNetworkManifest(data)
+		require.NoError(err, "UnmarshalNetworkManifest %s", format)
+		require.NoError(decoded.Verify(), "Verify %s", format)
+		require.Equal("devnet", decoded.Name)
+		require.Equal(sdkTesting.Alice.Address, decoded.SignerAddress())
+		require.EqualValues(m.Network.ParaTimes.All["evm"], decoded.Network.ParaTimes.All["evm"], format)
+		require.Equal(m.Network.Security, decoded.Network.Security, format)
+
+		// Tampering with the network must invalidate the signature.
+		decoded.Network.RPC = "evil.example.com:443"
+		require.Error(decoded.Verify(), "Verify should fail for tampered manifest (%s)", format)
+	}
+}
+
+func TestNetworkManifestUnsigned(t *testing.T) {
+	require := require.New(t)
+
+	m := NewNetworkManifest("devnet", testManifestNetwork())
+	data, err := m.Marshal(NetworkManifestFormatJSON)
+	require.NoError(err, "Marshal")
+
+	decoded, err := UnmarshalNetworkManifest(data)
+	require.NoError(err, "UnmarshalNetworkManifest")
+	require.False(decoded.IsSigned())
+	require.Error(decoded.Verify(), "Verify should fail for unsigned manifest")
+
+	// Signing with a mismatched address specification must fail.
+	require.Error(m.Sign(sdkTesting.Alice.Signer, sdkTesting.Bob.SigSpec))
+
+	// Invalid networks must be rejected.
+	_, err = UnmarshalNetworkManifest([]byte(`{"version": 1, "name": "devnet", "network": {"chain_context": "invalid"}}`))
+	require.Error(err, "UnmarshalNetworkManifest should fail for invalid network")
+	_, err = UnmarshalNetworkManifest([]byte(`version = 2`))
+	require.Error(err, "UnmarshalNetworkManifest should fail for unsupported version")
+}
+
+func TestNetworkManifestImportNetwork(t *testing.T) {
+	require := require.New(t)
+
+	m := NewNetworkManifest("devnet", testManifestNetwork())
+	require.NotNil(m.Network.Security)
+
+	// The manifest must not be able to set headers by default.
+	net := m.ImportNetwork(nil, false)
+	require.Nil(net.Security, "security profile should be dropped")
+	require.Equal(m.Network.RPC, net.RPC)
+	require.NotNil(m.Network.Security, "manifest should not be modified")
+
+	// The local security profile should be kept.
+	local := &config.Network{
+		Security: &config.NetworkSecurity{CACert: "ca.pem"},
+	}
+	net = m.ImportNetwork(local, false)
+	require.Equal(local.Security, net.Security)
+
+	// Unless the security profile is explicitly accepted.
+	net = m.ImportNetwork(local, true)
+	require.Equal(m.Network.Security, net.Security)
+	require.Equal(map[string]string{"x-api-key": "$DEVNET_API_KEY"}, net.Security.Headers)
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cast"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+)
+
+// Version is the version of the configuration schema supported by this CLI.
+const Version = 1
+
+// migration migrates the raw configuration from one schema version to the next.
+type migration struct {
+	description string
+	apply       func(raw map[string]interface{}) error
+}
+
+// migrations are the schema migrations indexed by the version they migrate from.
+var migrations = []migration{
+	0: {
+		description: "populate missing native denominations of known paratimes",
+		apply:       migrateNativeDenominations,
+	},
+}
+
+// migrate migrates the raw configuration to the latest schema version in place and returns the
+// version it was migrated from.
+func migrate(raw map[string]interface{}) (uint32, error) {
+	from, err := cast.ToUint32E(raw["version"])
+	if err != nil {
+		return 0, fmt.Errorf("malformed configuration version: %w", err)
+	}
+	if from > Version {
+		return from, fmt.Errorf("configuration version %d is newer than the supported version %d, upgrade the CLI", from, Version)
+	}
+
+	for v := from; v < Version; v++ {
+		m := migrations[v]
+		if err = m.apply(raw); err != nil {
+			return from, fmt.Errorf("failed to migrate configuration from version %d (%s): %w", v, m.description, err)
+		}
+		raw["version"] = v + 1
+	}
+	return from, nil
+}
+
+// migrateNativeDenominations populates the native denomination of paratimes that were configured
+// before denominations were introduced, using the known defaults for the paratime.
+func migrateNativeDenominations(raw map[string]interface{}) error {
+	networks, _ := raw["networks"].(map[string]interface{})
+	for netName, rawNet := range networks {
+		net, ok := rawNet.(map[string]interface{})
+		if !ok || netName == "default" {
+			continue
+		}
+		paratimes, _ := net["paratimes"].(map[string]interface{})
+		for ptName, rawPt := range paratimes {
+			pt, ok := rawPt.(map[string]interface{})
+			if !ok || ptName == "default" {
+				continue
+			}
+
+			denoms, _ := pt["denominations"].(map[string]interface{})
+			if _, exists := denoms[config.NativeDenominationKey]; exists {
+				continue
+			}
+			native := knownNativeDenomination(cast.ToString(net["chain_context"]), cast.ToString(pt["id"]))
+			if native == nil {
+				continue
+			}
+
+			if denoms == nil {
+				denoms = make(map[string]interface{})
+			}
+			denoms[config.NativeDenominationKey] = map[string]interface{}{
+				"symbol":   native.Symbol,
+				"decimals": native.Decimals,
+			}
+			pt["denominations"] = denoms
+		}
+	}
+	return nil
+}
+
+// knownNativeDenomination returns the native denomination of the given paratime in case it is
+// one of the default paratimes.
+func knownNativeDenomination(chainContext, id string) *config.DenominationInfo {
+	for _, net := range config.DefaultNetworks.All {
+		if net.ChainContext != chainContext {
+			continue
+		}
+		for _, pt := range net.ParaTimes.All {
+			if pt.ID == id {
+				return pt.Denominations[config.NativeDenominationKey]
+			}
+		}
+	}
+	return nil
+}
+
+// Migrated returns the schema version that the configuration was migrated from when loaded and
+// whether a migration took place.
+func (cfg *Config) Migrated() (uint32, bool) {
+	return cfg.migratedFrom, cfg.migrated
+}
+
+// Backup copies the configuration file to a timestamped backup file next to it and returns the
+// path of the backup.
+func (cfg *Config) Backup() (string, error) {
+	fn := cfg.viper.ConfigFileUsed()
+	if fn == "" {
+		return "", fmt.Errorf("no configuration file in use")
+	}
+
+	src, err := os.Open(fn)
+	if err != nil {
+		return "", fmt.Errorf("failed to open configuration file: %w", err)
+	}
+	defer src.Close()
+
+	backupFn := fmt.Sprintf("%s.v%d-%s.bak", fn, cfg.migratedFrom, time.Now().UTC().Format("20060102T150405Z"))
+	dst, err := os.OpenFile(backupFn, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("failed to create configuration backup: %w", err)
+	}
+	if _, err = io.Copy(dst, src); err != nil {
+		dst.Close()
+		return "", fmt.Errorf("failed to write configuration backup: %w", err)
+	}
+	if err = dst.Close(); err != nil {
+		return "", fmt.Errorf("failed to write configuration backup: %w", err)
+	}
+	return backupFn, nil
+}
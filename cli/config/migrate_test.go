@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+)
+
+const legacyConfig = `
+[networks]
+default = "mainnet"
+
+[networks.mainnet]
+chain_context = "b11b369e0da5bb230b220127f5e7b242d385ef8c6f54906243f30af63c815535"
+rpc = "grpc.oasis.dev:443"
+
+[networks.mainnet.denomination]
+symbol = "ROSE"
+decimals = 9
+
+[networks.mainnet.paratimes]
+default = "emerald"
+
+[networks.mainnet.paratimes.emerald]
+id = "000000000000000000000000000000000000000000000000e2eaa99fc008f87f"
+
+[networks.mainnet.paratimes.custom]
+id = "8000000000000000000000000000000000000000000000000000000000000001"
+`
+
+func loadTestConfig(t *testing.T, content string) (*Config, string) {
+	fn := filepath.Join(t.TempDir(), "cli.toml")
+	require.NoError(t, os.WriteFile(fn, []byte(content), 0o600))
+
+	v := viper.New()
+	v.SetConfigFile(fn)
+	require.NoError(t, v.ReadInConfig())
+
+	var cfg Config
+	require.NoError(t, cfg.Load(v))
+	return &cfg, fn
+}
+
+func TestMigrate(t *testing.T) {
+	require := require.New(t)
+
+	cfg, fn := loadTestConfig(t, legacyConfig)
+	from, migrated := cfg.Migrated()
+	require.True(migrated)
+	require.EqualValues(0, from)
+	require.EqualValues(Version, cfg.Version)
+
+	emerald := cfg.Networks.All["mainnet"].ParaTimes.All["emerald"]
+	require.Equal(&config.DenominationInfo{Symbol: "ROSE", Decimals: 18}, emerald.Denominations[config.NativeDenominationKey])
+	custom := cfg.Networks.All["mainnet"].ParaTimes.All["custom"]
+	require.Empty(custom.Denominations)
+
+	backupFn, err := cfg.Backup()
+	require.NoError(err, "Backup")
+	backup, err := os.ReadFile(backupFn)
+	require.NoError(err)
+	require.Equal(legacyConfig, string(backup))
+
+	require.NoError(cfg.Save(), "Save")
+
+	// Loading the saved configuration should not migrate it again.
+	data, err := os.ReadFile(fn)
+	require.NoError(err)
+	cfg, _ = loadTestConfig(t, string(data))
+	_, migrated = cfg.Migrated()
+	require.False(migrated)
+	emerald = cfg.Networks.All["mainnet"].ParaTimes.All["emerald"]
+	require.Equal(&config.DenominationInfo{Symbol: "ROSE", Decimals: 18}, emerald.Denominations[config.NativeDenominationKey])
+}
+
+func TestMigrateNewerVersion(t *testing.T) {
+	require := require.New(t)
+
+	fn := filepath.Join(t.TempDir(), "cli.toml")
+	require.NoError(os.WriteFile(fn, []byte("version = 1000\n"), 0o600))
+	v := viper.New()
+	v.SetConfigFile(fn)
+	require.NoError(v.ReadInConfig())
+
+	var cfg Config
+	require.Error(cfg.Load(v), "Load should fail for newer configuration versions")
+}
+
+func TestLoadEmptyConfig(t *testing.T) {
+	require := require.New(t)
+
+	cfg, fn := loadTestConfig(t, "")
+	_, migrated := cfg.Migrated()
+	require.False(migrated, "empty configuration should not be migrated")
+	require.EqualValues(Version, cfg.Version)
+
+	// The saved configuration should use the current version.
+	require.NoError(cfg.Save(), "Save")
+	data, err := os.ReadFile(fn)
+	require.NoError(err)
+	cfg, _ = loadTestConfig(t, string(data))
+	_, migrated = cfg.Migrated()
+	require.False(migrated, "saved configuration should not be migrated again")
+	require.EqualValues(Version, cfg.Version)
+}
+
+func TestProfileValidate(t *testing.T) {
+	require := require.New(t)
+
+	cfg := &Config{Networks: config.DefaultNetworks}
+
+	for _, tc := range []struct {
+		profile Profile
+		valid   bool
+	}{
+		{Profile{}, true},
+		{Profile{Network: "testnet"}, true},
+		{Profile{Network: "testnet", ParaTime: "cipher"}, true},
+		{Profile{Network: "devnet"}, false},
+		{Profile{Network: "testnet", ParaTime: "sapphire"}, false},
+		{Profile{ParaTime: "cipher"}, false},
+		{Profile{Account: "alice"}, false},
+	} {
+		err := tc.profile.Validate(cfg)
+		if tc.valid {
+			require.NoError(err, "profile %+v should be valid", tc.profile)
+		} else {
+			require.Error(err, "profile %+v should be invalid", tc.profile)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+)
+
+// Profiles contains the configuration of the named profiles.
+type Profiles struct {
+	// Default is the name of the profile used when none is explicitly selected.
+	Default string `mapstructure:"default,omitempty"`
+
+	// All is a map of all configured profiles.
+	All map[string]*Profile `mapstructure:",remain"`
+}
+
+// Validate performs config validation.
+func (p *Profiles) Validate() error {
+	// Make sure the default profile actually exists.
+	if _, exists := p.All[p.Default]; p.Default != "" && !exists {
+		return fmt.Errorf("default profile '%s' does not exist", p.Default)
+	}
+
+	for name := range p.All {
+		if err := config.ValidateIdentifier(name); err != nil {
+			return fmt.Errorf("malformed profile name '%s': %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Add adds a new profile.
+func (p *Profiles) Add(name string, profile *Profile) error {
+	if _, exists := p.All[name]; exists {
+		return fmt.Errorf("profile '%s' already exists", name)
+	}
+
+	if err := config.ValidateIdentifier(name); err != nil {
+		return fmt.Errorf("malformed profile name '%s': %w", name, err)
+	}
+
+	if p.All == nil {
+		p.All = make(map[string]*Profile)
+	}
+	p.All[name] = profile
+
+	return nil
+}
+
+// Remove removes an existing profile.
+func (p *Profiles) Remove(name string) error {
+	if _, exists := p.All[name]; !exists {
+		return fmt.Errorf("profile '%s' does not exist", name)
+	}
+
+	delete(p.All, name)
+
+	if p.Default == name {
+		p.Default = ""
+	}
+
+	return nil
+}
+
+// SetDefault sets the given profile as the default one. An empty name clears the default.
+func (p *Profiles) SetDefault(name string) error {
+	if _, exists := p.All[name]; name != "" && !exists {
+		return fmt.Errorf("profile '%s' does not exist", name)
+	}
+
+	p.Default = name
+
+	return nil
+}
+
+// Profile is a named selection of the network, paratime and account to use by default.
+type Profile struct {
+	Description string `mapstructure:"description,omitempty"`
+
+	// Network is the name of the network. If empty, the default network is used.
+	Network string `mapstructure:"network,omitempty"`
+	// ParaTime is the name of the paratime within the network. It requires the network to be set.
+	// If empty, the network's default paratime is used.
+	ParaTime string `mapstructure:"paratime,omitempty"`
+	// Account is the name of the wallet account. If empty, the default account is used.
+	Account string `mapstructure:"account,omitempty"`
+}
+
+// Validate validates the profile against the given configuration.
+func (p *Profile) Validate(cfg *Config) error {
+	if p.Network != "" {
+		net, exists := cfg.Networks.All[p.Network]
+		if !exists {
+			return fmt.Errorf("network '%s' does not exist", p.Network)
+		}
+		if _, exists = net.ParaTimes.All[p.ParaTime]; p.ParaTime != "" && !exists {
+			return fmt.Errorf("paratime '%s' does not exist in network '%s'", p.ParaTime, p.Network)
+		}
+	} else if p.ParaTime != "" {
+		return fmt.Errorf("paratime '%s' configured without a network", p.ParaTime)
+	}
+
+	if _, exists := cfg.Wallet.All[p.Account]; p.Account != "" && !exists {
+		return fmt.Errorf("account '%s' does not exist in the wallet", p.Account)
+	}
+
+	return nil
+}
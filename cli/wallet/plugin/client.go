@@ -0,0 +1,169 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+)
+
+// Launcher starts a new plugin instance and returns a connection to it.
+type Launcher func() (io.ReadWriteCloser, error)
+
+// ExecLauncher returns a launcher that starts the plugin executable at the given path and talks
+// to it over its standard input and output.
+func ExecLauncher(path string) Launcher {
+	return func() (io.ReadWriteCloser, error) {
+		cmd := exec.Command(path) // #nosec G204
+		cmd.Stderr = os.Stderr
+
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, err
+		}
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, err
+		}
+		if err = cmd.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start plugin '%s': %w", path, err)
+		}
+
+		return &processConn{
+			cmd:    cmd,
+			stdin:  stdin,
+			stdout: stdout,
+		}, nil
+	}
+}
+
+type processConn struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+}
+
+func (pc *processConn) Read(p []byte) (int, error) {
+	return pc.stdout.Read(p)
+}
+
+func (pc *processConn) Write(p []byte) (int, error) {
+	return pc.stdin.Write(p)
+}
+
+func (pc *processConn) Close() error {
+	// Closing standard input signals the plugin to terminate.
+	_ = pc.stdin.Close()
+	return pc.cmd.Wait()
+}
+
+type client struct {
+	sync.Mutex
+
+	conn io.ReadWriteCloser
+	dec  *json.Decoder
+	enc  *json.Encoder
+
+	nextID uint64
+}
+
+func (c *client) call(method string, params, result interface{}) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.conn == nil {
+		return fmt.Errorf("plugin: connection closed")
+	}
+
+	req := Request{
+		ID:     c.nextID,
+		Method: method,
+	}
+	c.nextID++
+
+	if params != nil {
+		var err error
+		if req.Params, err = json.Marshal(params); err != nil {
+			return fmt.Errorf("plugin: failed to marshal parameters: %w", err)
+		}
+	}
+	if err := c.enc.Encode(&req); err != nil {
+		return fmt.Errorf("plugin: failed to send request: %w", err)
+	}
+
+	var rsp Response
+	if err := c.dec.Decode(&rsp); err != nil {
+		return fmt.Errorf("plugin: failed to receive response: %w", err)
+	}
+	if rsp.ID != req.ID {
+		return fmt.Errorf("plugin: response identifier mismatch (expected: %d got: %d)", req.ID, rsp.ID)
+	}
+	if rsp.Error != "" {
+		return fmt.Errorf("plugin: %s", rsp.Error)
+	}
+	if result != nil {
+		if err := json.Unmarshal(rsp.Result, result); err != nil {
+			return fmt.Errorf("plugin: malformed result: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *client) Close() {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.conn == nil {
+		return
+	}
+	_ = c.conn.Close()
+	c.conn = nil
+
+	openClients.Lock()
+	delete(openClients.clients, c)
+	openClients.Unlock()
+}
+
+func newClient(launch Launcher) (*client, error) {
+	conn, err := launch()
+	if err != nil {
+		return nil, err
+	}
+	c := &client{
+		conn: conn,
+		dec:  json.NewDecoder(conn),
+		enc:  json.NewEncoder(conn),
+	}
+
+	openClients.Lock()
+	openClients.clients[c] = struct{}{}
+	openClients.Unlock()
+
+	return c, nil
+}
+
+// openClients are the clients of all running plugin instances.
+var openClients = struct {
+	sync.Mutex
+
+	clients map[*client]struct{}
+}{
+	clients: make(map[*client]struct{}),
+}
+
+// CloseAll terminates all running plugin instances. Accounts loaded from plugins can no longer
+// be used afterwards.
+func CloseAll() {
+	openClients.Lock()
+	clients := make([]*client, 0, len(openClients.clients))
+	for c := range openClients.clients {
+		clients = append(clients, c)
+	}
+	openClients.Unlock()
+
+	for _, c := range clients {
+		c.Close()
+	}
+}
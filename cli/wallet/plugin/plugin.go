@@ -0,0 +1,321 @@
+// Package plugin implements wallet accounts backed by external signer plugins.
+//
+// A plugin is an executable placed into the plugins subdirectory of the CLI configuration
+// directory and named oasis-wallet-<kind>. Each such executable is registered as a separate
+// account kind by DiscoverAndRegister and is spawned whenever an account of that kind is created
+// or loaded. Running plugin instances are terminated by CloseAll. The CLI talks to the plugin
+// over its standard input and output using the protocol defined in this package.
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/mitchellh/mapstructure"
+	flag "github.com/spf13/pflag"
+
+	coreSignature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+
+	"github.com/oasisprotocol/oasis-sdk/cli/config"
+	"github.com/oasisprotocol/oasis-sdk/cli/wallet"
+	sdkConfig "github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/secp256k1"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+const (
+	// ExecutablePrefix is the filename prefix of plugin executables.
+	ExecutablePrefix = "oasis-wallet-"
+
+	cfgConfig = "plugin.config"
+)
+
+// Flags contains the account configuration flags shared by all plugin account kinds.
+//
+// The flags are shared so that they can be registered before plugins are discovered.
+var Flags *flag.FlagSet
+
+// Directory returns the path to the directory where plugin executables are discovered.
+func Directory() string {
+	return filepath.Join(config.Directory(), "plugins")
+}
+
+type accountConfig struct {
+	Algorithm string                 `mapstructure:"algorithm,omitempty"`
+	Config    map[string]interface{} `mapstructure:"config,omitempty"`
+}
+
+type pluginAccountFactory struct {
+	kind   string
+	launch Launcher
+}
+
+// NewFactory creates a new account factory for the given kind, backed by plugin instances
+// started by the given launcher.
+func NewFactory(kind string, launch Launcher) wallet.Factory {
+	return &pluginAccountFactory{
+		kind:   kind,
+		launch: launch,
+	}
+}
+
+func (af *pluginAccountFactory) Kind() string {
+	return af.kind
+}
+
+func (af *pluginAccountFactory) PrettyKind(rawCfg map[string]interface{}) string {
+	cfg, err := af.unmarshalConfig(rawCfg)
+	if err != nil || cfg.Algorithm == "" {
+		return fmt.Sprintf("%s (plugin)", af.kind)
+	}
+	return fmt.Sprintf("%s (plugin:%s)", af.kind, cfg.Algorithm)
+}
+
+func (af *pluginAccountFactory) Flags() *flag.FlagSet {
+	return Flags
+}
+
+func (af *pluginAccountFactory) GetConfigFromFlags() (map[string]interface{}, error) {
+	pluginCfg, _ := Flags.GetStringToString(cfgConfig)
+
+	cfg := make(map[string]interface{})
+	if len(pluginCfg) > 0 {
+		raw := make(map[string]interface{})
+		for k, v := range pluginCfg {
+			raw[k] = v
+		}
+		cfg["config"] = raw
+	}
+	return cfg, nil
+}
+
+func (af *pluginAccountFactory) GetConfigFromSurvey(kind *wallet.ImportKind) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("%s: import not supported", af.kind)
+}
+
+func (af *pluginAccountFactory) DataPrompt(kind wallet.ImportKind, rawCfg map[string]interface{}) survey.Prompt {
+	return nil
+}
+
+func (af *pluginAccountFactory) DataValidator(kind wallet.ImportKind, rawCfg map[string]interface{}) survey.Validator {
+	return nil
+}
+
+func (af *pluginAccountFactory) RequiresPassphrase() bool {
+	// Plugins are responsible for their own authentication.
+	return false
+}
+
+func (af *pluginAccountFactory) SupportedImportKinds() []wallet.ImportKind {
+	return []wallet.ImportKind{}
+}
+
+func (af *pluginAccountFactory) HasConsensusSigner(rawCfg map[string]interface{}) bool {
+	cfg, err := af.unmarshalConfig(rawCfg)
+	if err != nil {
+		return false
+	}
+	return cfg.Algorithm == AlgorithmEd25519
+}
+
+func (af *pluginAccountFactory) unmarshalConfig(raw map[string]interface{}) (*accountConfig, error) {
+	if raw == nil {
+		return nil, fmt.Errorf("missing configuration")
+	}
+
+	var cfg accountConfig
+	if err := mapstructure.Decode(raw, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (af *pluginAccountFactory) Create(name string, passphrase string, rawCfg map[string]interface{}) (wallet.Account, error) {
+	acc, err := af.open(MethodCreate, name, passphrase, rawCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remember the algorithm so we know whether there is a consensus signer without starting
+	// the plugin.
+	rawCfg["algorithm"] = acc.cfg.Algorithm
+
+	return acc, nil
+}
+
+func (af *pluginAccountFactory) Load(name string, passphrase string, rawCfg map[string]interface{}) (wallet.Account, error) {
+	return af.open(MethodLoad, name, passphrase, rawCfg)
+}
+
+func (af *pluginAccountFactory) open(method, name, passphrase string, rawCfg map[string]interface{}) (*pluginAccount, error) {
+	cfg, err := af.unmarshalConfig(rawCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := newClient(af.launch)
+	if err != nil {
+		return nil, err
+	}
+
+	var pub PublicResponse
+	err = cli.call(method, &AccountRequest{
+		Name:       name,
+		Passphrase: passphrase,
+		Config:     cfg.Config,
+	}, &pub)
+	if err != nil {
+		cli.Close()
+		return nil, err
+	}
+
+	acc, err := newAccount(cli, cfg, &pub)
+	if err != nil {
+		cli.Close()
+		return nil, err
+	}
+	return acc, nil
+}
+
+func (af *pluginAccountFactory) Remove(name string, rawCfg map[string]interface{}) error {
+	// Key material is managed by the plugin.
+	return nil
+}
+
+func (af *pluginAccountFactory) Rename(old, new string, rawCfg map[string]interface{}) error {
+	// Key material is managed by the plugin.
+	return nil
+}
+
+func (af *pluginAccountFactory) Import(name string, passphrase string, rawCfg map[string]interface{}, src *wallet.ImportSource) (wallet.Account, error) {
+	return nil, fmt.Errorf("%s: import not supported", af.kind)
+}
+
+type pluginAccount struct {
+	cfg    *accountConfig
+	signer *pluginSigner
+}
+
+func newAccount(cli *client, cfg *accountConfig, pub *PublicResponse) (*pluginAccount, error) {
+	if cfg.Algorithm != "" && cfg.Algorithm != pub.Algorithm {
+		return nil, fmt.Errorf("plugin: algorithm mismatch (expected: %s got: %s)", cfg.Algorithm, pub.Algorithm)
+	}
+
+	signer := &pluginSigner{client: cli}
+	switch pub.Algorithm {
+	case AlgorithmEd25519:
+		var pk ed25519.PublicKey
+		if err := pk.UnmarshalBinary(pub.PublicKey); err != nil {
+			return nil, fmt.Errorf("plugin: got malformed public key: %w", err)
+		}
+		signer.pk = pk
+	case AlgorithmSecp256k1:
+		var pk secp256k1.PublicKey
+		if err := pk.UnmarshalBinary(pub.PublicKey); err != nil {
+			return nil, fmt.Errorf("plugin: got malformed public key: %w", err)
+		}
+		signer.pk = pk
+	default:
+		return nil, fmt.Errorf("plugin: algorithm '%s' not supported", pub.Algorithm)
+	}
+
+	return &pluginAccount{
+		cfg: &accountConfig{
+			Algorithm: pub.Algorithm,
+			Config:    cfg.Config,
+		},
+		signer: signer,
+	}, nil
+}
+
+func (a *pluginAccount) ConsensusSigner() coreSignature.Signer {
+	pk, ok := a.signer.pk.(ed25519.PublicKey)
+	if !ok {
+		return nil
+	}
+	return &pluginCoreSigner{
+		client: a.signer.client,
+		pk:     coreSignature.PublicKey(pk),
+	}
+}
+
+func (a *pluginAccount) Signer() signature.Signer {
+	return a.signer
+}
+
+func (a *pluginAccount) Address() types.Address {
+	return types.NewAddress(a.SignatureAddressSpec())
+}
+
+func (a *pluginAccount) SignatureAddressSpec() types.SignatureAddressSpec {
+	switch pk := a.signer.pk.(type) {
+	case ed25519.PublicKey:
+		return types.NewSignatureAddressSpecEd25519(pk)
+	case secp256k1.PublicKey:
+		return types.NewSignatureAddressSpecSecp256k1Eth(pk)
+	default:
+		return types.SignatureAddressSpec{}
+	}
+}
+
+func (a *pluginAccount) UnsafeExport() string {
+	return ""
+}
+
+// Discover scans the given directory for plugin executables and returns a map of account
+// kinds to executable paths.
+func Discover(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	plugins := make(map[string]string)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, ExecutablePrefix) {
+			continue
+		}
+		kind := strings.TrimPrefix(name, ExecutablePrefix)
+		if err = sdkConfig.ValidateIdentifier(kind); err != nil {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil || info.Mode()&0o111 == 0 {
+			// Skip non-executable files.
+			continue
+		}
+		plugins[kind] = filepath.Join(dir, name)
+	}
+	return plugins, nil
+}
+
+// DiscoverAndRegister discovers plugin executables in the given directory and registers an
+// account kind for each of them. Plugins cannot override already registered kinds so calling it
+// multiple times is safe. A missing directory means that there are no plugins.
+func DiscoverAndRegister(dir string) {
+	plugins, err := Discover(dir)
+	if err != nil {
+		// No plugins directory, nothing to register.
+		return
+	}
+
+	for kind, path := range plugins {
+		if _, err = wallet.Load(kind); err == nil {
+			// Do not allow plugins to override built-in kinds.
+			continue
+		}
+		wallet.Register(NewFactory(kind, ExecLauncher(path)))
+	}
+}
+
+func init() {
+	Flags = flag.NewFlagSet("", flag.ContinueOnError)
+	Flags.StringToString(cfgConfig, nil, "Configuration passed to the plugin (key=value pairs)")
+}
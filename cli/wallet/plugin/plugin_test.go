@@ -0,0 +1,134 @@
+package plugin_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	coreSignature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+
+	"github.com/oasisprotocol/oasis-sdk/cli/wallet"
+	"github.com/oasisprotocol/oasis-sdk/cli/wallet/plugin"
+	"github.com/oasisprotocol/oasis-sdk/cli/wallet/plugin/testplugin"
+)
+
+type pipeConn struct {
+	io.Reader
+	io.WriteCloser
+}
+
+func inProcessLauncher() (io.ReadWriteCloser, error) {
+	reqR, reqW := io.Pipe()
+	rspR, rspW := io.Pipe()
+	go func() {
+		_ = plugin.Serve(reqR, rspW, testplugin.NewBackend())
+		rspW.Close()
+	}()
+	return &pipeConn{Reader: rspR, WriteCloser: reqW}, nil
+}
+
+func TestPluginAccount(t *testing.T) {
+	require := require.New(t)
+
+	af := plugin.NewFactory("test", inProcessLauncher)
+	require.Equal("test", af.Kind())
+	require.False(af.RequiresPassphrase())
+
+	for _, tc := range []struct {
+		algorithm string
+		consensus bool
+	}{
+		{plugin.AlgorithmEd25519, true},
+		{plugin.AlgorithmSecp256k1, false},
+	} {
+		rawCfg := map[string]interface{}{
+			"config": map[string]interface{}{
+				testplugin.CfgAlgorithm: tc.algorithm,
+			},
+		}
+		acc, err := af.Create("alice", "", rawCfg)
+		require.NoError(err, "Create")
+		require.Equal(tc.algorithm, rawCfg["algorithm"], "algorithm should be stored in config")
+		require.Equal(tc.consensus, af.HasConsensusSigner(rawCfg))
+
+		loaded, err := af.Load("alice", "", rawCfg)
+		require.NoError(err, "Load")
+		require.Equal(acc.Address(), loaded.Address(), "loaded account should have the same address")
+
+		context := []byte("oasis-sdk/plugin: test context")
+		message := []byte("hello world")
+		sig, err := loaded.Signer().ContextSign(context, message)
+		require.NoError(err, "ContextSign")
+		require.True(loaded.Signer().Public().Verify(context, message, sig), "signature should verify")
+		require.False(loaded.Signer().Public().Verify(context, []byte("other"), sig))
+
+		_, err = loaded.Signer().Sign(message)
+		require.Error(err, "signing without context should fail")
+
+		consensusSigner := loaded.ConsensusSigner()
+		if tc.consensus {
+			require.NotNil(consensusSigner)
+			coreContext := coreSignature.NewContext("oasis-sdk/plugin: test consensus context")
+			sig, err = consensusSigner.ContextSign(coreContext, message)
+			require.NoError(err, "ContextSign (consensus)")
+			require.True(consensusSigner.Public().Verify(coreContext, message, sig), "consensus signature should verify")
+		} else {
+			require.Nil(consensusSigner)
+		}
+
+		acc.Signer().Reset()
+		loaded.Signer().Reset()
+	}
+
+	// Algorithm mismatch between configuration and plugin should be rejected.
+	_, err := af.Load("alice", "", map[string]interface{}{
+		"algorithm": plugin.AlgorithmSecp256k1,
+	})
+	require.Error(err, "Load with algorithm mismatch")
+}
+
+func TestCloseAll(t *testing.T) {
+	require := require.New(t)
+
+	af := plugin.NewFactory("test", inProcessLauncher)
+	rawCfg := map[string]interface{}{
+		"config": map[string]interface{}{
+			testplugin.CfgAlgorithm: plugin.AlgorithmEd25519,
+		},
+	}
+	_, err := af.Create("alice", "", rawCfg)
+	require.NoError(err, "Create")
+	acc, err := af.Load("alice", "", rawCfg)
+	require.NoError(err, "Load")
+
+	plugin.CloseAll()
+
+	_, err = acc.Signer().ContextSign([]byte("oasis-sdk/plugin: test context"), []byte("hello world"))
+	require.Error(err, "ContextSign should fail after the plugin has been closed")
+}
+
+func TestDiscoverAndRegister(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(dir, plugin.ExecutablePrefix+"discovered"), []byte("#!/bin/sh\n"), 0o700))
+	require.NoError(os.WriteFile(filepath.Join(dir, plugin.ExecutablePrefix+"noexec"), []byte("#!/bin/sh\n"), 0o600))
+
+	// Missing directories should be ignored.
+	plugin.DiscoverAndRegister(filepath.Join(dir, "missing"))
+	_, err := wallet.Load("discovered")
+	require.Error(err, "plugin should not be registered before discovery")
+
+	plugin.DiscoverAndRegister(dir)
+	af, err := wallet.Load("discovered")
+	require.NoError(err, "plugin should be registered after discovery")
+	require.Equal("discovered", af.Kind())
+	_, err = wallet.Load("noexec")
+	require.Error(err, "non-executable files should not be registered")
+
+	// Registration should be idempotent.
+	plugin.DiscoverAndRegister(dir)
+}
@@ -0,0 +1,84 @@
+package plugin
+
+import (
+	"encoding/json"
+)
+
+// Methods supported by the wallet plugin protocol.
+const (
+	// MethodCreate creates a new account inside the plugin.
+	MethodCreate = "Create"
+	// MethodLoad loads an existing account inside the plugin.
+	MethodLoad = "Load"
+	// MethodPublic returns the public key of the currently loaded account.
+	MethodPublic = "Public"
+	// MethodContextSign signs a message with the currently loaded account.
+	MethodContextSign = "ContextSign"
+)
+
+// Signature algorithms that a plugin may use for its accounts.
+const (
+	// AlgorithmEd25519 is the Ed25519 signature algorithm.
+	AlgorithmEd25519 = "ed25519"
+	// AlgorithmSecp256k1 is the Secp256k1 signature algorithm.
+	AlgorithmSecp256k1 = "secp256k1"
+)
+
+// Request is a single request sent from the CLI to the plugin.
+//
+// Requests and responses are exchanged as newline-delimited JSON objects over the plugin's
+// standard input and output. Each request is answered by exactly one response carrying the
+// same identifier.
+type Request struct {
+	// ID is the request identifier.
+	ID uint64 `json:"id"`
+	// Method is the name of the method being called.
+	Method string `json:"method"`
+	// Params are the method-specific parameters.
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a single response sent from the plugin to the CLI.
+type Response struct {
+	// ID is the identifier of the request this response is for.
+	ID uint64 `json:"id"`
+	// Result is the method-specific result in case of success.
+	Result json.RawMessage `json:"result,omitempty"`
+	// Error is the error message in case of failure.
+	Error string `json:"error,omitempty"`
+}
+
+// AccountRequest are the parameters of the Create and Load methods.
+type AccountRequest struct {
+	// Name is the name of the account in the wallet.
+	Name string `json:"name"`
+	// Passphrase is the passphrase entered by the user (if any).
+	Passphrase string `json:"passphrase,omitempty"`
+	// Config is the plugin-specific account configuration.
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// PublicResponse is the result of the Create, Load and Public methods.
+type PublicResponse struct {
+	// Algorithm is the signature algorithm used by the account.
+	Algorithm string `json:"algorithm"`
+	// PublicKey is the binary encoded public key of the account.
+	PublicKey []byte `json:"public_key"`
+}
+
+// ContextSignRequest are the parameters of the ContextSign method.
+//
+// The plugin must produce a signature in the same way as the SDK signers for the given
+// algorithm do, including domain separation based on the passed context.
+type ContextSignRequest struct {
+	// Context is the domain separation context.
+	Context []byte `json:"context"`
+	// Message is the message to sign.
+	Message []byte `json:"message"`
+}
+
+// ContextSignResponse is the result of the ContextSign method.
+type ContextSignResponse struct {
+	// Signature is the generated signature.
+	Signature []byte `json:"signature"`
+}
@@ -0,0 +1,85 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Backend is the interface that needs to be implemented by wallet plugins written in Go.
+//
+// Each plugin process serves a single account: either Create or Load is called first and all
+// subsequent calls refer to that account.
+type Backend interface {
+	// Create creates a new account.
+	Create(req *AccountRequest) (*PublicResponse, error)
+
+	// Load loads an existing account.
+	Load(req *AccountRequest) (*PublicResponse, error)
+
+	// Public returns the public key of the loaded account.
+	Public() (*PublicResponse, error)
+
+	// ContextSign signs the given message under the given context.
+	ContextSign(req *ContextSignRequest) (*ContextSignResponse, error)
+}
+
+// Serve serves wallet plugin protocol requests read from r using the given backend and writes
+// the responses to w.
+//
+// It returns once r is closed.
+func Serve(r io.Reader, w io.Writer, backend Backend) error {
+	dec := json.NewDecoder(r)
+	enc := json.NewEncoder(w)
+
+	for {
+		var req Request
+		if err := dec.Decode(&req); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("plugin: failed to decode request: %w", err)
+		}
+
+		rsp := Response{ID: req.ID}
+		result, err := dispatch(backend, &req)
+		switch err {
+		case nil:
+			rsp.Result, err = json.Marshal(result)
+			if err != nil {
+				rsp.Error = fmt.Sprintf("failed to marshal result: %s", err)
+			}
+		default:
+			rsp.Error = err.Error()
+		}
+
+		if err = enc.Encode(&rsp); err != nil {
+			return fmt.Errorf("plugin: failed to encode response: %w", err)
+		}
+	}
+}
+
+func dispatch(backend Backend, req *Request) (interface{}, error) {
+	switch req.Method {
+	case MethodCreate, MethodLoad:
+		var params AccountRequest
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, fmt.Errorf("malformed parameters: %w", err)
+		}
+		if req.Method == MethodCreate {
+			return backend.Create(&params)
+		}
+		return backend.Load(&params)
+	case MethodPublic:
+		return backend.Public()
+	case MethodContextSign:
+		var params ContextSignRequest
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, fmt.Errorf("malformed parameters: %w", err)
+		}
+		return backend.ContextSign(&params)
+	default:
+		return nil, fmt.Errorf("unsupported method: %s", req.Method)
+	}
+}
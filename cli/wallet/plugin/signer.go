@@ -0,0 +1,63 @@
+package plugin
+
+import (
+	"fmt"
+
+	coreSignature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+)
+
+func (c *client) contextSign(context, message []byte) ([]byte, error) {
+	var rsp ContextSignResponse
+	if err := c.call(MethodContextSign, &ContextSignRequest{Context: context, Message: message}, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp.Signature, nil
+}
+
+type pluginCoreSigner struct {
+	client *client
+	pk     coreSignature.PublicKey
+}
+
+func (ps *pluginCoreSigner) Public() coreSignature.PublicKey {
+	return ps.pk
+}
+
+func (ps *pluginCoreSigner) ContextSign(context coreSignature.Context, message []byte) ([]byte, error) {
+	return ps.client.contextSign([]byte(context), message)
+}
+
+func (ps *pluginCoreSigner) String() string {
+	return fmt.Sprintf("[plugin signer: %s]", ps.pk)
+}
+
+func (ps *pluginCoreSigner) Reset() {
+	ps.client.Close()
+}
+
+type pluginSigner struct {
+	client *client
+	pk     signature.PublicKey
+}
+
+func (ps *pluginSigner) Public() signature.PublicKey {
+	return ps.pk
+}
+
+func (ps *pluginSigner) ContextSign(context, message []byte) ([]byte, error) {
+	return ps.client.contextSign(context, message)
+}
+
+func (ps *pluginSigner) Sign(message []byte) ([]byte, error) {
+	return nil, fmt.Errorf("plugin: signing without context not supported")
+}
+
+func (ps *pluginSigner) String() string {
+	return fmt.Sprintf("[plugin signer: %s]", ps.pk)
+}
+
+func (ps *pluginSigner) Reset() {
+	ps.client.Close()
+}
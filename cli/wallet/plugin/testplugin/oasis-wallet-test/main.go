@@ -0,0 +1,17 @@
+// Command oasis-wallet-test is a reference wallet plugin using deterministic in-memory test keys.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/oasisprotocol/oasis-sdk/cli/wallet/plugin"
+	"github.com/oasisprotocol/oasis-sdk/cli/wallet/plugin/testplugin"
+)
+
+func main() {
+	if err := plugin.Serve(os.Stdin, os.Stdout, testplugin.NewBackend()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
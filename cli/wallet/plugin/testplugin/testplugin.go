@@ -0,0 +1,92 @@
+// Package testplugin implements a reference wallet plugin backend that keeps deterministic
+// test keys in memory.
+//
+// It must never be used with real funds.
+package testplugin
+
+import (
+	"crypto/sha512"
+	"encoding"
+	"fmt"
+
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+
+	"github.com/oasisprotocol/oasis-sdk/cli/wallet/plugin"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/secp256k1"
+)
+
+const (
+	// CfgAlgorithm is the configuration key used to select the signature algorithm.
+	CfgAlgorithm = "algorithm"
+
+	seedPrefix = "oasis-wallet-test: "
+)
+
+type backend struct {
+	algorithm string
+	signer    signature.Signer
+}
+
+// NewBackend creates a new test plugin backend.
+func NewBackend() plugin.Backend {
+	return &backend{}
+}
+
+func (b *backend) Create(req *plugin.AccountRequest) (*plugin.PublicResponse, error) {
+	algorithm := plugin.AlgorithmEd25519
+	if raw, ok := req.Config[CfgAlgorithm]; ok {
+		algorithm, ok = raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("malformed algorithm")
+		}
+	}
+
+	seed := seedPrefix + req.Name
+	switch algorithm {
+	case plugin.AlgorithmEd25519:
+		b.signer = ed25519.WrapSigner(memorySigner.NewTestSigner(seed))
+	case plugin.AlgorithmSecp256k1:
+		pk := sha512.Sum512_256([]byte(seed))
+		b.signer = secp256k1.NewSigner(pk[:])
+	default:
+		return nil, fmt.Errorf("algorithm '%s' not supported", algorithm)
+	}
+	b.algorithm = algorithm
+
+	return b.Public()
+}
+
+func (b *backend) Load(req *plugin.AccountRequest) (*plugin.PublicResponse, error) {
+	// Keys are derived deterministically from the account name so loading is the same as
+	// creating.
+	return b.Create(req)
+}
+
+func (b *backend) Public() (*plugin.PublicResponse, error) {
+	if b.signer == nil {
+		return nil, fmt.Errorf("no account loaded")
+	}
+
+	pk, err := b.signer.Public().(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.PublicResponse{
+		Algorithm: b.algorithm,
+		PublicKey: pk,
+	}, nil
+}
+
+func (b *backend) ContextSign(req *plugin.ContextSignRequest) (*plugin.ContextSignResponse, error) {
+	if b.signer == nil {
+		return nil, fmt.Errorf("no account loaded")
+	}
+
+	sig, err := b.signer.ContextSign(req.Context, req.Message)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ContextSignResponse{Signature: sig}, nil
+}
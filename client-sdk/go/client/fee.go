@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+const methodMinGasPrice = "core.MinGasPrice"
+
+// SelectGasPrice selects the gas price to use from the given set of minimum gas prices accepted
+// by the runtime.
+//
+// In case denom is specified, the runtime must accept fees in that denomination. Otherwise the
+// native denomination is preferred and if the runtime does not accept it, the first accepted
+// denomination (in lexicographic order) is used.
+func SelectGasPrice(mgp map[types.Denomination]types.Quantity, denom *types.Denomination) (*types.BaseUnits, error) {
+	if denom != nil {
+		price, ok := mgp[*denom]
+		if !ok {
+			// Runtimes without any configured minimum gas prices accept native fees.
+			if len(mgp) == 0 && denom.IsNative() {
+				price = *quantity.NewQuantity()
+			} else {
+				return nil, fmt.Errorf("runtime does not accept fees in denomination '%s'", denom)
+			}
+		}
+		bu := types.NewBaseUnits(price, *denom)
+		return &bu, nil
+	}
+
+	if price, ok := mgp[types.NativeDenomination]; ok || len(mgp) == 0 {
+		bu := types.NewBaseUnits(price, types.NativeDenomination)
+		return &bu, nil
+	}
+
+	denoms := make([]string, 0, len(mgp))
+	for d := range mgp {
+		denoms = append(denoms, string(d))
+	}
+	sort.Strings(denoms)
+	bu := types.NewBaseUnits(mgp[types.Denomination(denoms[0])], types.Denomination(denoms[0]))
+	return &bu, nil
+}
+
+// SetFeeGasPrice configures the fee amount based on the given gas price and the currently
+// configured gas limit.
+//
+// The gas limit must be configured via SetFeeGas before calling this method.
+func (tb *TransactionBuilder) SetFeeGasPrice(price types.BaseUnits) error {
+	amount := price.Amount.Clone()
+	if err := amount.Mul(quantity.NewFromUint64(tb.tx.AuthInfo.Fee.Gas)); err != nil {
+		return fmt.Errorf("failed to compute fee amount: %w", err)
+	}
+	tb.tx.AuthInfo.Fee.Amount = types.NewBaseUnits(*amount, price.Denomination)
+	return nil
+}
+
+// SetFeeMinGasPrice queries the minimum gas prices accepted by the runtime and configures the fee
+// amount based on the currently configured gas limit.
+//
+// In case denom is nil, any denomination accepted by the runtime is used (see SelectGasPrice).
+//
+// The gas limit must be configured via SetFeeGas before calling this method.
+func (tb *TransactionBuilder) SetFeeMinGasPrice(ctx context.Context, denom *types.Denomination) error {
+	var mgp map[types.Denomination]types.Quantity
+	if err := tb.rc.Query(ctx, RoundLatest, methodMinGasPrice, nil, &mgp); err != nil {
+		return fmt.Errorf("failed to query minimum gas price: %w", err)
+	}
+
+	price, err := SelectGasPrice(mgp, denom)
+	if err != nil {
+		return err
+	}
+	return tb.SetFeeGasPrice(*price)
+}
@@ -0,0 +1,166 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing/mock"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func denomPtr(d types.Denomination) *types.Denomination {
+	return &d
+}
+
+func TestSelectGasPrice(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		name     string
+		mgp      map[types.Denomination]types.Quantity
+		denom    *types.Denomination
+		valid    bool
+		expected types.BaseUnits
+	}{
+		{
+			"nil denomination, no prices",
+			nil,
+			nil,
+			true,
+			types.NewBaseUnits(*quantity.NewFromUint64(0), types.NativeDenomination),
+		},
+		{
+			"nil denomination, native price",
+			map[types.Denomination]types.Quantity{
+				types.NativeDenomination: *quantity.NewFromUint64(10),
+				"USDT":                   *quantity.NewFromUint64(5),
+			},
+			nil,
+			true,
+			types.NewBaseUnits(*quantity.NewFromUint64(10), types.NativeDenomination),
+		},
+		{
+			"nil denomination, default denomination fallback",
+			map[types.Denomination]types.Quantity{
+				"USDT": *quantity.NewFromUint64(5),
+				"ABC":  *quantity.NewFromUint64(3),
+			},
+			nil,
+			true,
+			types.NewBaseUnits(*quantity.NewFromUint64(3), "ABC"),
+		},
+		{
+			"native denomination, no prices",
+			nil,
+			denomPtr(types.NativeDenomination),
+			true,
+			types.NewBaseUnits(*quantity.NewFromUint64(0), types.NativeDenomination),
+		},
+		{
+			"native denomination, missing",
+			map[types.Denomination]types.Quantity{
+				"USDT": *quantity.NewFromUint64(5),
+			},
+			denomPtr(types.NativeDenomination),
+			false,
+			types.BaseUnits{},
+		},
+		{
+			"explicit denomination",
+			map[types.Denomination]types.Quantity{
+				types.NativeDenomination: *quantity.NewFromUint64(10),
+				"USDT":                   *quantity.NewFromUint64(5),
+			},
+			denomPtr("USDT"),
+			true,
+			types.NewBaseUnits(*quantity.NewFromUint64(5), "USDT"),
+		},
+		{
+			"explicit denomination, missing",
+			map[types.Denomination]types.Quantity{
+				types.NativeDenomination: *quantity.NewFromUint64(10),
+			},
+			denomPtr("USDT"),
+			false,
+			types.BaseUnits{},
+		},
+		{
+			"explicit denomination, no prices",
+			nil,
+			denomPtr("USDT"),
+			false,
+			types.BaseUnits{},
+		},
+	} {
+		price, err := client.SelectGasPrice(tc.mgp, tc.denom)
+		if tc.valid {
+			require.NoError(err, tc.name)
+			require.EqualValues(&tc.expected, price, tc.name)
+		} else {
+			require.Error(err, tc.name)
+		}
+	}
+}
+
+func TestSetFeeGasPrice(t *testing.T) {
+	require := require.New(t)
+
+	rc := mock.NewRuntimeClient(common.NewTestNamespaceFromSeed([]byte("fee test"), 0), "test chain context")
+	tb := client.NewTransactionBuilder(rc, "test.Method", nil).SetFeeGas(1000)
+
+	err := tb.SetFeeGasPrice(types.NewBaseUnits(*quantity.NewFromUint64(3), "USDT"))
+	require.NoError(err, "SetFeeGasPrice")
+
+	fee := tb.GetTransaction().AuthInfo.Fee
+	require.EqualValues(1000, fee.Gas)
+	require.EqualValues(types.NewBaseUnits(*quantity.NewFromUint64(3000), "USDT"), fee.Amount)
+}
+
+func TestSetFeeMinGasPrice(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	rc := mock.NewRuntimeClient(common.NewTestNamespaceFromSeed([]byte("fee test"), 0), "test chain context")
+	rc.SetQueryHandler("core.MinGasPrice", func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		return map[types.Denomination]types.Quantity{
+			"USDT": *quantity.NewFromUint64(5),
+			"ABC":  *quantity.NewFromUint64(3),
+		}, nil
+	})
+
+	for _, tc := range []struct {
+		name     string
+		denom    *types.Denomination
+		valid    bool
+		expected types.BaseUnits
+	}{
+		{"nil denomination", nil, true, types.NewBaseUnits(*quantity.NewFromUint64(300), "ABC")},
+		{"explicit denomination", denomPtr("USDT"), true, types.NewBaseUnits(*quantity.NewFromUint64(500), "USDT")},
+		{"missing denomination", denomPtr("XYZ"), false, types.BaseUnits{}},
+		{"missing native denomination", denomPtr(types.NativeDenomination), false, types.BaseUnits{}},
+	} {
+		tb := client.NewTransactionBuilder(rc, "test.Method", nil).SetFeeGas(100)
+		err := tb.SetFeeMinGasPrice(ctx, tc.denom)
+		if tc.valid {
+			require.NoError(err, tc.name)
+			require.EqualValues(tc.expected, tb.GetTransaction().AuthInfo.Fee.Amount, tc.name)
+		} else {
+			require.Error(err, tc.name)
+		}
+	}
+
+	// Query failures should be propagated.
+	rc.SetQueryHandler("core.MinGasPrice", func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		return nil, fmt.Errorf("query failed")
+	})
+	tb := client.NewTransactionBuilder(rc, "test.Method", nil).SetFeeGas(100)
+	require.Error(tb.SetFeeMinGasPrice(ctx, nil), "SetFeeMinGasPrice should fail when the query fails")
+}
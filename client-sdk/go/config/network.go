@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"strings"
 
@@ -109,6 +110,9 @@ type Network struct {
 	Denomination DenominationInfo `mapstructure:"denomination"`
 
 	ParaTimes ParaTimes `mapstructure:"paratimes"`
+
+	// Security is the connection security profile. If not set, the default profile is used.
+	Security *NetworkSecurity `mapstructure:"security,omitempty"`
 }
 
 // Validate performs config validation.
@@ -119,9 +123,11 @@ func (n *Network) Validate() error {
 		return fmt.Errorf("malformed chain context: %w", err)
 	}
 
-	// RPC should be a valid URI.
+	// RPC should be a valid URI or a host:port pair (e.g. an IP address on a private network).
 	if _, err := url.Parse(n.RPC); err != nil {
-		return fmt.Errorf("malformed RPC endpoint: %w", err)
+		if _, _, hpErr := net.SplitHostPort(n.RPC); hpErr != nil {
+			return fmt.Errorf("malformed RPC endpoint: %w", err)
+		}
 	}
 
 	// Validate denomination information.
@@ -130,10 +136,17 @@ func (n *Network) Validate() error {
 	}
 
 	// Validate paratimes attached to a network.
-	if err := n.ParaTimes.Validate(); err != nil { //revive:disable-line:if-return
+	if err := n.ParaTimes.Validate(); err != nil {
 		return err
 	}
 
+	// Validate the connection security profile.
+	if n.Security != nil {
+		if err := n.Security.Validate(); err != nil {
+			return fmt.Errorf("security: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -141,3 +154,11 @@ func (n *Network) Validate() error {
 func (n *Network) IsLocalRPC() bool {
 	return strings.HasPrefix(n.RPC, "unix:")
 }
+
+// UsesTLS checks whether connections to the RPC endpoint should use TLS.
+func (n *Network) UsesTLS() bool {
+	if n.Security != nil && n.Security.Mode != "" {
+		return n.Security.Mode == SecurityModeTLS
+	}
+	return !n.IsLocalRPC()
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+const (
+	// SecurityModeTLS establishes connections over TLS. This is the default for non-local RPC
+	// endpoints.
+	SecurityModeTLS = "tls"
+	// SecurityModeInsecure establishes plaintext connections without TLS. It should only be used
+	// for nodes reachable over trusted private networks.
+	SecurityModeInsecure = "insecure"
+)
+
+// NetworkSecurity is the connection security profile of a network.
+type NetworkSecurity struct {
+	// Mode is the connection security mode. If empty, local RPC endpoints are reached without
+	// TLS and all other endpoints over TLS.
+	Mode string `mapstructure:"mode,omitempty"`
+
+	// CACert is the path to a PEM-encoded file containing the CA certificates to trust instead of
+	// the system roots.
+	CACert string `mapstructure:"ca_cert,omitempty"`
+	// ClientCert is the path to a PEM-encoded client certificate presented to the node.
+	ClientCert string `mapstructure:"client_cert,omitempty"`
+	// ClientKey is the path to the PEM-encoded private key of the client certificate.
+	ClientKey string `mapstructure:"client_key,omitempty"`
+	// ServerName overrides the server name used to verify the node's certificate.
+	ServerName string `mapstructure:"server_name,omitempty"`
+	// PinnedPublicKeys are the base64-encoded SHA-256 hashes of the DER-encoded
+	// SubjectPublicKeyInfo of certificates, one of which must be present in the node's verified
+	// certificate chain. Without a CACert the pins replace chain verification and the node's own
+	// certificate must be pinned, which allows using self-signed certificates.
+	PinnedPublicKeys []string `mapstructure:"pinned_public_keys,omitempty"`
+
+	// Headers are the metadata headers sent with each request, e.g. to authenticate with a
+	// gateway. Environment variable references in values (e.g. $API_KEY) are expanded when
+	// connecting so that secrets need not be stored in the configuration.
+	Headers map[string]string `mapstructure:"headers,omitempty"`
+}
+
+// Validate performs config validation.
+func (s *NetworkSecurity) Validate() error {
+	switch s.Mode {
+	case "", SecurityModeTLS:
+	case SecurityModeInsecure:
+		if s.CACert != "" || s.ClientCert != "" || s.ServerName != "" || len(s.PinnedPublicKeys) > 0 {
+			return fmt.Errorf("TLS options cannot be used in '%s' security mode", SecurityModeInsecure)
+		}
+	default:
+		return fmt.Errorf("unsupported security mode '%s'", s.Mode)
+	}
+
+	if (s.ClientCert == "") != (s.ClientKey == "") {
+		return fmt.Errorf("client certificate and key must be configured together")
+	}
+
+	for _, pin := range s.PinnedPublicKeys {
+		raw, err := base64.StdEncoding.DecodeString(pin)
+		if err != nil || len(raw) != sha256.Size {
+			return fmt.Errorf("malformed pinned public key '%s'", pin)
+		}
+	}
+
+	for name := range s.Headers {
+		if name == "" || name != strings.ToLower(name) || strings.ContainsAny(name, " :") {
+			return fmt.Errorf("malformed header name '%s'", name)
+		}
+	}
+
+	return nil
+}
+
+// IsInsecure checks whether the security profile requires plaintext connections.
+func (s *NetworkSecurity) IsInsecure() bool {
+	return s != nil && s.Mode == SecurityModeInsecure
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkSecurityValidate(t *testing.T) {
+	require := require.New(t)
+
+	pin := "47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="
+	for _, tc := range []struct {
+		sec   NetworkSecurity
+		valid bool
+	}{
+		{NetworkSecurity{}, true},
+		{NetworkSecurity{Mode: SecurityModeTLS, CACert: "ca.pem", ServerName: "node.example.com"}, true},
+		{NetworkSecurity{Mode: SecurityModeInsecure, Headers: map[string]string{"x-api-key": "$API_KEY"}}, true},
+		{NetworkSecurity{Mode: "foo"}, false},
+		{NetworkSecurity{Mode: SecurityModeInsecure, CACert: "ca.pem"}, false},
+		{NetworkSecurity{Mode: SecurityModeInsecure, PinnedPublicKeys: []string{pin}}, false},
+		{NetworkSecurity{ClientCert: "client.pem", ClientKey: "client.key"}, true},
+		{NetworkSecurity{ClientCert: "client.pem"}, false},
+		{NetworkSecurity{ClientKey: "client.key"}, false},
+		{NetworkSecurity{PinnedPublicKeys: []string{pin}}, true},
+		{NetworkSecurity{PinnedPublicKeys: []string{"AAAA"}}, false},
+		{NetworkSecurity{PinnedPublicKeys: []string{"not base64"}}, false},
+		{NetworkSecurity{Headers: map[string]string{"X-Api-Key": "foo"}}, false},
+		{NetworkSecurity{Headers: map[string]string{"": "foo"}}, false},
+	} {
+		if tc.valid {
+			require.NoError(tc.sec.Validate(), "%+v", tc.sec)
+		} else {
+			require.Error(tc.sec.Validate(), "%+v", tc.sec)
+		}
+	}
+}
+
+func TestNetworkUsesTLS(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		net     Network
+		usesTLS bool
+	}{
+		{Network{RPC: "grpc.oasis.dev:443"}, true},
+		{Network{RPC: "unix:/tmp/internal.sock"}, false},
+		{Network{RPC: "10.0.0.1:42261", Security: &NetworkSecurity{Mode: SecurityModeInsecure}}, false},
+		{Network{RPC: "10.0.0.1:42261", Security: &NetworkSecurity{CACert: "ca.pem"}}, true},
+	} {
+		require.Equal(tc.usesTLS, tc.net.UsesTLS(), tc.net.RPC)
+	}
+}
+
+func TestNetworkValidateRPC(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		rpc   string
+		valid bool
+	}{
+		{"grpc.oasis.dev:443", true},
+		{"unix:/tmp/internal.sock", true},
+		{"10.0.0.1:42261", true},
+		{"[fd00::1]:42261", true},
+		{"10.0.0.1:foo:bar", false},
+	} {
+		net := Network{
+			ChainContext: "b11b369e0da5bb230b220127f5e7b242d385ef8c6f54906243f30af63c815535",
+			RPC:          tc.rpc,
+		}
+		if tc.valid {
+			require.NoError(net.Validate(), tc.rpc)
+		} else {
+			require.Error(net.Validate(), tc.rpc)
+		}
+	}
+}
@@ -2,24 +2,30 @@ package connection
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	consensusMod "github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensus"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensusaccounts"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/contracts"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/core"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/rewards"
 )
 
@@ -30,8 +36,10 @@ type RuntimeClient struct {
 	Core              core.V1
 	Accounts          accounts.V1
 	Rewards           rewards.V1
+	Consensus         consensusMod.V1
 	ConsensusAccounts consensusaccounts.V1
 	Contracts         contracts.V1
+	EVM               evm.V1
 }
 
 // Connection is the general node connection interface.
@@ -39,6 +47,27 @@ type Connection interface {
 	// Consensus returns an interface to the consensus layer.
 	Consensus() consensus.ClientBackend
 
+	// Staking returns an interface to the consensus layer staking backend.
+	Staking() StakingClient
+
+	// Registry returns an interface to the consensus layer registry backend.
+	Registry() RegistryClient
+
+	// Scheduler returns an interface to the consensus layer scheduler backend.
+	Scheduler() scheduler.Backend
+
+	// Governance returns an interface to the consensus layer governance backend.
+	Governance() governance.Backend
+
+	// Beacon returns an interface to the consensus layer beacon backend.
+	Beacon() beacon.Backend
+
+	// RootHash returns an interface to the consensus layer roothash backend.
+	RootHash() roothash.Backend
+
+	// KeyManager returns an interface to the consensus layer key manager backend.
+	KeyManager() KeyManagerClient
+
 	// Control returns an interface to the node control layer.
 	Control() control.NodeController
 
@@ -48,12 +77,46 @@ type Connection interface {
 
 type connection struct {
 	conn *grpc.ClientConn
+	net  *config.Network
 }
 
 func (c *connection) Consensus() consensus.ClientBackend {
 	return consensus.NewConsensusClient(c.conn)
 }
 
+func (c *connection) Staking() StakingClient {
+	return &stakingClient{
+		Backend: staking.NewStakingClient(c.conn),
+		net:     c.net,
+	}
+}
+
+func (c *connection) Registry() RegistryClient {
+	return &registryClient{
+		Backend: registry.NewRegistryClient(c.conn),
+	}
+}
+
+func (c *connection) Scheduler() scheduler.Backend {
+	return scheduler.NewSchedulerClient(c.conn)
+}
+
+func (c *connection) Governance() governance.Backend {
+	return governance.NewGovernanceClient(c.conn)
+}
+
+func (c *connection) Beacon() beacon.Backend {
+	return beacon.NewBeaconClient(c.conn)
+}
+
+func (c *connection) RootHash() roothash.Backend {
+	return roothash.NewRootHashClient(c.conn)
+}
+
+func (c *connection) KeyManager() KeyManagerClient {
+	return keymanager.NewKeymanagerClient(c.conn)
+}
+
 func (c *connection) Control() control.NodeController {
 	return control.NewNodeControllerClient(c.conn)
 }
@@ -63,14 +126,20 @@ func (c *connection) Runtime(pt *config.ParaTime) RuntimeClient {
 	if err := runtimeID.UnmarshalHex(pt.ID); err != nil {
 		panic(err)
 	}
-	cli := client.New(c.conn, runtimeID)
+	return newRuntimeClient(client.New(c.conn, runtimeID))
+}
+
+// newRuntimeClient wraps the given runtime client with the commonly used modules.
+func newRuntimeClient(cli client.RuntimeClient) RuntimeClient {
 	return RuntimeClient{
 		RuntimeClient:     cli,
 		Core:              core.NewV1(cli),
 		Accounts:          accounts.NewV1(cli),
 		Rewards:           rewards.NewV1(cli),
+		Consensus:         consensusMod.NewV1(cli),
 		ConsensusAccounts: consensusaccounts.NewV1(cli),
 		Contracts:         contracts.NewV1(cli),
+		EVM:               evm.NewV1(cli),
 	}
 }
 
@@ -98,15 +167,9 @@ func Connect(ctx context.Context, net *config.Network) (Connection, error) {
 // ConnectNoVerify establishes a connection with the target network,
 // omitting the chain context check.
 func ConnectNoVerify(ctx context.Context, net *config.Network) (Connection, error) {
-	var dialOpts []grpc.DialOption
-	switch net.IsLocalRPC() {
-	case true:
-		// No TLS needed for local nodes.
-		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	case false:
-		// Configure TLS for non-local nodes.
-		creds := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
-		dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
+	dialOpts, err := dialOptions(net)
+	if err != nil {
+		return nil, err
 	}
 
 	conn, err := cmnGrpc.Dial(net.RPC, dialOpts...)
@@ -116,5 +179,6 @@ func ConnectNoVerify(ctx context.Context, net *config.Network) (Connection, erro
 
 	return &connection{
 		conn: conn,
+		net:  net,
 	}, nil
 }
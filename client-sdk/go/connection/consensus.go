@@ -0,0 +1,146 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// StakingClient is the consensus layer staking backend that uses the SDK address and denomination
+// conventions.
+type StakingClient interface {
+	staking.Backend
+
+	// AccountOf returns the consensus layer account of the given address.
+	AccountOf(ctx context.Context, height int64, address types.Address) (*staking.Account, error)
+
+	// BalanceOf returns the general balance of the given address.
+	BalanceOf(ctx context.Context, height int64, address types.Address) (*types.Quantity, error)
+
+	// AllowancesOf returns the allowances granted by the given address, keyed by beneficiary.
+	AllowancesOf(ctx context.Context, height int64, address types.Address) (map[types.Address]types.Quantity, error)
+
+	// DelegationsOf returns the delegations made by the given address, keyed by escrow account.
+	DelegationsOf(ctx context.Context, height int64, address types.Address) (map[types.Address]*staking.Delegation, error)
+
+	// DelegationsToAddress returns the delegations to the given address, keyed by delegator.
+	DelegationsToAddress(ctx context.Context, height int64, address types.Address) (map[types.Address]*staking.Delegation, error)
+
+	// FormatAmount formats the given amount using the network's consensus layer denomination.
+	FormatAmount(amount types.Quantity) string
+
+	// ParseAmount parses the given amount using the network's consensus layer denomination.
+	ParseAmount(amount string) (*types.Quantity, error)
+}
+
+type stakingClient struct {
+	staking.Backend
+
+	net *config.Network
+}
+
+// Implements StakingClient.
+func (s *stakingClient) AccountOf(ctx context.Context, height int64, address types.Address) (*staking.Account, error) {
+	return s.Account(ctx, &staking.OwnerQuery{Height: height, Owner: address.ConsensusAddress()})
+}
+
+// Implements StakingClient.
+func (s *stakingClient) BalanceOf(ctx context.Context, height int64, address types.Address) (*types.Quantity, error) {
+	acct, err := s.AccountOf(ctx, height, address)
+	if err != nil {
+		return nil, err
+	}
+	return &acct.General.Balance, nil
+}
+
+// Implements StakingClient.
+func (s *stakingClient) AllowancesOf(ctx context.Context, height int64, address types.Address) (map[types.Address]types.Quantity, error) {
+	acct, err := s.AccountOf(ctx, height, address)
+	if err != nil {
+		return nil, err
+	}
+	allowances := make(map[types.Address]types.Quantity, len(acct.General.Allowances))
+	for beneficiary, amount := range acct.General.Allowances {
+		allowances[types.NewAddressFromConsensus(beneficiary)] = amount
+	}
+	return allowances, nil
+}
+
+// Implements StakingClient.
+func (s *stakingClient) DelegationsOf(ctx context.Context, height int64, address types.Address) (map[types.Address]*staking.Delegation, error) {
+	dels, err := s.DelegationsFor(ctx, &staking.OwnerQuery{Height: height, Owner: address.ConsensusAddress()})
+	if err != nil {
+		return nil, err
+	}
+	return convertDelegations(dels), nil
+}
+
+// Implements StakingClient.
+func (s *stakingClient) DelegationsToAddress(ctx context.Context, height int64, address types.Address) (map[types.Address]*staking.Delegation, error) {
+	dels, err := s.DelegationsTo(ctx, &staking.OwnerQuery{Height: height, Owner: address.ConsensusAddress()})
+	if err != nil {
+		return nil, err
+	}
+	return convertDelegations(dels), nil
+}
+
+// Implements StakingClient.
+func (s *stakingClient) FormatAmount(amount types.Quantity) string {
+	return helpers.FormatConsensusDenomination(s.net, amount)
+}
+
+// Implements StakingClient.
+func (s *stakingClient) ParseAmount(amount string) (*types.Quantity, error) {
+	return helpers.ParseConsensusDenomination(s.net, amount)
+}
+
+func convertDelegations(dels map[staking.Address]*staking.Delegation) map[types.Address]*staking.Delegation {
+	result := make(map[types.Address]*staking.Delegation, len(dels))
+	for addr, del := range dels {
+		result[types.NewAddressFromConsensus(addr)] = del
+	}
+	return result
+}
+
+// RegistryClient is the consensus layer registry backend that uses the SDK address conventions.
+type RegistryClient interface {
+	registry.Backend
+
+	// EntityByAddress returns the registered entity with the given address.
+	EntityByAddress(ctx context.Context, height int64, address types.Address) (*entity.Entity, error)
+}
+
+type registryClient struct {
+	registry.Backend
+}
+
+// Implements RegistryClient.
+func (r *registryClient) EntityByAddress(ctx context.Context, height int64, address types.Address) (*entity.Entity, error) {
+	entities, err := r.GetEntities(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	for _, ent := range entities {
+		if types.NewAddressFromConsensusPublicKey(ent.ID).Equal(address) {
+			return ent, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", registry.ErrNoSuchEntity, address)
+}
+
+// KeyManagerClient is the consensus layer key manager backend.
+type KeyManagerClient interface {
+	// GetStatus returns a key manager status by key manager ID.
+	GetStatus(ctx context.Context, query *registry.NamespaceQuery) (*keymanager.Status, error)
+
+	// GetStatuses returns all currently tracked key manager statuses.
+	GetStatuses(ctx context.Context, height int64) ([]*keymanager.Status, error)
+}
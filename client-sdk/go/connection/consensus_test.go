@@ -0,0 +1,105 @@
+package connection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+type testStakingBackend struct {
+	staking.Backend
+
+	accounts    map[staking.Address]*staking.Account
+	delegations map[staking.Address]map[staking.Address]*staking.Delegation
+}
+
+func (b *testStakingBackend) Account(ctx context.Context, query *staking.OwnerQuery) (*staking.Account, error) {
+	if acct, ok := b.accounts[query.Owner]; ok {
+		return acct, nil
+	}
+	return &staking.Account{}, nil
+}
+
+func (b *testStakingBackend) DelegationsFor(ctx context.Context, query *staking.OwnerQuery) (map[staking.Address]*staking.Delegation, error) {
+	return b.delegations[query.Owner], nil
+}
+
+type testRegistryBackend struct {
+	registry.Backend
+
+	entities []*entity.Entity
+}
+
+func (b *testRegistryBackend) GetEntities(ctx context.Context, height int64) ([]*entity.Entity, error) {
+	return b.entities, nil
+}
+
+func TestStakingClient(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	alice := sdkTesting.Alice.Address
+	bob := sdkTesting.Bob.Address
+
+	var acct staking.Account
+	acct.General.Balance = *quantity.NewFromUint64(1_000_000_000)
+	acct.General.Allowances = map[staking.Address]quantity.Quantity{
+		bob.ConsensusAddress(): *quantity.NewFromUint64(10),
+	}
+	sc := &stakingClient{
+		Backend: &testStakingBackend{
+			accounts: map[staking.Address]*staking.Account{alice.ConsensusAddress(): &acct},
+			delegations: map[staking.Address]map[staking.Address]*staking.Delegation{
+				alice.ConsensusAddress(): {bob.ConsensusAddress(): {}},
+			},
+		},
+		net: &config.Network{
+			Denomination: config.DenominationInfo{Symbol: "TEST", Decimals: 9},
+		},
+	}
+
+	balance, err := sc.BalanceOf(ctx, 1, alice)
+	require.NoError(err, "BalanceOf")
+	require.Equal("1.0 TEST", sc.FormatAmount(*balance))
+
+	amount, err := sc.ParseAmount("1")
+	require.NoError(err, "ParseAmount")
+	require.Equal(balance, amount)
+
+	allowances, err := sc.AllowancesOf(ctx, 1, alice)
+	require.NoError(err, "AllowancesOf")
+	require.Equal(map[types.Address]types.Quantity{bob: *quantity.NewFromUint64(10)}, allowances)
+
+	dels, err := sc.DelegationsOf(ctx, 1, alice)
+	require.NoError(err, "DelegationsOf")
+	require.Contains(dels, bob)
+}
+
+func TestRegistryClient(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	alice := &entity.Entity{ID: memorySigner.NewTestSigner("connection test: alice").Public()}
+	bob := &entity.Entity{ID: memorySigner.NewTestSigner("connection test: bob").Public()}
+	rc := &registryClient{
+		Backend: &testRegistryBackend{entities: []*entity.Entity{alice, bob}},
+	}
+
+	ent, err := rc.EntityByAddress(ctx, 1, types.NewAddressFromConsensusPublicKey(bob.ID))
+	require.NoError(err, "EntityByAddress")
+	require.Equal(bob, ent)
+
+	_, err = rc.EntityByAddress(ctx, 1, sdkTesting.Alice.Address)
+	require.ErrorIs(err, registry.ErrNoSuchEntity)
+}
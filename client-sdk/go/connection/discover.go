@@ -0,0 +1,188 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	consensusMod "github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensus"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// Discovery is the result of discovering a network from a node.
+type Discovery struct {
+	// Network is the discovered network configuration.
+	Network *config.Network
+	// Skipped are the compute runtimes registered in the consensus layer that could not be
+	// discovered, keyed by runtime ID, e.g. because the node does not serve them or because they
+	// are not based on the SDK.
+	Skipped map[string]error
+}
+
+// Discover queries the node at the network's RPC endpoint for the chain context, native token and
+// registered ParaTimes and returns the complete network configuration.
+//
+// Only the RPC endpoint and the security profile of the given network are used. Names and
+// descriptions of known ParaTimes are taken from config.DefaultNetworks.
+func Discover(ctx context.Context, net *config.Network) (*Discovery, error) {
+	conn, err := ConnectNoVerify(ctx, net)
+	if err != nil {
+		return nil, err
+	}
+	return discover(ctx, conn, net)
+}
+
+func discover(ctx context.Context, conn Connection, base *config.Network) (*Discovery, error) {
+	chainContext, err := conn.Consensus().GetChainContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve chain context: %w", err)
+	}
+	symbol, err := conn.Staking().TokenSymbol(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve token symbol: %w", err)
+	}
+	decimals, err := conn.Staking().TokenValueExponent(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve token value exponent: %w", err)
+	}
+
+	net := &config.Network{
+		ChainContext: chainContext,
+		RPC:          base.RPC,
+		Security:     base.Security,
+		Denomination: config.DenominationInfo{
+			Symbol:   symbol,
+			Decimals: decimals,
+		},
+	}
+	var known *config.Network
+	for _, defaultNet := range config.DefaultNetworks.All {
+		if defaultNet.ChainContext == chainContext {
+			known = defaultNet
+			net.Description = defaultNet.Description
+			break
+		}
+	}
+
+	runtimes, err := conn.Registry().GetRuntimes(ctx, &registry.GetRuntimesQuery{Height: consensus.HeightLatest})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve runtimes: %w", err)
+	}
+	sort.Slice(runtimes, func(i, j int) bool {
+		return runtimes[i].ID.String() < runtimes[j].ID.String()
+	})
+
+	d := &Discovery{
+		Network: net,
+		Skipped: make(map[string]error),
+	}
+	for _, rt := range runtimes {
+		if rt.Kind != registry.KindCompute {
+			continue
+		}
+		id := rt.ID.Hex()
+
+		name, pt := discoveryParaTimeTemplate(known, id)
+		if err = discoverParaTime(ctx, conn.Runtime(pt), net, pt); err != nil {
+			d.Skipped[id] = err
+			continue
+		}
+		if err = net.ParaTimes.Add(name, pt); err != nil {
+			d.Skipped[id] = err
+			continue
+		}
+	}
+	if known != nil {
+		if _, exists := net.ParaTimes.All[known.ParaTimes.Default]; exists {
+			net.ParaTimes.Default = known.ParaTimes.Default
+		}
+	}
+
+	return d, nil
+}
+
+// discoveryParaTimeTemplate returns the name and the initial configuration of the ParaTime with
+// the given ID, using the known network configuration if available.
+func discoveryParaTimeTemplate(known *config.Network, id string) (string, *config.ParaTime) {
+	if known != nil {
+		for name, pt := range known.ParaTimes.All {
+			if pt.ID != id {
+				continue
+			}
+			template := &config.ParaTime{Description: pt.Description, ID: id}
+			if di, ok := pt.Denominations[config.NativeDenominationKey]; ok {
+				template.Denominations = map[string]*config.DenominationInfo{
+					config.NativeDenominationKey: {Symbol: di.Symbol},
+				}
+			}
+			return name, template
+		}
+	}
+	return fmt.Sprintf("paratime_%s", id[len(id)-8:]), &config.ParaTime{ID: id}
+}
+
+// discoverParaTime queries the given ParaTime for its denominations and stores them in the
+// ParaTime configuration.
+//
+// The denomination representing the consensus layer token uses the network's symbol while other
+// denominations are named after themselves. The native denomination keeps the symbol already
+// configured for it and defaults to the network's symbol.
+func discoverParaTime(ctx context.Context, rc RuntimeClient, net *config.Network, pt *config.ParaTime) error {
+	info, err := rc.Core.RuntimeInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query runtime info: %w", err)
+	}
+	if _, ok := info.Modules[accounts.ModuleName]; !ok {
+		return fmt.Errorf("runtime does not include the %s module", accounts.ModuleName)
+	}
+
+	params, err := rc.Accounts.Parameters(ctx, client.RoundLatest)
+	if err != nil {
+		return fmt.Errorf("failed to query accounts parameters: %w", err)
+	}
+	nativeInfo, err := rc.Accounts.DenominationInfo(ctx, client.RoundLatest, types.NativeDenomination)
+	if err != nil {
+		return fmt.Errorf("failed to query native denomination info: %w", err)
+	}
+
+	consensusDenomination := types.NativeDenomination
+	if _, ok := info.Modules[consensusMod.ModuleName]; ok {
+		var consensusParams *consensusMod.Parameters
+		if consensusParams, err = rc.Consensus.Parameters(ctx, client.RoundLatest); err != nil {
+			return fmt.Errorf("failed to query consensus parameters: %w", err)
+		}
+		consensusDenomination = consensusParams.ConsensusDenomination
+	}
+
+	nativeSymbol := net.Denomination.Symbol
+	if di, ok := pt.Denominations[config.NativeDenominationKey]; ok && di.Symbol != "" {
+		nativeSymbol = di.Symbol
+	}
+	pt.Denominations = map[string]*config.DenominationInfo{
+		config.NativeDenominationKey: {
+			Symbol:   nativeSymbol,
+			Decimals: nativeInfo.Decimals,
+		},
+	}
+	for denom, di := range params.DenominationInfos {
+		if denom.IsNative() {
+			continue
+		}
+		symbol := string(denom)
+		if denom == consensusDenomination {
+			symbol = net.Denomination.Symbol
+		}
+		pt.Denominations[string(denom)] = &config.DenominationInfo{
+			Symbol:   symbol,
+			Decimals: di.Decimals,
+		}
+	}
+
+	return pt.Validate()
+}
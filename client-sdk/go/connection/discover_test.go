@@ -0,0 +1,213 @@
+package connection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	consensusMod "github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensus"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/core"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing/mock"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+type testConsensusBackend struct {
+	consensus.ClientBackend
+
+	chainContext string
+}
+
+func (b *testConsensusBackend) GetChainContext(ctx context.Context) (string, error) {
+	return b.chainContext, nil
+}
+
+type testTokenBackend struct {
+	staking.Backend
+
+	symbol   string
+	exponent uint8
+}
+
+func (b *testTokenBackend) TokenSymbol(ctx context.Context) (string, error) {
+	return b.symbol, nil
+}
+
+func (b *testTokenBackend) TokenValueExponent(ctx context.Context) (uint8, error) {
+	return b.exponent, nil
+}
+
+type testRuntimesBackend struct {
+	registry.Backend
+
+	runtimes []*registry.Runtime
+}
+
+func (b *testRuntimesBackend) GetRuntimes(ctx context.Context, query *registry.GetRuntimesQuery) ([]*registry.Runtime, error) {
+	return b.runtimes, nil
+}
+
+type testConnection struct {
+	Connection
+
+	consensus *testConsensusBackend
+	staking   *testTokenBackend
+	registry  *testRuntimesBackend
+	runtimes  map[string]*mock.RuntimeClient
+}
+
+func (c *testConnection) Consensus() consensus.ClientBackend {
+	return c.consensus
+}
+
+func (c *testConnection) Staking() StakingClient {
+	return &stakingClient{Backend: c.staking}
+}
+
+func (c *testConnection) Registry() RegistryClient {
+	return &registryClient{Backend: c.registry}
+}
+
+func (c *testConnection) Runtime(pt *config.ParaTime) RuntimeClient {
+	return newRuntimeClient(c.runtimes[pt.ID])
+}
+
+func newDiscoveryRuntime(t *testing.T, id string, modules []string, denominations map[types.Denomination]accounts.DenominationInfo, consensusDenomination types.Denomination) *mock.RuntimeClient {
+	var runtimeID common.Namespace
+	require.NoError(t, runtimeID.UnmarshalHex(id))
+
+	rc := mock.NewRuntimeClient(runtimeID, "test chain context")
+	if modules == nil {
+		// Not an SDK runtime.
+		return rc
+	}
+
+	info := &core.RuntimeInfoResponse{Modules: make(map[string]core.ModuleInfo)}
+	for _, m := range modules {
+		info.Modules[m] = core.ModuleInfo{Version: 1}
+	}
+	rc.SetQueryHandler("core.RuntimeInfo", func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		return info, nil
+	})
+	rc.SetQueryHandler("accounts.Parameters", func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		return &accounts.Parameters{DenominationInfos: denominations}, nil
+	})
+	rc.SetQueryHandler("accounts.DenominationInfo", func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		var query accounts.DenominationInfoQuery
+		if err := cbor.Unmarshal(args, &query); err != nil {
+			return nil, err
+		}
+		di := denominations[query.Denomination]
+		return &di, nil
+	})
+	rc.SetQueryHandler("consensus.Parameters", func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		return &consensusMod.Parameters{ConsensusDenomination: consensusDenomination, ConsensusScalingFactor: 1}, nil
+	})
+	return rc
+}
+
+func TestDiscover(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	testnet := config.DefaultNetworks.All["testnet"]
+	emeraldID := testnet.ParaTimes.All["emerald"].ID
+	customID := "8000000000000000000000000000000000000000000000000000000000c0ffee"
+	legacyID := "8000000000000000000000000000000000000000000000000000000000000001"
+	kmID := "4000000000000000000000000000000000000000000000000000000000000000"
+
+	var runtimes []*registry.Runtime
+	for _, rt := range []struct {
+		id   string
+		kind registry.RuntimeKind
+	}{
+		{legacyID, registry.KindCompute},
+		{kmID, registry.KindKeyManager},
+		{customID, registry.KindCompute},
+		{emeraldID, registry.KindCompute},
+	} {
+		var id common.Namespace
+		require.NoError(id.UnmarshalHex(rt.id))
+		runtimes = append(runtimes, &registry.Runtime{ID: id, Kind: rt.kind})
+	}
+
+	conn := &testConnection{
+		consensus: &testConsensusBackend{chainContext: testnet.ChainContext},
+		staking:   &testTokenBackend{symbol: "TEST", exponent: 9},
+		registry:  &testRuntimesBackend{runtimes: runtimes},
+		runtimes: map[string]*mock.RuntimeClient{
+			emeraldID: newDiscoveryRuntime(t, emeraldID,
+				[]string{accounts.ModuleName, consensusMod.ModuleName},
+				map[types.Denomination]accounts.DenominationInfo{types.NativeDenomination: {Decimals: 18}},
+				types.NativeDenomination,
+			),
+			customID: newDiscoveryRuntime(t, customID,
+				[]string{accounts.ModuleName, consensusMod.ModuleName},
+				map[types.Denomination]accounts.DenominationInfo{
+					types.NativeDenomination: {Decimals: 6},
+					"WRAPPED":                {Decimals: 9},
+					"FOO":                    {Decimals: 3},
+				},
+				"WRAPPED",
+			),
+			legacyID: newDiscoveryRuntime(t, legacyID, nil, nil, ""),
+		},
+	}
+
+	d, err := discover(ctx, conn, &config.Network{RPC: "localhost:42261"})
+	require.NoError(err, "discover")
+
+	net := d.Network
+	require.NoError(net.Validate())
+	require.Equal(testnet.ChainContext, net.ChainContext)
+	require.Equal("localhost:42261", net.RPC)
+	require.Equal(testnet.Description, net.Description)
+	require.Equal(config.DenominationInfo{Symbol: "TEST", Decimals: 9}, net.Denomination)
+
+	require.Len(net.ParaTimes.All, 2)
+	require.Equal("emerald", net.ParaTimes.Default)
+	require.Equal(&config.ParaTime{
+		ID: emeraldID,
+		Denominations: map[string]*config.DenominationInfo{
+			config.NativeDenominationKey: {Symbol: "TEST", Decimals: 18},
+		},
+	}, net.ParaTimes.All["emerald"])
+	require.Equal(&config.ParaTime{
+		ID: customID,
+		Denominations: map[string]*config.DenominationInfo{
+			config.NativeDenominationKey: {Symbol: "TEST", Decimals: 6},
+			"WRAPPED":                    {Symbol: "TEST", Decimals: 9},
+			"FOO":                        {Symbol: "FOO", Decimals: 3},
+		},
+	}, net.ParaTimes.All["paratime_00c0ffee"])
+
+	require.Len(d.Skipped, 1)
+	require.Contains(d.Skipped, legacyID)
+	require.Contains(d.Skipped[legacyID].Error(), "runtime info")
+}
+
+func TestDiscoverUnknownNetwork(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	conn := &testConnection{
+		consensus: &testConsensusBackend{chainContext: "0000000000000000000000000000000000000000000000000000000000000000"},
+		staking:   &testTokenBackend{symbol: "LOCAL", exponent: 6},
+		registry:  &testRuntimesBackend{},
+	}
+
+	d, err := discover(ctx, conn, &config.Network{RPC: "unix:/tmp/node.sock"})
+	require.NoError(err, "discover")
+	require.Empty(d.Network.Description)
+	require.Empty(d.Network.ParaTimes.All)
+	require.Empty(d.Skipped)
+	require.Equal(config.DenominationInfo{Symbol: "LOCAL", Decimals: 6}, d.Network.Denomination)
+}
@@ -0,0 +1,149 @@
+package connection
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+)
+
+// headerCredentials are per-RPC credentials that attach static metadata headers to requests.
+type headerCredentials struct {
+	headers    map[string]string
+	requireTLS bool
+}
+
+func (hc *headerCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return hc.headers, nil
+}
+
+func (hc *headerCredentials) RequireTransportSecurity() bool {
+	return hc.requireTLS
+}
+
+// newHeaderCredentials creates the per-RPC credentials for the headers configured in the network's
+// security profile. It returns nil in case no headers are configured.
+func newHeaderCredentials(net *config.Network) *headerCredentials {
+	if net.Security == nil || len(net.Security.Headers) == 0 {
+		return nil
+	}
+
+	headers := make(map[string]string, len(net.Security.Headers))
+	for name, value := range net.Security.Headers {
+		headers[name] = os.ExpandEnv(value)
+	}
+	return &headerCredentials{
+		headers:    headers,
+		requireTLS: net.UsesTLS(),
+	}
+}
+
+// newTLSConfig creates the TLS configuration for the given security profile.
+func newTLSConfig(sec *config.NetworkSecurity) (*tls.Config, error) {
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if sec == nil {
+		return tlsCfg, nil
+	}
+
+	tlsCfg.ServerName = sec.ServerName
+
+	if sec.CACert != "" {
+		pem, err := os.ReadFile(sec.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no CA certificates found in '%s'", sec.CACert)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if sec.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(sec.ClientCert, sec.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if len(sec.PinnedPublicKeys) > 0 {
+		pins := make(map[string]bool, len(sec.PinnedPublicKeys))
+		for _, pin := range sec.PinnedPublicKeys {
+			pins[pin] = true
+		}
+
+		if sec.CACert == "" {
+			// Without a CA the pins are the only trust anchor, which allows connecting to nodes
+			// with self-signed certificates. Chain verification is skipped and the leaf
+			// certificate, whose key the node has proven possession of, must be pinned.
+			tlsCfg.InsecureSkipVerify = true
+			tlsCfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+				if len(rawCerts) == 0 {
+					return fmt.Errorf("node did not present a certificate")
+				}
+				leaf, err := x509.ParseCertificate(rawCerts[0])
+				if err != nil {
+					return err
+				}
+				if !pins[PublicKeyPin(leaf)] {
+					return fmt.Errorf("node's certificate public key is not pinned")
+				}
+				return nil
+			}
+		} else {
+			// Only certificates in verified chains are considered as the node may send
+			// arbitrary additional certificates.
+			tlsCfg.VerifyPeerCertificate = func(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+				for _, chain := range verifiedChains {
+					for _, cert := range chain {
+						if pins[PublicKeyPin(cert)] {
+							return nil
+						}
+					}
+				}
+				return fmt.Errorf("no pinned public key found in the node's verified certificate chain")
+			}
+		}
+	}
+
+	return tlsCfg, nil
+}
+
+// PublicKeyPin returns the pin of the given certificate's public key in the format used by
+// config.NetworkSecurity.
+func PublicKeyPin(cert *x509.Certificate) string {
+	h := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+// dialOptions returns the gRPC dial options honouring the network's security profile.
+func dialOptions(net *config.Network) ([]grpc.DialOption, error) {
+	var dialOpts []grpc.DialOption
+	switch net.UsesTLS() {
+	case true:
+		tlsCfg, err := newTLSConfig(net.Security)
+		if err != nil {
+			return nil, err
+		}
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+	case false:
+		// No TLS needed for local nodes and trusted private networks.
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	if hc := newHeaderCredentials(net); hc != nil {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(hc))
+	}
+
+	return dialOpts, nil
+}
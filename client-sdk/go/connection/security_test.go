@@ -0,0 +1,151 @@
+package connection
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+)
+
+func generateTestCertificate(t *testing.T, dir, name string) (*x509.Certificate, string, string) {
+	require := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err, "GenerateKey")
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(err, "CreateCertificate")
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(err, "ParseCertificate")
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(err, "MarshalECPrivateKey")
+
+	certFn := filepath.Join(dir, name+".pem")
+	keyFn := filepath.Join(dir, name+".key")
+	err = os.WriteFile(certFn, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	require.NoError(err, "WriteFile")
+	err = os.WriteFile(keyFn, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600)
+	require.NoError(err, "WriteFile")
+
+	return cert, certFn, keyFn
+}
+
+// tlsHandshake performs a TLS handshake between a client using the given configuration and a
+// server presenting the given certificate.
+func tlsHandshake(clientCfg *tls.Config, serverCert tls.Certificate) error {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	go func() {
+		defer serverConn.Close()
+		_ = tls.Server(serverConn, &tls.Config{Certificates: []tls.Certificate{serverCert}}).Handshake()
+	}()
+	return tls.Client(clientConn, clientCfg).Handshake()
+}
+
+func TestTLSConfig(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	server, serverCertFn, serverKeyFn := generateTestCertificate(t, dir, "server")
+	other, otherCertFn, otherKeyFn := generateTestCertificate(t, dir, "other")
+	_, clientCertFn, clientKeyFn := generateTestCertificate(t, dir, "client")
+
+	tlsCfg, err := newTLSConfig(nil)
+	require.NoError(err, "newTLSConfig")
+	require.Nil(tlsCfg.RootCAs)
+	require.Nil(tlsCfg.VerifyPeerCertificate)
+
+	sec := &config.NetworkSecurity{
+		CACert:           serverCertFn,
+		ClientCert:       clientCertFn,
+		ClientKey:        clientKeyFn,
+		ServerName:       "node.example.com",
+		PinnedPublicKeys: []string{PublicKeyPin(server)},
+	}
+	require.NoError(sec.Validate())
+	tlsCfg, err = newTLSConfig(sec)
+	require.NoError(err, "newTLSConfig")
+	require.NotNil(tlsCfg.RootCAs)
+	require.Len(tlsCfg.Certificates, 1)
+	require.Equal("node.example.com", tlsCfg.ServerName)
+	require.False(tlsCfg.InsecureSkipVerify)
+	require.NoError(tlsCfg.VerifyPeerCertificate([][]byte{server.Raw}, [][]*x509.Certificate{{server}}))
+	require.Error(tlsCfg.VerifyPeerCertificate([][]byte{other.Raw}, [][]*x509.Certificate{{other}}))
+	require.Error(
+		tlsCfg.VerifyPeerCertificate([][]byte{other.Raw, server.Raw}, [][]*x509.Certificate{{other}}),
+		"pinned certificates outside of the verified chain should be ignored",
+	)
+
+	// Without a CA the node's own certificate must be pinned.
+	serverCert, err := tls.LoadX509KeyPair(serverCertFn, serverKeyFn)
+	require.NoError(err, "LoadX509KeyPair")
+	otherCert, err := tls.LoadX509KeyPair(otherCertFn, otherKeyFn)
+	require.NoError(err, "LoadX509KeyPair")
+	otherCert.Certificate = append(otherCert.Certificate, server.Raw)
+
+	tlsCfg, err = newTLSConfig(&config.NetworkSecurity{PinnedPublicKeys: []string{PublicKeyPin(server)}})
+	require.NoError(err, "newTLSConfig")
+	require.True(tlsCfg.InsecureSkipVerify)
+	require.NoError(tlsHandshake(tlsCfg, serverCert), "self-signed pinned certificate should be accepted")
+	require.Error(tlsHandshake(tlsCfg, otherCert), "appended pinned certificate should be rejected")
+
+	// Missing files should be reported.
+	_, err = newTLSConfig(&config.NetworkSecurity{CACert: filepath.Join(dir, "missing.pem")})
+	require.Error(err)
+	_, err = newTLSConfig(&config.NetworkSecurity{CACert: clientKeyFn})
+	require.Error(err, "a file without certificates should be rejected")
+}
+
+func TestHeaderCredentials(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(newHeaderCredentials(&config.Network{RPC: "grpc.example.com:443"}))
+
+	t.Setenv("OASIS_TEST_API_KEY", "secret")
+	net := &config.Network{
+		RPC: "10.0.0.1:42261",
+		Security: &config.NetworkSecurity{
+			Mode: config.SecurityModeInsecure,
+			Headers: map[string]string{
+				"x-api-key":     "$OASIS_TEST_API_KEY",
+				"authorization": "Bearer ${OASIS_TEST_API_KEY}",
+			},
+		},
+	}
+	hc := newHeaderCredentials(net)
+	require.NotNil(hc)
+	require.False(hc.RequireTransportSecurity())
+	md, err := hc.GetRequestMetadata(context.Background())
+	require.NoError(err, "GetRequestMetadata")
+	require.Equal(map[string]string{"x-api-key": "secret", "authorization": "Bearer secret"}, md)
+
+	net.Security.Mode = config.SecurityModeTLS
+	require.True(newHeaderCredentials(net).RequireTransportSecurity())
+
+	opts, err := dialOptions(net)
+	require.NoError(err, "dialOptions")
+	require.Len(opts, 2)
+}
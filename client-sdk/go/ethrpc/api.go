@@ -0,0 +1,381 @@
+package ethrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// ethereumTxAuthScheme is the module-controlled authentication scheme used for Ethereum-signed
+// transactions.
+const ethereumTxAuthScheme = "evm.ethereum.v0"
+
+func parseParams(params []json.RawMessage, required int, out ...interface{}) error {
+	if len(params) < required {
+		return invalidParams("missing value for required argument %d", len(params))
+	}
+	if len(params) > len(out) {
+		return invalidParams("too many arguments, want at most %d", len(out))
+	}
+	for i, p := range params {
+		if err := json.Unmarshal(p, out[i]); err != nil {
+			return invalidParams("invalid argument %d: %s", i, err)
+		}
+	}
+	return nil
+}
+
+// resolveRound resolves the given block number into a runtime round.
+func (s *Server) resolveRound(ctx context.Context, bn *blockNumber) (uint64, error) {
+	switch {
+	case bn == nil, bn.tag == tagLatest, bn.tag == tagPending:
+		return client.RoundLatest, nil
+	case bn.tag == tagEarliest:
+		blk, err := s.rc.GetLastRetainedBlock(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return blk.Header.Round, nil
+	default:
+		return bn.round, nil
+	}
+}
+
+func (s *Server) latestRound(ctx context.Context) (uint64, error) {
+	blk, err := s.rc.GetBlock(ctx, client.RoundLatest)
+	if err != nil {
+		return 0, err
+	}
+	return blk.Header.Round, nil
+}
+
+func (s *Server) chainID(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	return hexutil.Uint64(s.cfg.ChainID), nil
+}
+
+func (s *Server) netVersion(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	return strconv.FormatUint(s.cfg.ChainID, 10), nil
+}
+
+func (s *Server) blockNumber(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	round, err := s.latestRound(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Uint64(round), nil
+}
+
+func (s *Server) getBalance(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var (
+		address common.Address
+		bn      *blockNumber
+	)
+	if err := parseParams(params, 1, &address, &bn); err != nil {
+		return nil, err
+	}
+	round, err := s.resolveRound(ctx, bn)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := s.evm.Balance(ctx, round, address.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(balance.ToBigInt()), nil
+}
+
+func (s *Server) getCode(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var (
+		address common.Address
+		bn      *blockNumber
+	)
+	if err := parseParams(params, 1, &address, &bn); err != nil {
+		return nil, err
+	}
+	round, err := s.resolveRound(ctx, bn)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := s.evm.Code(ctx, round, address.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(code), nil
+}
+
+func (s *Server) getStorageAt(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var (
+		address  common.Address
+		position string
+		bn       *blockNumber
+	)
+	if err := parseParams(params, 2, &address, &position, &bn); err != nil {
+		return nil, err
+	}
+	index, err := decodeStorageKey(position)
+	if err != nil {
+		return nil, invalidParams("invalid storage position: %s", err)
+	}
+	round, err := s.resolveRound(ctx, bn)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := s.evm.Storage(ctx, round, address.Bytes(), index)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(common.LeftPadBytes(value, 32)), nil
+}
+
+func (s *Server) call(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var (
+		args callArgs
+		bn   *blockNumber
+	)
+	if err := parseParams(params, 1, &args, &bn); err != nil {
+		return nil, err
+	}
+	if args.To == nil {
+		return nil, invalidParams("contract creation is not supported")
+	}
+	round, err := s.resolveRound(ctx, bn)
+	if err != nil {
+		return nil, err
+	}
+
+	var caller common.Address
+	if args.From != nil {
+		caller = *args.From
+	}
+	gasLimit := s.cfg.CallGasLimit
+	if args.Gas != nil {
+		gasLimit = uint64(*args.Gas)
+	}
+	gasPrice, err := u256Bytes((*big.Int)(args.GasPrice))
+	if err != nil {
+		return nil, invalidParams("invalid gas price: %s", err)
+	}
+	value, err := u256Bytes((*big.Int)(args.Value))
+	if err != nil {
+		return nil, invalidParams("invalid value: %s", err)
+	}
+
+	result, err := s.evm.SimulateCall(ctx, round, gasPrice, gasLimit, caller.Bytes(), args.To.Bytes(), value, args.data())
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(result), nil
+}
+
+func (s *Server) getLogs(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var filter filterQuery
+	if err := parseParams(params, 1, &filter); err != nil {
+		return nil, err
+	}
+	if filter.BlockHash != nil {
+		return nil, invalidParams("filtering by block hash is not supported")
+	}
+
+	latest, err := s.latestRound(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolve := func(bn *blockNumber) (uint64, error) {
+		round, rerr := s.resolveRound(ctx, bn)
+		if round == client.RoundLatest {
+			round = latest
+		}
+		return round, rerr
+	}
+	from, err := resolve(filter.FromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolve(filter.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case from > to:
+		return nil, invalidParams("invalid block range")
+	case to-from >= s.cfg.MaxLogRounds:
+		return nil, invalidParams("block range too large (maximum is %d blocks)", s.cfg.MaxLogRounds)
+	}
+
+	logs := make([]*rpcLog, 0)
+	for round := from; round <= to; round++ {
+		roundLogs, err := s.getRoundLogs(ctx, round, &filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get logs for round %d: %w", round, err)
+		}
+		logs = append(logs, roundLogs...)
+	}
+	return logs, nil
+}
+
+func (s *Server) getRoundLogs(ctx context.Context, round uint64, filter *filterQuery) ([]*rpcLog, error) {
+	blk, err := s.rc.GetBlock(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	blockHash := blk.Header.EncodedHash()
+
+	txs, err := s.rc.GetTransactionsWithResults(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		logs     []*rpcLog
+		logIndex uint
+	)
+	for txIndex, tx := range txs {
+		txHash := transactionHash(&tx.Tx)
+		for _, rawEv := range tx.Events {
+			evs, err := evm.DecodeEvent(rawEv)
+			if err != nil {
+				return nil, err
+			}
+			for _, dev := range evs {
+				ev := dev.(*evm.Event)
+				log := &rpcLog{
+					Address:     common.BytesToAddress(ev.Address),
+					Data:        ev.Data,
+					BlockNumber: hexutil.Uint64(round),
+					BlockHash:   common.BytesToHash(blockHash[:]),
+					TxHash:      txHash,
+					TxIndex:     hexutil.Uint(txIndex),
+					Index:       hexutil.Uint(logIndex),
+				}
+				for _, topic := range ev.Topics {
+					log.Topics = append(log.Topics, common.BytesToHash(topic))
+				}
+				logIndex++
+
+				if filter.matches(log) {
+					logs = append(logs, log)
+				}
+			}
+		}
+	}
+	return logs, nil
+}
+
+func (s *Server) sendRawTransaction(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var raw hexutil.Bytes
+	if err := parseParams(params, 1, &raw); err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, invalidParams("empty transaction")
+	}
+
+	tx := &types.UnverifiedTransaction{
+		Body:       raw,
+		AuthProofs: []types.AuthProof{{Module: ethereumTxAuthScheme}},
+	}
+	if err := s.rc.SubmitTxNoWait(ctx, tx); err != nil {
+		return nil, err
+	}
+	return transactionHash(tx), nil
+}
+
+// transactionHash returns the hash of the given transaction as seen by Ethereum clients.
+//
+// For Ethereum-encoded transactions this is the Keccak-256 hash of the raw transaction, for
+// all other transactions it is the runtime transaction hash.
+func transactionHash(tx *types.UnverifiedTransaction) common.Hash {
+	if len(tx.AuthProofs) == 1 && tx.AuthProofs[0].Module == ethereumTxAuthScheme {
+		return common.BytesToHash(crypto.Keccak256(tx.Body))
+	}
+	h := tx.Hash()
+	return common.BytesToHash(h[:])
+}
+
+// matches returns true iff the given log matches the filter's address and topic criteria.
+func (f *filterQuery) matches(log *rpcLog) bool {
+	if len(f.Addresses) > 0 {
+		var found bool
+		for _, addr := range f.Addresses {
+			if bytes.Equal(addr[:], log.Address[:]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(f.Topics) > len(log.Topics) {
+		return false
+	}
+	for i, alternatives := range f.Topics {
+		if len(alternatives) == 0 {
+			// Wildcard.
+			continue
+		}
+		var found bool
+		for _, topic := range alternatives {
+			if topic == log.Topics[i] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// u256Bytes encodes the given value as a big-endian 256-bit unsigned integer. A nil value is
+// encoded as zero.
+func u256Bytes(v *big.Int) ([]byte, error) {
+	if v == nil {
+		return make([]byte, 32), nil
+	}
+	if v.Sign() < 0 || v.BitLen() > 256 {
+		return nil, fmt.Errorf("value out of range")
+	}
+	return common.LeftPadBytes(v.Bytes(), 32), nil
+}
+
+// decodeStorageKey decodes a hex-encoded storage key which may be shorter than 32 bytes and may
+// contain leading zeros.
+func decodeStorageKey(key string) ([]byte, error) {
+	if !has0xPrefix(key) {
+		return nil, fmt.Errorf("missing 0x prefix")
+	}
+	key = key[2:]
+	if len(key) > 64 {
+		return nil, fmt.Errorf("key too long")
+	}
+	if len(key)%2 == 1 {
+		key = "0" + key
+	}
+	raw, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, err
+	}
+	return common.LeftPadBytes(raw, 32), nil
+}
+
+func has0xPrefix(s string) bool {
+	return len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X')
+}
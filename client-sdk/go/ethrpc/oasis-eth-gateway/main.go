@@ -0,0 +1,57 @@
+// Command oasis-eth-gateway serves a subset of the Ethereum JSON-RPC API for a ParaTime that uses
+// the EVM module, allowing standard Ethereum tooling to be used against it.
+//
+// Usage:
+//
+//	oasis-eth-gateway -rpc grpc.oasis.dev:443 -runtime-id 000...ff -chain-id 42261 -listen :8545
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/connection"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/ethrpc"
+)
+
+func main() {
+	var (
+		rpc          = flag.String("rpc", "", "gRPC endpoint of the Oasis node")
+		runtimeID    = flag.String("runtime-id", "", "hex-encoded ParaTime identifier")
+		chainID      = flag.Uint64("chain-id", 0, "EVM chain ID of the ParaTime")
+		listen       = flag.String("listen", "127.0.0.1:8545", "address to serve JSON-RPC requests on")
+		maxLogRounds = flag.Uint64("max-log-rounds", ethrpc.DefaultMaxLogRounds, "maximum number of rounds in a single eth_getLogs request")
+	)
+	flag.Parse()
+
+	if err := run(*rpc, *runtimeID, *chainID, *listen, *maxLogRounds); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(rpc, runtimeID string, chainID uint64, listen string, maxLogRounds uint64) error {
+	if rpc == "" || runtimeID == "" || chainID == 0 {
+		return fmt.Errorf("the -rpc, -runtime-id and -chain-id flags are required")
+	}
+	pt := &config.ParaTime{ID: runtimeID}
+	if err := pt.Validate(); err != nil {
+		return err
+	}
+
+	conn, err := connection.ConnectNoVerify(context.Background(), &config.Network{RPC: rpc})
+	if err != nil {
+		return fmt.Errorf("failed to connect to node: %w", err)
+	}
+
+	srv := ethrpc.NewServer(conn.Runtime(pt), &ethrpc.Config{
+		ChainID:      chainID,
+		MaxLogRounds: maxLogRounds,
+	})
+	fmt.Fprintf(os.Stderr, "serving Ethereum JSON-RPC on %s\n", listen)
+	return http.ListenAndServe(listen, srv) // nolint: gosec
+}
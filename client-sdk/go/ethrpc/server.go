@@ -0,0 +1,259 @@
+// Package ethrpc implements a gateway serving a subset of the Ethereum JSON-RPC API on top of a
+// runtime that uses the EVM module.
+package ethrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
+)
+
+const (
+	jsonrpcVersion = "2.0"
+
+	// DefaultMaxLogRounds is the default maximum number of rounds that can be queried in a single
+	// eth_getLogs request.
+	DefaultMaxLogRounds = 100
+
+	// maxRequestSize is the maximum size of a JSON-RPC request body.
+	maxRequestSize = 5 * 1024 * 1024
+)
+
+// Standard JSON-RPC and Ethereum-specific error codes.
+const (
+	ErrCodeParse          = -32700
+	ErrCodeInvalidRequest = -32600
+	ErrCodeMethodNotFound = -32601
+	ErrCodeInvalidParams  = -32602
+	ErrCodeInternal       = -32603
+	ErrCodeServer         = -32000
+	ErrCodeReverted       = 3
+)
+
+// Error is a JSON-RPC error.
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Error returns the string representation of the JSON-RPC error.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func invalidParams(format string, a ...interface{}) *Error {
+	return &Error{Code: ErrCodeInvalidParams, Message: fmt.Sprintf(format, a...)}
+}
+
+type request struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Config is the gateway configuration.
+type Config struct {
+	// ChainID is the EVM chain ID of the runtime.
+	ChainID uint64
+	// MaxLogRounds is the maximum number of rounds that can be queried in a single eth_getLogs
+	// request. Defaults to DefaultMaxLogRounds.
+	MaxLogRounds uint64
+	// CallGasLimit is the gas limit used for eth_call in case the caller does not specify one.
+	// Defaults to evm.DefaultSimulateCallGasLimit.
+	CallGasLimit uint64
+}
+
+type methodHandler func(ctx context.Context, params []json.RawMessage) (interface{}, error)
+
+// Server is an Ethereum JSON-RPC gateway.
+//
+// It implements http.Handler and serves JSON-RPC 2.0 requests (including batches) via HTTP POST.
+type Server struct {
+	rc  client.RuntimeClient
+	evm evm.V1
+	cfg Config
+
+	methods map[string]methodHandler
+	logger  *logging.Logger
+}
+
+// NewServer creates a new Ethereum JSON-RPC gateway for the given runtime.
+func NewServer(rc client.RuntimeClient, cfg *Config) *Server {
+	s := &Server{
+		rc:     rc,
+		evm:    evm.NewV1(rc),
+		cfg:    *cfg,
+		logger: logging.GetLogger("ethrpc"),
+	}
+	if s.cfg.MaxLogRounds == 0 {
+		s.cfg.MaxLogRounds = DefaultMaxLogRounds
+	}
+	if s.cfg.CallGasLimit == 0 {
+		s.cfg.CallGasLimit = evm.DefaultSimulateCallGasLimit
+	}
+
+	s.methods = map[string]methodHandler{
+		"eth_chainId":            s.chainID,
+		"net_version":            s.netVersion,
+		"eth_blockNumber":        s.blockNumber,
+		"eth_getBalance":         s.getBalance,
+		"eth_getCode":            s.getCode,
+		"eth_getStorageAt":       s.getStorageAt,
+		"eth_call":               s.call,
+		"eth_getLogs":            s.getLogs,
+		"eth_sendRawTransaction": s.sendRawTransaction,
+	}
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+
+	result := s.HandleRaw(r.Context(), body)
+	if result == nil {
+		// Only notifications, nothing to respond with.
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(result)
+}
+
+// HandleRaw handles a raw JSON-RPC request or batch of requests and returns the raw response.
+//
+// Notifications (requests without an identifier) are not answered so in case the body only
+// contains notifications, nil is returned.
+func (s *Server) HandleRaw(ctx context.Context, body []byte) []byte {
+	body = bytes.TrimSpace(body)
+
+	var rsp interface{}
+	switch {
+	case len(body) > 0 && body[0] == '[':
+		var reqs []json.RawMessage
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			rsp = &response{Version: jsonrpcVersion, ID: json.RawMessage("null"), Error: &Error{Code: ErrCodeParse, Message: err.Error()}}
+			break
+		}
+		if len(reqs) == 0 {
+			rsp = &response{Version: jsonrpcVersion, ID: json.RawMessage("null"), Error: &Error{Code: ErrCodeInvalidRequest, Message: "empty batch"}}
+			break
+		}
+		rsps := make([]*response, 0, len(reqs))
+		for _, raw := range reqs {
+			if r := s.handleRequest(ctx, raw); r != nil {
+				rsps = append(rsps, r)
+			}
+		}
+		if len(rsps) == 0 {
+			return nil
+		}
+		rsp = rsps
+	default:
+		r := s.handleRequest(ctx, body)
+		if r == nil {
+			return nil
+		}
+		rsp = r
+	}
+
+	data, err := json.Marshal(rsp)
+	if err != nil {
+		// This should never happen as all results are JSON-serializable.
+		s.logger.Error("failed to marshal response", "err", err)
+		data, _ = json.Marshal(&response{Version: jsonrpcVersion, ID: json.RawMessage("null"), Error: &Error{Code: ErrCodeInternal, Message: "internal error"}})
+	}
+	return data
+}
+
+// handleRequest handles a single JSON-RPC request. It returns nil for notifications.
+func (s *Server) handleRequest(ctx context.Context, raw json.RawMessage) *response {
+	var req request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return &response{Version: jsonrpcVersion, ID: json.RawMessage("null"), Error: &Error{Code: ErrCodeParse, Message: err.Error()}}
+	}
+	rsp := &response{Version: jsonrpcVersion, ID: req.ID}
+	if rsp.ID == nil {
+		rsp.ID = json.RawMessage("null")
+	}
+	if req.Version != jsonrpcVersion || req.Method == "" {
+		rsp.Error = &Error{Code: ErrCodeInvalidRequest, Message: "invalid request"}
+		return rsp
+	}
+
+	// Notifications are processed but never answered, not even with errors.
+	notification := req.ID == nil
+
+	handler, ok := s.methods[req.Method]
+	if !ok {
+		if notification {
+			return nil
+		}
+		rsp.Error = &Error{Code: ErrCodeMethodNotFound, Message: fmt.Sprintf("the method %s does not exist/is not available", req.Method)}
+		return rsp
+	}
+
+	var params []json.RawMessage
+	if len(req.Params) > 0 && string(req.Params) != "null" {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			if notification {
+				return nil
+			}
+			rsp.Error = invalidParams("invalid params: %s", err)
+			return rsp
+		}
+	}
+
+	result, err := handler(ctx, params)
+	switch {
+	case notification:
+		return nil
+	case err != nil:
+		rsp.Error = s.toError(req.Method, err)
+	default:
+		rsp.Result = result
+	}
+	return rsp
+}
+
+func (s *Server) toError(method string, err error) *Error {
+	if rpcErr, ok := err.(*Error); ok {
+		return rpcErr
+	}
+	if rev := evm.DecodeRevert(err, nil); rev != nil {
+		rpcErr := &Error{Code: ErrCodeReverted, Message: rev.Error()}
+		if len(rev.Data) > 0 {
+			rpcErr.Data = fmt.Sprintf("0x%x", rev.Data)
+		}
+		return rpcErr
+	}
+
+	s.logger.Debug("request failed", "method", method, "err", err)
+	return &Error{Code: ErrCodeServer, Message: err.Error()}
+}
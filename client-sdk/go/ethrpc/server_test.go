@@ -0,0 +1,361 @@
+package ethrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	coreCommon "github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	coreErrors "github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing/mock"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+var (
+	testContract = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	testOther    = common.HexToAddress("0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512")
+	testTopicA   = crypto.Keccak256Hash([]byte("A()"))
+	testTopicB   = crypto.Keccak256Hash([]byte("B()"))
+)
+
+func evmTx(nonce uint64, ethereum bool, evs ...*evm.Event) *client.TransactionWithResults {
+	tx := types.UnverifiedTransaction{Body: cbor.Marshal(nonce)}
+	if ethereum {
+		tx.AuthProofs = []types.AuthProof{{Module: ethereumTxAuthScheme}}
+	}
+	return &client.TransactionWithResults{
+		Tx:     tx,
+		Result: types.CallResult{Ok: cbor.Marshal(nil)},
+		Events: []*types.Event{
+			{Module: "accounts", Code: 1, Value: cbor.Marshal(nil)},
+			{Module: evm.ModuleName, Code: 1, Value: cbor.Marshal(evs)},
+		},
+	}
+}
+
+func newTestServer(t *testing.T) (*Server, *mock.RuntimeClient) {
+	rc := mock.NewRuntimeClient(coreCommon.NewTestNamespaceFromSeed([]byte("ethrpc test"), 0), "test chain context")
+
+	rc.SetQueryHandler("evm.Balance", func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		var q evm.BalanceQuery
+		if err := cbor.Unmarshal(args, &q); err != nil {
+			return nil, err
+		}
+		return quantity.NewFromUint64(1000 * round), nil
+	})
+	rc.SetQueryHandler("evm.Code", func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		var q evm.CodeQuery
+		if err := cbor.Unmarshal(args, &q); err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(q.Address, testContract.Bytes()) {
+			return []byte{}, nil
+		}
+		return []byte{0x60, 0x80}, nil
+	})
+	rc.SetQueryHandler("evm.Storage", func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		var q evm.StorageQuery
+		if err := cbor.Unmarshal(args, &q); err != nil {
+			return nil, err
+		}
+		if len(q.Index) != 32 {
+			return nil, coreErrors.FromCode("evm", 1, "invalid index")
+		}
+		return []byte{q.Index[31] + 1}, nil
+	})
+	rc.SetQueryHandler("evm.SimulateCall", func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		var q evm.SimulateCallQuery
+		if err := cbor.Unmarshal(args, &q); err != nil {
+			return nil, err
+		}
+		switch {
+		case len(q.Data) == 0:
+			return nil, coreErrors.FromCode("evm", evm.ErrCodeReverted, "reverted: no data")
+		case q.GasLimit != evm.DefaultSimulateCallGasLimit:
+			return q.Data[:1], nil
+		default:
+			return q.Data, nil
+		}
+	})
+
+	rc.AddBlock()
+	rc.AddBlock(
+		evmTx(0, false, &evm.Event{Address: testContract.Bytes(), Topics: [][]byte{testTopicA.Bytes()}, Data: []byte{1}}),
+		evmTx(1, true,
+			&evm.Event{Address: testOther.Bytes(), Topics: [][]byte{testTopicB.Bytes()}, Data: []byte{2}},
+			&evm.Event{Address: testContract.Bytes(), Topics: [][]byte{testTopicB.Bytes(), testTopicA.Bytes()}, Data: []byte{3}},
+		),
+	)
+	rc.AddBlock(evmTx(2, false, &evm.Event{Address: testContract.Bytes(), Topics: [][]byte{testTopicA.Bytes()}, Data: []byte{4}}))
+
+	return NewServer(rc, &Config{ChainID: 42261, MaxLogRounds: 10}), rc
+}
+
+func call(t *testing.T, s *Server, method string, params ...interface{}) (json.RawMessage, *Error) {
+	req := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	}
+	rawReq, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	var rsp struct {
+		ID     int             `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *Error          `json:"error"`
+	}
+	err = json.Unmarshal(s.HandleRaw(context.Background(), rawReq), &rsp)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, rsp.ID)
+	return rsp.Result, rsp.Error
+}
+
+func TestServerQueries(t *testing.T) {
+	require := require.New(t)
+	s, _ := newTestServer(t)
+
+	for _, tc := range []struct {
+		method   string
+		params   []interface{}
+		expected string
+	}{
+		{"eth_chainId", nil, `"0xa515"`},
+		{"net_version", nil, `"42261"`},
+		{"eth_blockNumber", nil, `"0x3"`},
+		{"eth_getBalance", []interface{}{testOther, "latest"}, `"0xbb8"`},
+		{"eth_getBalance", []interface{}{testOther, "0x1"}, `"0x3e8"`},
+		{"eth_getBalance", []interface{}{testOther, "earliest"}, `"0x0"`},
+		{"eth_getBalance", []interface{}{testOther}, `"0xbb8"`},
+		{"eth_getBalance", []interface{}{testOther, map[string]string{"blockNumber": "0x2"}}, `"0x7d0"`},
+		{"eth_getCode", []interface{}{testContract, "latest"}, `"0x6080"`},
+		{"eth_getCode", []interface{}{testOther, "latest"}, `"0x"`},
+		{"eth_getStorageAt", []interface{}{testContract, "0x0", "latest"}, `"0x0000000000000000000000000000000000000000000000000000000000000001"`},
+		{"eth_getStorageAt", []interface{}{testContract, "0x0000000000000000000000000000000000000000000000000000000000000002"}, `"0x0000000000000000000000000000000000000000000000000000000000000003"`},
+		{"eth_call", []interface{}{map[string]string{"to": testContract.Hex(), "data": "0x1234"}, "latest"}, `"0x1234"`},
+		{"eth_call", []interface{}{map[string]string{"to": testContract.Hex(), "data": "0x1234", "input": "0xabcd"}}, `"0xabcd"`},
+		{"eth_call", []interface{}{map[string]string{"to": testContract.Hex(), "data": "0x1234", "gas": "0x5208"}}, `"0x12"`},
+	} {
+		result, rpcErr := call(t, s, tc.method, tc.params...)
+		require.Nil(rpcErr, "%s(%v)", tc.method, tc.params)
+		require.JSONEq(tc.expected, string(result), "%s(%v)", tc.method, tc.params)
+	}
+}
+
+func TestServerGetLogs(t *testing.T) {
+	require := require.New(t)
+	s, _ := newTestServer(t)
+
+	for _, tc := range []struct {
+		filter   map[string]interface{}
+		expected []byte
+	}{
+		{map[string]interface{}{}, []byte{4}},
+		{map[string]interface{}{"fromBlock": "earliest"}, []byte{1, 2, 3, 4}},
+		{map[string]interface{}{"fromBlock": "0x2", "toBlock": "0x2"}, []byte{1, 2, 3}},
+		{map[string]interface{}{"fromBlock": "0x0", "address": testContract}, []byte{1, 3, 4}},
+		{map[string]interface{}{"fromBlock": "0x0", "address": []common.Address{testContract, testOther}}, []byte{1, 2, 3, 4}},
+		{map[string]interface{}{"fromBlock": "0x0", "topics": []interface{}{testTopicB}}, []byte{2, 3}},
+		{map[string]interface{}{"fromBlock": "0x0", "topics": []interface{}{nil, testTopicA}}, []byte{3}},
+		{map[string]interface{}{"fromBlock": "0x0", "topics": []interface{}{[]common.Hash{testTopicA, testTopicB}}}, []byte{1, 2, 3, 4}},
+		{map[string]interface{}{"fromBlock": "0x0", "address": testOther, "topics": []interface{}{testTopicA}}, []byte{}},
+	} {
+		result, rpcErr := call(t, s, "eth_getLogs", tc.filter)
+		require.Nil(rpcErr, "eth_getLogs(%v)", tc.filter)
+
+		var logs []*rpcLog
+		err := json.Unmarshal(result, &logs)
+		require.NoError(err, "eth_getLogs(%v)", tc.filter)
+		data := []byte{}
+		for _, log := range logs {
+			data = append(data, log.Data...)
+		}
+		require.EqualValues(tc.expected, data, "eth_getLogs(%v)", tc.filter)
+	}
+
+	// Check log metadata.
+	result, rpcErr := call(t, s, "eth_getLogs", map[string]interface{}{"fromBlock": "0x2", "toBlock": "0x2"})
+	require.Nil(rpcErr)
+	var logs []*rpcLog
+	err := json.Unmarshal(result, &logs)
+	require.NoError(err)
+	require.Len(logs, 3)
+
+	txs, err := s.rc.GetTransactionsWithResults(context.Background(), 2)
+	require.NoError(err)
+	blk, err := s.rc.GetBlock(context.Background(), 2)
+	require.NoError(err)
+	blkHash := blk.Header.EncodedHash()
+
+	log := logs[2]
+	require.Equal(testContract, log.Address)
+	require.Equal([]common.Hash{testTopicB, testTopicA}, log.Topics)
+	require.EqualValues(2, log.BlockNumber)
+	require.Equal(common.BytesToHash(blkHash[:]), log.BlockHash)
+	require.Equal(crypto.Keccak256Hash(txs[1].Tx.Body), log.TxHash, "Ethereum transactions should use the Ethereum hash")
+	require.EqualValues(1, log.TxIndex)
+	require.EqualValues(2, log.Index)
+
+	txHash := txs[0].Tx.Hash()
+	require.Equal(common.BytesToHash(txHash[:]), logs[0].TxHash, "other transactions should use the runtime hash")
+}
+
+func TestServerSendRawTransaction(t *testing.T) {
+	require := require.New(t)
+	s, rc := newTestServer(t)
+
+	raw := []byte{0xf8, 0x6b, 0x80, 0x85}
+	result, rpcErr := call(t, s, "eth_sendRawTransaction", "0xf86b8085")
+	require.Nil(rpcErr)
+	require.JSONEq(`"`+crypto.Keccak256Hash(raw).Hex()+`"`, string(result))
+
+	submitted := rc.Submitted()
+	require.Len(submitted, 1)
+	require.EqualValues(raw, submitted[0].Body)
+	require.Len(submitted[0].AuthProofs, 1)
+	require.Equal("evm.ethereum.v0", submitted[0].AuthProofs[0].Module)
+
+	// The transaction should have been included in a new block.
+	result, rpcErr = call(t, s, "eth_blockNumber")
+	require.Nil(rpcErr)
+	require.JSONEq(`"0x4"`, string(result))
+}
+
+func TestServerErrors(t *testing.T) {
+	require := require.New(t)
+	s, _ := newTestServer(t)
+
+	for _, tc := range []struct {
+		method string
+		params []interface{}
+		code   int
+	}{
+		{"eth_unknown", nil, ErrCodeMethodNotFound},
+		{"eth_getBalance", nil, ErrCodeInvalidParams},
+		{"eth_getBalance", []interface{}{testOther, "latest", "extra"}, ErrCodeInvalidParams},
+		{"eth_getBalance", []interface{}{"not an address"}, ErrCodeInvalidParams},
+		{"eth_getBalance", []interface{}{testOther, "0x10"}, ErrCodeServer},
+		{"eth_getStorageAt", []interface{}{testContract, "0"}, ErrCodeInvalidParams},
+		{"eth_getStorageAt", []interface{}{testContract, "0x" + strings.Repeat("0", 65)}, ErrCodeInvalidParams},
+		{"eth_call", []interface{}{map[string]string{"data": "0x1234"}}, ErrCodeInvalidParams},
+		{"eth_call", []interface{}{map[string]string{"to": testContract.Hex()}}, ErrCodeReverted},
+		{"eth_getLogs", []interface{}{map[string]interface{}{"fromBlock": "0x3", "toBlock": "0x2"}}, ErrCodeInvalidParams},
+		{"eth_getLogs", []interface{}{map[string]interface{}{"blockHash": common.Hash{}}}, ErrCodeInvalidParams},
+		{"eth_sendRawTransaction", []interface{}{"0x"}, ErrCodeInvalidParams},
+	} {
+		_, rpcErr := call(t, s, tc.method, tc.params...)
+		require.NotNil(rpcErr, "%s(%v)", tc.method, tc.params)
+		require.Equal(tc.code, rpcErr.Code, "%s(%v): %s", tc.method, tc.params, rpcErr.Message)
+	}
+
+	// Reverts should include the revert reason.
+	_, rpcErr := call(t, s, "eth_call", map[string]string{"to": testContract.Hex()})
+	require.NotNil(rpcErr)
+	require.Equal("execution reverted: no data", rpcErr.Message)
+
+	// Log range limits.
+	s.cfg.MaxLogRounds = 2
+	_, rpcErr = call(t, s, "eth_getLogs", map[string]interface{}{"fromBlock": "earliest"})
+	require.NotNil(rpcErr)
+	require.Equal(ErrCodeInvalidParams, rpcErr.Code)
+}
+
+func TestServerHTTP(t *testing.T) {
+	require := require.New(t)
+	s, _ := newTestServer(t)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	rsp, err := http.Get(srv.URL)
+	require.NoError(err)
+	rsp.Body.Close()
+	require.Equal(http.StatusMethodNotAllowed, rsp.StatusCode)
+
+	batch := `[
+		{"jsonrpc": "2.0", "id": 1, "method": "eth_chainId"},
+		{"jsonrpc": "2.0", "id": "two", "method": "eth_blockNumber", "params": []},
+		{"jsonrpc": "1.0", "id": 3, "method": "eth_chainId"},
+		{"jsonrpc": "2.0", "id": 4, "method": "eth_nope"}
+	]`
+	rsp, err = http.Post(srv.URL, "application/json", strings.NewReader(batch))
+	require.NoError(err)
+	defer rsp.Body.Close()
+	require.Equal(http.StatusOK, rsp.StatusCode)
+
+	var rsps []struct {
+		ID     json.RawMessage `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *Error          `json:"error"`
+	}
+	err = json.NewDecoder(rsp.Body).Decode(&rsps)
+	require.NoError(err)
+	require.Len(rsps, 4)
+	require.JSONEq(`1`, string(rsps[0].ID))
+	require.JSONEq(`"0xa515"`, string(rsps[0].Result))
+	require.JSONEq(`"two"`, string(rsps[1].ID))
+	require.JSONEq(`"0x3"`, string(rsps[1].Result))
+	require.Equal(ErrCodeInvalidRequest, rsps[2].Error.Code)
+	require.Equal(ErrCodeMethodNotFound, rsps[3].Error.Code)
+
+	for _, body := range []string{`[]`, `{`, `[{]`} {
+		raw := s.HandleRaw(context.Background(), []byte(body))
+		var single response
+		err = json.Unmarshal(raw, &single)
+		require.NoError(err, body)
+		require.NotNil(single.Error, body)
+	}
+}
+
+func TestServerNotifications(t *testing.T) {
+	require := require.New(t)
+	s, rc := newTestServer(t)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	// Notifications should be processed but not answered.
+	for _, body := range []string{
+		`{"jsonrpc": "2.0", "method": "eth_sendRawTransaction", "params": ["0xf86b8085"]}`,
+		`{"jsonrpc": "2.0", "method": "eth_nope"}`,
+		`{"jsonrpc": "2.0", "method": "eth_getBalance", "params": {}}`,
+		`[{"jsonrpc": "2.0", "method": "eth_chainId"}, {"jsonrpc": "2.0", "method": "eth_blockNumber"}]`,
+	} {
+		require.Nil(s.HandleRaw(context.Background(), []byte(body)), body)
+	}
+	require.Len(rc.Submitted(), 1, "notifications should be processed")
+
+	rsp, err := http.Post(srv.URL, "application/json", strings.NewReader(`{"jsonrpc": "2.0", "method": "eth_chainId"}`))
+	require.NoError(err)
+	rsp.Body.Close()
+	require.Equal(http.StatusNoContent, rsp.StatusCode)
+
+	// Only requests should be answered in mixed batches.
+	batch := `[
+		{"jsonrpc": "2.0", "method": "eth_chainId"},
+		{"jsonrpc": "2.0", "id": null, "method": "eth_chainId"},
+		{"jsonrpc": "2.0", "id": 2, "method": "eth_blockNumber"}
+	]`
+	var rsps []struct {
+		ID     json.RawMessage `json:"id"`
+		Result json.RawMessage `json:"result"`
+	}
+	err = json.Unmarshal(s.HandleRaw(context.Background(), []byte(batch)), &rsps)
+	require.NoError(err)
+	require.Len(rsps, 2)
+	require.JSONEq(`null`, string(rsps[0].ID))
+	require.JSONEq(`"0xa515"`, string(rsps[0].Result))
+	require.JSONEq(`2`, string(rsps[1].ID))
+}
@@ -0,0 +1,166 @@
+package ethrpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type blockTag int
+
+const (
+	tagNumber blockTag = iota
+	tagLatest
+	tagPending
+	tagEarliest
+)
+
+// blockNumber is a block number or one of the special block tags.
+type blockNumber struct {
+	tag   blockTag
+	round uint64
+}
+
+// UnmarshalJSON decodes a block number or tag.
+func (bn *blockNumber) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		// Support the EIP-1898 object form with a block number.
+		var obj struct {
+			BlockNumber *blockNumber `json:"blockNumber"`
+			BlockHash   *common.Hash `json:"blockHash"`
+		}
+		if err = json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		if obj.BlockHash != nil || obj.BlockNumber == nil {
+			return fmt.Errorf("block hashes are not supported")
+		}
+		*bn = *obj.BlockNumber
+		return nil
+	}
+
+	switch raw {
+	case "latest":
+		bn.tag = tagLatest
+	case "pending":
+		bn.tag = tagPending
+	case "earliest":
+		bn.tag = tagEarliest
+	default:
+		round, err := hexutil.DecodeUint64(raw)
+		if err != nil {
+			return fmt.Errorf("invalid block number: %w", err)
+		}
+		bn.tag = tagNumber
+		bn.round = round
+	}
+	return nil
+}
+
+// callArgs are the arguments of eth_call.
+type callArgs struct {
+	From     *common.Address `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      *hexutil.Uint64 `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Data     *hexutil.Bytes  `json:"data"`
+	Input    *hexutil.Bytes  `json:"input"`
+}
+
+// data returns the call data, preferring input over data.
+func (args *callArgs) data() []byte {
+	switch {
+	case args.Input != nil:
+		return *args.Input
+	case args.Data != nil:
+		return *args.Data
+	default:
+		return nil
+	}
+}
+
+// filterQuery is the filter of eth_getLogs.
+type filterQuery struct {
+	BlockHash *common.Hash
+	FromBlock *blockNumber
+	ToBlock   *blockNumber
+	Addresses []common.Address
+	// Topics are the topic criteria. Each position contains a list of alternatives where an empty
+	// list matches any topic.
+	Topics [][]common.Hash
+}
+
+// UnmarshalJSON decodes a log filter where addresses and topics can either be single values or
+// lists of values.
+func (f *filterQuery) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		BlockHash *common.Hash      `json:"blockHash"`
+		FromBlock *blockNumber      `json:"fromBlock"`
+		ToBlock   *blockNumber      `json:"toBlock"`
+		Address   json.RawMessage   `json:"address"`
+		Topics    []json.RawMessage `json:"topics"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	f.BlockHash = raw.BlockHash
+	f.FromBlock = raw.FromBlock
+	f.ToBlock = raw.ToBlock
+
+	if err := unmarshalOneOrMany(raw.Address, &f.Addresses); err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+	f.Topics = make([][]common.Hash, len(raw.Topics))
+	for i, topic := range raw.Topics {
+		if err := unmarshalOneOrMany(topic, &f.Topics[i]); err != nil {
+			return fmt.Errorf("invalid topic %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// unmarshalOneOrMany decodes either a single value or a list of values into out which must be a
+// pointer to a slice of addresses or hashes.
+func unmarshalOneOrMany(data json.RawMessage, out interface{}) error {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	if data[0] == '[' {
+		return json.Unmarshal(data, out)
+	}
+
+	switch o := out.(type) {
+	case *[]common.Address:
+		var v common.Address
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		*o = []common.Address{v}
+	case *[]common.Hash:
+		var v common.Hash
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		*o = []common.Hash{v}
+	default:
+		return fmt.Errorf("unsupported type: %T", out)
+	}
+	return nil
+}
+
+// rpcLog is an Ethereum log entry.
+type rpcLog struct {
+	Address     common.Address `json:"address"`
+	Topics      []common.Hash  `json:"topics"`
+	Data        hexutil.Bytes  `json:"data"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	TxHash      common.Hash    `json:"transactionHash"`
+	TxIndex     hexutil.Uint   `json:"transactionIndex"`
+	Index       hexutil.Uint   `json:"logIndex"`
+	Removed     bool           `json:"removed"`
+}
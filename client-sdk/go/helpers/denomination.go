@@ -2,11 +2,14 @@ package helpers
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/shopspring/decimal"
 
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensus"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 )
 
@@ -24,6 +27,81 @@ func ParseParaTimeDenomination(pt *config.ParaTime, amount string, denom types.D
 	return parseDenomination(pt.GetDenominationInfo(denom), amount, denom)
 }
 
+// ParseParaTimeConsensusAmount parses an amount of the consensus layer denomination in the given
+// ParaTime, e.g. for deposits and withdrawals. The consensus denomination and scaling factor are
+// taken from the given consensus module parameters.
+//
+// Amounts that cannot be exactly represented on the consensus layer are rejected. The
+// corresponding consensus layer amount is returned as well.
+func ParseParaTimeConsensusAmount(pt *config.ParaTime, params *consensus.Parameters, amount string) (*types.BaseUnits, *types.Quantity, error) {
+	bu, err := ParseParaTimeDenomination(pt, amount, params.ConsensusDenomination)
+	if err != nil {
+		return nil, nil, err
+	}
+	q, err := params.ToConsensusAmount(*bu)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bu, q, nil
+}
+
+// ParseParaTimeAmount parses an amount with an optional denomination (e.g. "10.5 USDC") for the
+// given ParaTime.
+//
+// The denomination can be given either by its name or by its configured symbol and must be
+// configured for the ParaTime as its number of decimals is otherwise unknown. In case no
+// denomination is given, the native denomination is used.
+func ParseParaTimeAmount(pt *config.ParaTime, amount string) (*types.BaseUnits, error) {
+	fields := strings.Fields(amount)
+	switch len(fields) {
+	case 1:
+		return ParseParaTimeDenomination(pt, fields[0], types.NativeDenomination)
+	case 2:
+		denom, err := ResolveParaTimeDenomination(pt, fields[1])
+		if err != nil {
+			return nil, err
+		}
+		return ParseParaTimeDenomination(pt, fields[0], denom)
+	default:
+		return nil, fmt.Errorf("malformed amount '%s' (expected <amount> [<denomination>])", amount)
+	}
+}
+
+// ResolveParaTimeDenomination resolves the given denomination name or symbol for the given
+// ParaTime.
+//
+// Configured denomination names take precedence over symbols. Denominations which are not
+// configured for the ParaTime are rejected.
+func ResolveParaTimeDenomination(pt *config.ParaTime, name string) (types.Denomination, error) {
+	if name == config.NativeDenominationKey {
+		return types.NativeDenomination, nil
+	}
+	if len(name) > types.MaxDenominationSize {
+		return types.NativeDenomination, fmt.Errorf("malformed denomination '%s'", name)
+	}
+	if _, ok := pt.Denominations[name]; ok {
+		return types.Denomination(name), nil
+	}
+
+	// Look up by symbol, in a deterministic order.
+	var denoms []string
+	for denom := range pt.Denominations {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+	for _, denom := range denoms {
+		if pt.Denominations[denom].Symbol != name {
+			continue
+		}
+		if denom == config.NativeDenominationKey {
+			return types.NativeDenomination, nil
+		}
+		return types.Denomination(denom), nil
+	}
+
+	return types.NativeDenomination, fmt.Errorf("denomination '%s' is not configured for the paratime", name)
+}
+
 func parseDenomination(di *config.DenominationInfo, amount string, denom types.Denomination) (*types.BaseUnits, error) {
 	v, err := decimal.NewFromString(amount)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/config"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensus"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 )
 
@@ -175,3 +176,92 @@ func TestFormatParaTimeDenomination(t *testing.T) {
 		require.EqualValues(tc.expected, FormatParaTimeDenomination(&pt, amount), "%d", tc.amount)
 	}
 }
+
+func TestParseParaTimeAmount(t *testing.T) {
+	require := require.New(t)
+
+	pt := config.ParaTime{
+		ID: "0000000000000000000000000000000000000000000000000000000000000000",
+		Denominations: map[string]*config.DenominationInfo{
+			"_": {
+				Symbol:   "TEST",
+				Decimals: 5,
+			},
+			"X": {
+				Symbol:   "OMG",
+				Decimals: 3,
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		amount   string
+		valid    bool
+		expected uint64
+		denom    types.Denomination
+	}{
+		{"", false, 0, types.NativeDenomination},
+		{"1 2 3", false, 0, types.NativeDenomination},
+		{"1", true, 100_000, types.NativeDenomination},
+		{"1 TEST", true, 100_000, types.NativeDenomination},
+		{"1 _", true, 100_000, types.NativeDenomination},
+		{"1 X", true, 1_000, types.Denomination("X")},
+		{"1 OMG", true, 1_000, types.Denomination("X")},
+		{" 10.5   OMG ", true, 10_500, types.Denomination("X")},
+		{"1 UNKNOWN", false, 0, types.NativeDenomination},
+		{"1 THISDENOMINATIONISWAYTOOLONGTOBEVALID", false, 0, types.NativeDenomination},
+		{"foo OMG", false, 0, types.NativeDenomination},
+	} {
+		amount, err := ParseParaTimeAmount(&pt, tc.amount)
+		if tc.valid {
+			require.NoError(err, tc.amount)
+
+			expected := types.NewBaseUnits(*quantity.NewFromUint64(tc.expected), tc.denom)
+			require.EqualValues(&expected, amount, tc.amount)
+		} else {
+			require.Error(err, tc.amount)
+		}
+	}
+}
+
+func TestParseParaTimeConsensusAmount(t *testing.T) {
+	require := require.New(t)
+
+	pt := config.ParaTime{
+		ID: "0000000000000000000000000000000000000000000000000000000000000000",
+		Denominations: map[string]*config.DenominationInfo{
+			"_": {
+				Symbol:   "TEST",
+				Decimals: 18,
+			},
+		},
+	}
+	params := consensus.Parameters{
+		ConsensusDenomination:  types.NativeDenomination,
+		ConsensusScalingFactor: 1_000_000_000,
+	}
+
+	for _, tc := range []struct {
+		amount    string
+		valid     bool
+		consensus uint64
+	}{
+		{"", false, 0},
+		{"0", true, 0},
+		{"1", true, 1_000_000_000},
+		{"10.123456789", true, 10_123_456_789},
+		{"10.1234567891", false, 0},
+	} {
+		amount, consensusAmount, err := ParseParaTimeConsensusAmount(&pt, &params, tc.amount)
+		if tc.valid {
+			require.NoError(err, tc.amount)
+			require.EqualValues(quantity.NewFromUint64(tc.consensus), consensusAmount, tc.amount)
+
+			expected, err := params.ToParaTimeAmount(*consensusAmount)
+			require.NoError(err, tc.amount)
+			require.EqualValues(expected, amount, tc.amount)
+		} else {
+			require.Error(err, tc.amount)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// DefaultSnapshotConcurrency is the default number of concurrent balance queries performed when
+// taking a snapshot.
+const DefaultSnapshotConcurrency = 8
+
+// HolderBalance is the balance of a single holder in a snapshot.
+type HolderBalance struct {
+	// Address is the holder address.
+	Address types.Address `json:"address"`
+	// Balance is the holder balance.
+	Balance types.Quantity `json:"balance"`
+}
+
+// Snapshot is a snapshot of all holders of a denomination at a given round.
+type Snapshot struct {
+	// Round is the round at which the snapshot has been taken.
+	Round uint64 `json:"round"`
+	// Denomination is the denomination.
+	Denomination types.Denomination `json:"denomination"`
+	// Decimals is the number of decimals that the denomination is using.
+	Decimals uint8 `json:"decimals"`
+	// TotalSupply is the sum of all holder balances.
+	TotalSupply types.Quantity `json:"total_supply"`
+
+	// Holders are the holder balances, sorted by address.
+	Holders []*HolderBalance `json:"holders"`
+}
+
+// Reconcile checks that the total supply of the snapshot matches the expected total supply.
+func (s *Snapshot) Reconcile(expected *types.Quantity) error {
+	if s.TotalSupply.Cmp(expected) != 0 {
+		return fmt.Errorf("accounts: snapshot total supply %s does not match expected total supply %s", s.TotalSupply, expected)
+	}
+	return nil
+}
+
+// SnapshotOptions are the options for taking a snapshot.
+type SnapshotOptions struct {
+	// Concurrency is the number of concurrent balance queries. If zero,
+	// DefaultSnapshotConcurrency is used.
+	Concurrency int
+	// QueryInterval is the minimum interval between balance queries. If zero, queries are not
+	// rate limited.
+	QueryInterval time.Duration
+
+	// Completed are the holder balances fetched by a previous, interrupted, snapshot of the same
+	// round and denomination. Balances of these holders are not queried again.
+	Completed []*HolderBalance
+	// OnHolder is called for each newly fetched holder balance, e.g. to checkpoint progress. Calls
+	// are serialized. Returning an error aborts the snapshot.
+	OnHolder func(*HolderBalance) error
+}
+
+// TakeSnapshot fetches the balances of all holders of the given denomination at the given round.
+//
+// In case round is client.RoundLatest, the snapshot is taken at the latest round and the actual
+// round is recorded in the snapshot.
+func TakeSnapshot(ctx context.Context, rc client.RuntimeClient, round uint64, denomination types.Denomination, opts *SnapshotOptions) (*Snapshot, error) {
+	if opts == nil {
+		opts = &SnapshotOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultSnapshotConcurrency
+	}
+
+	// Make sure that all queries are performed against the same round.
+	if round == client.RoundLatest {
+		blk, err := rc.GetBlock(ctx, client.RoundLatest)
+		if err != nil {
+			return nil, fmt.Errorf("accounts: failed to get latest block: %w", err)
+		}
+		round = blk.Header.Round
+	}
+
+	a := NewV1(rc)
+	info, err := a.DenominationInfo(ctx, round, denomination)
+	if err != nil {
+		return nil, fmt.Errorf("accounts: failed to query denomination info: %w", err)
+	}
+	addresses, err := a.Addresses(ctx, round, denomination)
+	if err != nil {
+		return nil, fmt.Errorf("accounts: failed to query addresses: %w", err)
+	}
+
+	holders := make(map[types.Address]*HolderBalance)
+	for _, hb := range opts.Completed {
+		holders[hb.Address] = hb
+	}
+	var pending []types.Address
+	for _, addr := range addresses {
+		if _, ok := holders[addr]; !ok {
+			pending = append(pending, addr)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var limiter <-chan time.Time
+	if opts.QueryInterval > 0 {
+		ticker := time.NewTicker(opts.QueryInterval)
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	var (
+		wg       sync.WaitGroup
+		l        sync.Mutex
+		firstErr error
+	)
+	addrCh := make(chan types.Address)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for addr := range addrCh {
+				balances, err := a.Balances(ctx, round, addr)
+				if err == nil {
+					hb := &HolderBalance{Address: addr, Balance: balances.Balances[denomination]}
+
+					l.Lock()
+					holders[addr] = hb
+					if opts.OnHolder != nil {
+						err = opts.OnHolder(hb)
+					}
+					l.Unlock()
+				} else {
+					err = fmt.Errorf("accounts: failed to query balances of %s: %w", addr, err)
+				}
+				if err != nil {
+					l.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					l.Unlock()
+					cancel()
+				}
+			}
+		}()
+	}
+
+FeedLoop:
+	for _, addr := range pending {
+		if limiter != nil {
+			select {
+			case <-limiter:
+			case <-ctx.Done():
+				break FeedLoop
+			}
+		}
+		select {
+		case addrCh <- addr:
+		case <-ctx.Done():
+			break FeedLoop
+		}
+	}
+	close(addrCh)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		Round:        round,
+		Denomination: denomination,
+		Decimals:     info.Decimals,
+		Holders:      make([]*HolderBalance, 0, len(addresses)),
+	}
+	for _, addr := range addresses {
+		hb := holders[addr]
+		snapshot.Holders = append(snapshot.Holders, hb)
+		if err = snapshot.TotalSupply.Add(&hb.Balance); err != nil {
+			return nil, fmt.Errorf("accounts: failed to compute total supply: %w", err)
+		}
+	}
+	sort.Slice(snapshot.Holders, func(i, j int) bool {
+		return snapshot.Holders[i].Address.String() < snapshot.Holders[j].Address.String()
+	})
+
+	return snapshot, nil
+}
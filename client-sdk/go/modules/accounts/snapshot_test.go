@@ -0,0 +1,121 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing/mock"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestSnapshot(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	denomination := types.Denomination("TEST")
+	holders := map[types.Address]uint64{
+		sdkTesting.Alice.Address:   100,
+		sdkTesting.Bob.Address:     200,
+		sdkTesting.Charlie.Address: 300,
+		sdkTesting.Dave.Address:    400,
+	}
+
+	rc := mock.NewRuntimeClient(common.NewTestNamespaceFromSeed([]byte("accounts snapshot test"), 0), "test chain context")
+	rc.AddBlock()
+	rc.AddBlock()
+
+	var (
+		l       sync.Mutex
+		queried []types.Address
+		rounds  = make(map[uint64]bool)
+	)
+	rc.SetQueryHandler(methodDenominationInfo, func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		var query DenominationInfoQuery
+		if err := cbor.Unmarshal(args, &query); err != nil {
+			return nil, err
+		}
+		if query.Denomination != denomination {
+			return nil, fmt.Errorf("denomination not found")
+		}
+		return &DenominationInfo{Decimals: 6}, nil
+	})
+	rc.SetQueryHandler(methodAddresses, func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		var addresses Addresses
+		for addr := range holders {
+			addresses = append(addresses, addr)
+		}
+		return addresses, nil
+	})
+	rc.SetQueryHandler(methodBalances, func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		var query BalancesQuery
+		if err := cbor.Unmarshal(args, &query); err != nil {
+			return nil, err
+		}
+		l.Lock()
+		defer l.Unlock()
+		queried = append(queried, query.Address)
+		rounds[round] = true
+		return &AccountBalances{
+			Balances: map[types.Denomination]types.Quantity{
+				denomination:             *quantity.NewFromUint64(holders[query.Address]),
+				types.NativeDenomination: *quantity.NewFromUint64(1),
+			},
+		}, nil
+	})
+
+	_, err := TakeSnapshot(ctx, rc, client.RoundLatest, types.NativeDenomination, nil)
+	require.Error(err, "TakeSnapshot should fail for an unknown denomination")
+
+	// Take a full snapshot at the latest round.
+	var checkpointed []*HolderBalance
+	snapshot, err := TakeSnapshot(ctx, rc, client.RoundLatest, denomination, &SnapshotOptions{
+		Concurrency:   2,
+		QueryInterval: time.Millisecond,
+		OnHolder: func(hb *HolderBalance) error {
+			checkpointed = append(checkpointed, hb)
+			return nil
+		},
+	})
+	require.NoError(err, "TakeSnapshot")
+	require.EqualValues(2, snapshot.Round)
+	require.Equal(map[uint64]bool{2: true}, rounds)
+	require.EqualValues(6, snapshot.Decimals)
+	require.Len(snapshot.Holders, len(holders))
+	require.Len(checkpointed, len(holders))
+	for i, hb := range snapshot.Holders {
+		require.EqualValues(*quantity.NewFromUint64(holders[hb.Address]), hb.Balance)
+		if i > 0 {
+			require.True(snapshot.Holders[i-1].Address.String() < hb.Address.String(), "holders should be sorted")
+		}
+	}
+	require.NoError(snapshot.Reconcile(quantity.NewFromUint64(1_000)))
+	require.Error(snapshot.Reconcile(quantity.NewFromUint64(999)))
+
+	// Resume a snapshot where some holders have already been fetched.
+	queried = nil
+	snapshot, err = TakeSnapshot(ctx, rc, 2, denomination, &SnapshotOptions{
+		Completed: checkpointed[:3],
+	})
+	require.NoError(err, "TakeSnapshot")
+	require.Equal([]types.Address{checkpointed[3].Address}, queried)
+	require.NoError(snapshot.Reconcile(quantity.NewFromUint64(1_000)))
+
+	// Checkpointing errors abort the snapshot.
+	_, err = TakeSnapshot(ctx, rc, 2, denomination, &SnapshotOptions{
+		OnHolder: func(hb *HolderBalance) error {
+			return fmt.Errorf("disk full")
+		},
+	})
+	require.Error(err, "TakeSnapshot should fail when checkpointing fails")
+}
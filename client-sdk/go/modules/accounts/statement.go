@@ -0,0 +1,278 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensusaccounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/core"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// StatementEntryKind is the kind of an account statement entry.
+type StatementEntryKind string
+
+const (
+	// StatementTransferIn is an incoming transfer.
+	StatementTransferIn = StatementEntryKind("transfer_in")
+	// StatementTransferOut is an outgoing transfer.
+	StatementTransferOut = StatementEntryKind("transfer_out")
+	// StatementMint is a mint into the account.
+	StatementMint = StatementEntryKind("mint")
+	// StatementBurn is a burn from the account.
+	StatementBurn = StatementEntryKind("burn")
+	// StatementDeposit is a deposit from the consensus layer.
+	StatementDeposit = StatementEntryKind("deposit")
+	// StatementWithdraw is a withdrawal into the consensus layer.
+	StatementWithdraw = StatementEntryKind("withdraw")
+	// StatementWithdrawRefund is a refund of a failed withdrawal.
+	StatementWithdrawRefund = StatementEntryKind("withdraw_refund")
+	// StatementFee is a transaction fee paid by the account.
+	StatementFee = StatementEntryKind("fee")
+)
+
+// StatementEntry is a single balance change in an account statement.
+type StatementEntry struct {
+	// Round is the round in which the balance changed.
+	Round uint64 `json:"round"`
+	// Kind is the kind of the balance change.
+	Kind StatementEntryKind `json:"kind"`
+	// Counterparty is the other account involved in the balance change (if any).
+	Counterparty *types.Address `json:"counterparty,omitempty"`
+	// TxHash is the hash of the transaction that caused the balance change. It is only set for
+	// fees.
+	TxHash *hash.Hash `json:"tx_hash,omitempty"`
+	// GasUsed is the amount of gas used by the transaction. It is only set for fees in case the
+	// runtime emits gas used events.
+	GasUsed uint64 `json:"gas_used,omitempty"`
+
+	// Amount is the absolute amount of the balance change.
+	Amount types.BaseUnits `json:"amount"`
+	// Change is the signed amount of the balance change.
+	Change *big.Int `json:"change"`
+	// Balance is the running balance of the denomination after the change.
+	Balance *big.Int `json:"balance"`
+}
+
+// Statement is an account statement over a range of rounds.
+type Statement struct {
+	// Address is the account address.
+	Address types.Address `json:"address"`
+	// StartRound is the first round included in the statement.
+	StartRound uint64 `json:"start_round"`
+	// EndRound is the last round included in the statement.
+	EndRound uint64 `json:"end_round"`
+
+	// Opening are the account balances before StartRound.
+	Opening map[types.Denomination]types.Quantity `json:"opening"`
+	// Closing are the account balances after EndRound as computed from the entries.
+	Closing map[types.Denomination]*big.Int `json:"closing"`
+	// Actual are the account balances after EndRound as reported by the accounts module.
+	Actual map[types.Denomination]types.Quantity `json:"actual"`
+
+	// Entries are the balance changes, in order.
+	Entries []*StatementEntry `json:"entries"`
+}
+
+// Mismatches returns the denominations for which the computed closing balance does not match the
+// actual balance, sorted by denomination.
+//
+// Balance changes that do not emit events (e.g. transaction fee disbursements to compute node
+// entities) cause mismatches.
+func (s *Statement) Mismatches() []types.Denomination {
+	denoms := make(map[types.Denomination]bool)
+	for d := range s.Closing {
+		denoms[d] = true
+	}
+	for d := range s.Actual {
+		denoms[d] = true
+	}
+
+	var mismatches []types.Denomination
+	for d := range denoms {
+		closing := s.Closing[d]
+		if closing == nil {
+			closing = new(big.Int)
+		}
+		actual := s.Actual[d]
+		if closing.Cmp(actual.ToBigInt()) != 0 {
+			mismatches = append(mismatches, d)
+		}
+	}
+	sort.Slice(mismatches, func(i, j int) bool { return mismatches[i] < mismatches[j] })
+	return mismatches
+}
+
+// GenerateStatement builds the statement for the given account over the given (inclusive) range
+// of rounds by replaying the accounts and consensus accounts events and the fees of the
+// transactions signed by the account.
+func GenerateStatement(ctx context.Context, rc client.RuntimeClient, address types.Address, startRound, endRound uint64) (*Statement, error) {
+	if startRound > endRound {
+		return nil, fmt.Errorf("accounts: start round %d is after end round %d", startRound, endRound)
+	}
+
+	a := NewV1(rc)
+	st := &Statement{
+		Address:    address,
+		StartRound: startRound,
+		EndRound:   endRound,
+		Opening:    make(map[types.Denomination]types.Quantity),
+		Closing:    make(map[types.Denomination]*big.Int),
+	}
+	if startRound > 0 {
+		opening, err := a.Balances(ctx, startRound-1, address)
+		if err != nil {
+			return nil, fmt.Errorf("accounts: failed to query opening balances: %w", err)
+		}
+		st.Opening = opening.Balances
+	}
+	for d, q := range st.Opening {
+		st.Closing[d] = q.ToBigInt()
+	}
+
+	for round := startRound; round <= endRound; round++ {
+		if err := st.addFees(ctx, rc, round); err != nil {
+			return nil, err
+		}
+		if err := st.addEvents(ctx, rc, round); err != nil {
+			return nil, err
+		}
+	}
+
+	actual, err := a.Balances(ctx, endRound, address)
+	if err != nil {
+		return nil, fmt.Errorf("accounts: failed to query closing balances: %w", err)
+	}
+	st.Actual = actual.Balances
+
+	return st, nil
+}
+
+func (st *Statement) addEntry(entry *StatementEntry, credit bool) {
+	balance, ok := st.Closing[entry.Amount.Denomination]
+	if !ok {
+		balance = new(big.Int)
+		st.Closing[entry.Amount.Denomination] = balance
+	}
+	entry.Change = entry.Amount.Amount.ToBigInt()
+	if !credit {
+		entry.Change.Neg(entry.Change)
+	}
+	balance.Add(balance, entry.Change)
+	entry.Balance = new(big.Int).Set(balance)
+
+	st.Entries = append(st.Entries, entry)
+}
+
+func (st *Statement) addFees(ctx context.Context, rc client.RuntimeClient, round uint64) error {
+	txs, err := rc.GetTransactionsWithResults(ctx, round)
+	if err != nil {
+		return fmt.Errorf("accounts: failed to get transactions for round %d: %w", round, err)
+	}
+	for _, txr := range txs {
+		var tx types.Transaction
+		if err = cbor.Unmarshal(txr.Tx.Body, &tx); err != nil {
+			return fmt.Errorf("accounts: failed to decode transaction in round %d: %w", round, err)
+		}
+		if len(tx.AuthInfo.SignerInfo) == 0 || tx.AuthInfo.Fee.Amount.Amount.IsZero() {
+			continue
+		}
+		// The first signer pays for the fees.
+		payer, err := tx.AuthInfo.SignerInfo[0].AddressSpec.Address()
+		if err != nil || !payer.Equal(st.Address) {
+			continue
+		}
+
+		txHash := txr.Tx.Hash()
+		entry := &StatementEntry{
+			Round:  round,
+			Kind:   StatementFee,
+			TxHash: &txHash,
+			Amount: tx.AuthInfo.Fee.Amount,
+		}
+		for _, ev := range txr.Events {
+			decoded, err := core.DecodeEvent(ev)
+			if err != nil {
+				return fmt.Errorf("accounts: failed to decode core event in round %d: %w", round, err)
+			}
+			for _, dev := range decoded {
+				if gu := dev.(*core.Event).GasUsed; gu != nil {
+					entry.GasUsed = gu.Amount
+				}
+			}
+		}
+		st.addEntry(entry, false)
+	}
+	return nil
+}
+
+func (st *Statement) addEvents(ctx context.Context, rc client.RuntimeClient, round uint64) error {
+	rawEvs, err := rc.GetEventsRaw(ctx, round)
+	if err != nil {
+		return fmt.Errorf("accounts: failed to get events for round %d: %w", round, err)
+	}
+
+	// Collect deposits into the account so that the corresponding mints can be labeled.
+	var deposits []*consensusaccounts.DepositEvent
+	for _, rawEv := range rawEvs {
+		decoded, err := consensusaccounts.DecodeEvent(rawEv)
+		if err != nil {
+			return fmt.Errorf("accounts: failed to decode consensus accounts event in round %d: %w", round, err)
+		}
+		for _, dev := range decoded {
+			if ev := dev.(*consensusaccounts.Event).Deposit; ev != nil && ev.IsSuccess() && ev.To.Equal(st.Address) {
+				deposits = append(deposits, ev)
+			}
+		}
+	}
+	isDeposit := func(amount types.BaseUnits) bool {
+		for i, ev := range deposits {
+			if ev.Amount.Denomination == amount.Denomination && ev.Amount.Amount.Cmp(&amount.Amount) == 0 {
+				deposits = append(deposits[:i], deposits[i+1:]...)
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, rawEv := range rawEvs {
+		decoded, err := DecodeEvent(rawEv)
+		if err != nil {
+			return fmt.Errorf("accounts: failed to decode accounts event in round %d: %w", round, err)
+		}
+		for _, dev := range decoded {
+			ev := dev.(*Event)
+			switch {
+			case ev.Transfer != nil && ev.Transfer.From.Equal(st.Address):
+				to := ev.Transfer.To
+				kind := StatementTransferOut
+				if to.Equal(consensusaccounts.PendingWithdrawalAddress) {
+					kind = StatementWithdraw
+				}
+				st.addEntry(&StatementEntry{Round: round, Kind: kind, Counterparty: &to, Amount: ev.Transfer.Amount}, false)
+			case ev.Transfer != nil && ev.Transfer.To.Equal(st.Address):
+				from := ev.Transfer.From
+				kind := StatementTransferIn
+				if from.Equal(consensusaccounts.PendingWithdrawalAddress) {
+					kind = StatementWithdrawRefund
+				}
+				st.addEntry(&StatementEntry{Round: round, Kind: kind, Counterparty: &from, Amount: ev.Transfer.Amount}, true)
+			case ev.Mint != nil && ev.Mint.Owner.Equal(st.Address):
+				kind := StatementMint
+				if isDeposit(ev.Mint.Amount) {
+					kind = StatementDeposit
+				}
+				st.addEntry(&StatementEntry{Round: round, Kind: kind, Amount: ev.Mint.Amount}, true)
+			case ev.Burn != nil && ev.Burn.Owner.Equal(st.Address):
+				st.addEntry(&StatementEntry{Round: round, Kind: StatementBurn, Amount: ev.Burn.Amount}, false)
+			}
+		}
+	}
+	return nil
+}
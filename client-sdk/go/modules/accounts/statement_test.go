@@ -0,0 +1,125 @@
+package accounts
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/consensusaccounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/core"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing/mock"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func native(amount uint64) types.BaseUnits {
+	return types.NewBaseUnits(*quantity.NewFromUint64(amount), types.NativeDenomination)
+}
+
+func statementTx(signer sdkTesting.TestKey, fee uint64, evs ...*types.Event) *client.TransactionWithResults {
+	tx := types.NewTransaction(&types.Fee{Amount: native(fee)}, "test.Method", nil)
+	tx.AppendAuthSignature(signer.SigSpec, 0)
+	return &client.TransactionWithResults{
+		Tx:     types.UnverifiedTransaction{Body: cbor.Marshal(tx)},
+		Events: evs,
+	}
+}
+
+func statementEvent(module string, code uint32, evs interface{}) *types.Event {
+	return &types.Event{Module: module, Code: code, Value: cbor.Marshal(evs)}
+}
+
+func TestStatement(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	alice := sdkTesting.Alice.Address
+	bob := sdkTesting.Bob.Address
+
+	rc := mock.NewRuntimeClient(common.NewTestNamespaceFromSeed([]byte("accounts statement test"), 0), "test chain context")
+	balances := map[uint64]uint64{0: 1_000, 3: 1_990, 4: 1_000}
+	rc.SetQueryHandler(methodBalances, func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		return &AccountBalances{
+			Balances: map[types.Denomination]types.Quantity{
+				types.NativeDenomination: *quantity.NewFromUint64(balances[round]),
+			},
+		}, nil
+	})
+
+	// Round 1: Alice transfers 100 to Bob and pays a fee of 10.
+	rc.AddBlock(statementTx(sdkTesting.Alice, 10,
+		statementEvent(ModuleName, TransferEventCode, []*TransferEvent{{From: alice, To: bob, Amount: native(100)}}),
+		statementEvent(core.ModuleName, core.GasUsedEventCode, []*core.GasUsedEvent{{Amount: 1_234}}),
+	))
+	// Round 2: Alice deposits 1000 and gets a mint of 100.
+	rc.AddBlock(statementTx(sdkTesting.Alice, 0,
+		statementEvent(ModuleName, MintEventCode, []*MintEvent{
+			{Owner: alice, Amount: native(1_000)},
+			{Owner: alice, Amount: native(100)},
+		}),
+		statementEvent(consensusaccounts.ModuleName, consensusaccounts.DepositEventCode, []*consensusaccounts.DepositEvent{
+			{From: alice, To: alice, Amount: native(1_000)},
+		}),
+	))
+	// Round 3: Bob pays a fee, Alice's withdrawal of 500 is refunded.
+	rc.AddBlock(statementTx(sdkTesting.Bob, 10,
+		statementEvent(ModuleName, TransferEventCode, []*TransferEvent{
+			{From: alice, To: consensusaccounts.PendingWithdrawalAddress, Amount: native(500)},
+			{From: consensusaccounts.PendingWithdrawalAddress, To: alice, Amount: native(500)},
+		}),
+	))
+	// Round 4: Alice withdraws 500 and burns 490.
+	rc.AddBlock(statementTx(sdkTesting.Alice, 0,
+		statementEvent(ModuleName, TransferEventCode, []*TransferEvent{{From: alice, To: consensusaccounts.PendingWithdrawalAddress, Amount: native(500)}}),
+		statementEvent(ModuleName, BurnEventCode, []*BurnEvent{{Owner: alice, Amount: native(490)}}),
+	))
+
+	_, err := GenerateStatement(ctx, rc, alice, 3, 2)
+	require.Error(err, "GenerateStatement should fail for an invalid range")
+
+	st, err := GenerateStatement(ctx, rc, alice, 1, 3)
+	require.NoError(err, "GenerateStatement")
+	require.Empty(st.Mismatches())
+	require.EqualValues(*quantity.NewFromUint64(1_000), st.Opening[types.NativeDenomination])
+	require.EqualValues(big.NewInt(1_990), st.Closing[types.NativeDenomination])
+
+	expected := []struct {
+		round   uint64
+		kind    StatementEntryKind
+		change  int64
+		balance int64
+	}{
+		{1, StatementFee, -10, 990},
+		{1, StatementTransferOut, -100, 890},
+		{2, StatementDeposit, 1_000, 1_890},
+		{2, StatementMint, 100, 1_990},
+		{3, StatementWithdraw, -500, 1_490},
+		{3, StatementWithdrawRefund, 500, 1_990},
+	}
+	require.Len(st.Entries, len(expected))
+	for i, tc := range expected {
+		entry := st.Entries[i]
+		require.Equal(tc.round, entry.Round, "entry %d", i)
+		require.Equal(tc.kind, entry.Kind, "entry %d", i)
+		require.EqualValues(big.NewInt(tc.change), entry.Change, "entry %d", i)
+		require.EqualValues(big.NewInt(tc.balance), entry.Balance, "entry %d", i)
+	}
+	require.NotNil(st.Entries[0].TxHash)
+	require.EqualValues(1_234, st.Entries[0].GasUsed)
+	require.Equal(bob, *st.Entries[1].Counterparty)
+
+	// The burn in round 4 is accounted for, but the actual balance differs.
+	balances[4] = 1_100
+	st, err = GenerateStatement(ctx, rc, alice, 4, 4)
+	require.NoError(err, "GenerateStatement")
+	require.Len(st.Entries, 2)
+	require.EqualValues(big.NewInt(1_000), st.Closing[types.NativeDenomination])
+	require.Equal([]types.Denomination{types.NativeDenomination}, st.Mismatches())
+}
@@ -1,9 +1,72 @@
 package consensus
 
-import "github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// maxParaTimeAmountBits is the maximum size of ParaTime amounts (u128).
+const maxParaTimeAmountBits = 128
+
+// ModuleName is the consensus module name.
+const ModuleName = "consensus"
 
 // Parameters are the parameters for the consensus module.
 type Parameters struct {
 	ConsensusDenomination  types.Denomination `json:"consensus_denomination"`
 	ConsensusScalingFactor uint64             `json:"consensus_scaling_factor"`
 }
+
+// ScalingFactor returns the factor by which consensus layer amounts are multiplied to get the
+// amounts in the ParaTime.
+func (p *Parameters) ScalingFactor() (*quantity.Quantity, error) {
+	if p.ConsensusScalingFactor == 0 {
+		return nil, fmt.Errorf("consensus: invalid zero scaling factor")
+	}
+	return quantity.NewFromUint64(p.ConsensusScalingFactor), nil
+}
+
+// ToParaTimeAmount converts the given consensus layer amount into ParaTime base units of the
+// consensus denomination.
+func (p *Parameters) ToParaTimeAmount(amount types.Quantity) (*types.BaseUnits, error) {
+	scale, err := p.ScalingFactor()
+	if err != nil {
+		return nil, err
+	}
+	q := amount.Clone()
+	if err = q.Mul(scale); err != nil {
+		return nil, fmt.Errorf("consensus: failed to scale amount: %w", err)
+	}
+	if q.ToBigInt().BitLen() > maxParaTimeAmountBits {
+		return nil, fmt.Errorf("consensus: scaled amount %s is too large", q)
+	}
+	bu := types.NewBaseUnits(*q, p.ConsensusDenomination)
+	return &bu, nil
+}
+
+// ToConsensusAmount converts the given ParaTime base units of the consensus denomination into a
+// consensus layer amount.
+//
+// Amounts that are not exactly representable on the consensus layer are rejected.
+func (p *Parameters) ToConsensusAmount(amount types.BaseUnits) (*types.Quantity, error) {
+	if amount.Denomination != p.ConsensusDenomination {
+		return nil, fmt.Errorf("consensus: denomination '%s' is not the consensus denomination", amount.Denomination)
+	}
+	scale, err := p.ScalingFactor()
+	if err != nil {
+		return nil, err
+	}
+	quo, rem := new(big.Int).QuoRem(amount.Amount.ToBigInt(), scale.ToBigInt(), new(big.Int))
+	if rem.Sign() != 0 {
+		return nil, fmt.Errorf("consensus: amount %s is not a multiple of the scaling factor %s", amount.Amount, scale)
+	}
+	var q types.Quantity
+	if err = q.FromBigInt(quo); err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
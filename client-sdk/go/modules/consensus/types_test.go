@@ -0,0 +1,51 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestAmountScaling(t *testing.T) {
+	require := require.New(t)
+
+	params := Parameters{ConsensusScalingFactor: 1_000_000_000}
+	for _, tc := range []struct {
+		consensus uint64
+		paraTime  uint64
+	}{
+		{0, 0},
+		{1, 1_000_000_000},
+		{1_500_000_000, 1_500_000_000_000_000_000},
+	} {
+		bu, err := params.ToParaTimeAmount(*quantity.NewFromUint64(tc.consensus))
+		require.NoError(err, "ToParaTimeAmount")
+		require.Equal(types.NewBaseUnits(*quantity.NewFromUint64(tc.paraTime), types.NativeDenomination), *bu)
+
+		q, err := params.ToConsensusAmount(*bu)
+		require.NoError(err, "ToConsensusAmount")
+		require.Equal(quantity.NewFromUint64(tc.consensus), q)
+	}
+
+	// Lossy amounts should be rejected.
+	_, err := params.ToConsensusAmount(types.NewBaseUnits(*quantity.NewFromUint64(1_000_000_001), types.NativeDenomination))
+	require.Error(err, "ToConsensusAmount should fail for lossy amounts")
+	_, err = params.ToConsensusAmount(types.NewBaseUnits(*quantity.NewFromUint64(1_000_000_000), types.Denomination("FOO")))
+	require.Error(err, "ToConsensusAmount should fail for non-consensus denominations")
+
+	// Amounts that don't fit into ParaTime amounts should be rejected.
+	var large quantity.Quantity
+	err = large.UnmarshalText([]byte("340282366920938463463374607431768211455"))
+	require.NoError(err, "UnmarshalText")
+	_, err = params.ToParaTimeAmount(large)
+	require.Error(err, "ToParaTimeAmount should fail for too large amounts")
+
+	// Scaling factor must be set.
+	params.ConsensusScalingFactor = 0
+	_, err = params.ToParaTimeAmount(*quantity.NewFromUint64(1))
+	require.Error(err, "ToParaTimeAmount should fail for zero scaling factor")
+}
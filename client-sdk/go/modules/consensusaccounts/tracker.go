@@ -0,0 +1,402 @@
+package consensusaccounts
+
+import (
+	"context"
+	"fmt"
+
+	coreErrors "github.com/oasisprotocol/oasis-core/go/common/errors"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// DefaultTrackerTimeoutRounds is the default number of runtime rounds that the tracker waits for
+// the result of an operation before giving up.
+const DefaultTrackerTimeoutRounds = 20
+
+// OperationKind is the kind of a tracked consensus accounts operation.
+type OperationKind uint8
+
+const (
+	// OperationDeposit is a deposit from the consensus layer into the runtime.
+	OperationDeposit OperationKind = iota + 1
+	// OperationWithdraw is a withdrawal from the runtime into the consensus layer.
+	OperationWithdraw
+)
+
+// String returns a string representation of the operation kind.
+func (k OperationKind) String() string {
+	switch k {
+	case OperationDeposit:
+		return "deposit"
+	case OperationWithdraw:
+		return "withdraw"
+	default:
+		return "[unknown operation]"
+	}
+}
+
+// OperationState is the state of a tracked consensus accounts operation.
+type OperationState uint8
+
+const (
+	// OperationPending means that the result of the operation is not yet known.
+	OperationPending OperationState = iota
+	// OperationSucceeded means that the operation has been successfully executed.
+	OperationSucceeded
+	// OperationFailed means that the operation has failed either in the runtime or in the
+	// consensus layer.
+	OperationFailed
+	// OperationTimedOut means that the result of the operation has not been observed in time.
+	OperationTimedOut
+)
+
+// String returns a string representation of the operation state.
+func (s OperationState) String() string {
+	switch s {
+	case OperationPending:
+		return "pending"
+	case OperationSucceeded:
+		return "succeeded"
+	case OperationFailed:
+		return "failed"
+	case OperationTimedOut:
+		return "timed out"
+	default:
+		return "[unknown state]"
+	}
+}
+
+// StakingEventSource is a source of consensus layer staking events, e.g. a staking.Backend.
+type StakingEventSource interface {
+	// GetEvents returns the staking events at the given block height.
+	GetEvents(ctx context.Context, height int64) ([]*staking.Event, error)
+}
+
+// OperationResult is the outcome of a tracked deposit or withdrawal.
+type OperationResult struct {
+	// Kind is the kind of the operation.
+	Kind OperationKind
+	// State is the final state of the operation.
+	State OperationState
+
+	// From is the address of the account that initiated the operation.
+	From types.Address
+	// Nonce is the nonce of the transaction that initiated the operation.
+	Nonce uint64
+
+	// TxRound is the runtime round in which the transaction has been included (if known).
+	TxRound uint64
+	// Round is the runtime round in which the result event has been emitted.
+	Round uint64
+	// Event is the runtime result event. Exactly one of Deposit and Withdraw is set in case the
+	// event has been observed.
+	Event *Event
+
+	// ConsensusError is the error reported by the consensus layer (if any).
+	ConsensusError *ConsensusError
+	// Err is the decoded reason for the failure (if any).
+	Err error
+
+	// ConsensusEvents are the matching consensus layer staking transfer and allowance change
+	// events. They are only populated for successful operations when the tracker has access to a
+	// staking event source.
+	ConsensusEvents []*staking.Event
+}
+
+// Operation is a deposit or withdrawal that is being tracked.
+type Operation struct {
+	cancel   context.CancelFunc
+	resultCh chan *OperationResult
+	errCh    chan error
+}
+
+// Result waits for the final result of the operation.
+func (op *Operation) Result(ctx context.Context) (*OperationResult, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-op.resultCh:
+		return result, nil
+	case err := <-op.errCh:
+		return nil, err
+	}
+}
+
+// Stop stops tracking the operation.
+func (op *Operation) Stop() {
+	op.cancel()
+}
+
+// Tracker follows deposits and withdrawals through the runtime events and the matching consensus
+// layer staking events.
+type Tracker struct {
+	rc            client.RuntimeClient
+	stakingEvents StakingEventSource
+
+	// TimeoutRounds is the number of runtime rounds after the start of tracking in which the
+	// result event must be emitted. After that the operation is considered timed out.
+	TimeoutRounds uint64
+}
+
+// NewTracker creates a new deposit and withdrawal tracker for the given runtime.
+//
+// In case stakingEvents is non-nil, the tracker also looks up the consensus layer staking
+// events caused by successful operations.
+func NewTracker(rc client.RuntimeClient, stakingEvents StakingEventSource) *Tracker {
+	return &Tracker{
+		rc:            rc,
+		stakingEvents: stakingEvents,
+		TimeoutRounds: DefaultTrackerTimeoutRounds,
+	}
+}
+
+// Watch starts tracking the operation of the given kind initiated by the transaction with the
+// given signer address and nonce. It must be called before the transaction is submitted so that
+// no events are missed.
+func (t *Tracker) Watch(ctx context.Context, kind OperationKind, from types.Address, nonce uint64) (*Operation, error) {
+	info, err := t.rc.GetInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("consensusaccounts: failed to get runtime info: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	blkCh, blkSub, err := t.rc.WatchBlocks(ctx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("consensusaccounts: failed to watch blocks: %w", err)
+	}
+
+	op := &Operation{
+		cancel:   cancel,
+		resultCh: make(chan *OperationResult, 1),
+		errCh:    make(chan error, 1),
+	}
+	w := &operationWatcher{
+		tracker:        t,
+		runtimeAddress: staking.NewRuntimeAddress(info.ID),
+		heights:        make(map[uint64]int64),
+		result: OperationResult{
+			Kind:  kind,
+			State: OperationPending,
+			From:  from,
+			Nonce: nonce,
+		},
+	}
+	go func() {
+		defer cancel()
+		defer blkSub.Close()
+
+		result, err := w.run(ctx, blkCh)
+		switch err {
+		case nil:
+			op.resultCh <- result
+		default:
+			op.errCh <- err
+		}
+	}()
+	return op, nil
+}
+
+// Submit submits the given signed deposit or withdrawal transaction and waits for the final
+// result of the operation.
+func (t *Tracker) Submit(ctx context.Context, tb *client.TransactionBuilder) (*OperationResult, error) {
+	tx := tb.GetTransaction()
+	var kind OperationKind
+	switch tx.Call.Method {
+	case methodDeposit:
+		kind = OperationDeposit
+	case methodWithdraw:
+		kind = OperationWithdraw
+	default:
+		return nil, fmt.Errorf("consensusaccounts: cannot track method '%s'", tx.Call.Method)
+	}
+	if len(tx.AuthInfo.SignerInfo) == 0 {
+		return nil, fmt.Errorf("consensusaccounts: transaction has no signers")
+	}
+	si := tx.AuthInfo.SignerInfo[0]
+	from, err := si.AddressSpec.Address()
+	if err != nil {
+		return nil, fmt.Errorf("consensusaccounts: %w", err)
+	}
+
+	op, err := t.Watch(ctx, kind, from, si.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	defer op.Stop()
+
+	meta, err := tb.SubmitTxMeta(ctx, nil)
+	switch {
+	case err != nil && meta != nil:
+		// The transaction failed in the runtime so no consensus message has been emitted.
+		return &OperationResult{
+			Kind:    kind,
+			State:   OperationFailed,
+			From:    from,
+			Nonce:   si.Nonce,
+			TxRound: meta.Round,
+			Err:     err,
+		}, nil
+	case err != nil:
+		return nil, fmt.Errorf("consensusaccounts: failed to submit transaction: %w", err)
+	}
+	if meta.CheckTxError != nil {
+		return nil, fmt.Errorf("consensusaccounts: transaction check failed: module: %s code: %d message: %s",
+			meta.CheckTxError.Module,
+			meta.CheckTxError.Code,
+			meta.CheckTxError.Message,
+		)
+	}
+
+	result, err := op.Result(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.TxRound = meta.Round
+	return result, nil
+}
+
+// operationWatcher processes runtime blocks while waiting for the result of an operation.
+type operationWatcher struct {
+	tracker        *Tracker
+	runtimeAddress staking.Address
+
+	startRound uint64
+	heights    map[uint64]int64
+
+	result OperationResult
+}
+
+func (w *operationWatcher) run(ctx context.Context, blkCh <-chan *roothash.AnnotatedBlock) (*OperationResult, error) {
+	for {
+		var blk *roothash.AnnotatedBlock
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case blk = <-blkCh:
+			if blk == nil {
+				return nil, fmt.Errorf("consensusaccounts: block watcher closed")
+			}
+		}
+
+		round := blk.Block.Header.Round
+		w.heights[round] = blk.Height
+		if len(w.heights) == 1 {
+			w.startRound = round
+		}
+
+		done, err := w.processRound(ctx, round)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			return &w.result, nil
+		}
+		if round-w.startRound >= w.tracker.TimeoutRounds {
+			w.result.State = OperationTimedOut
+			return &w.result, nil
+		}
+	}
+}
+
+func (w *operationWatcher) processRound(ctx context.Context, round uint64) (bool, error) {
+	rawEvs, err := w.tracker.rc.GetEventsRaw(ctx, round)
+	if err != nil {
+		return false, fmt.Errorf("consensusaccounts: failed to get events for round %d: %w", round, err)
+	}
+
+	for _, rawEv := range rawEvs {
+		evs, err := DecodeEvent(rawEv)
+		if err != nil {
+			return false, fmt.Errorf("consensusaccounts: %w", err)
+		}
+		for _, ev := range evs {
+			ce := ev.(*Event)
+			if !w.matches(ce) {
+				continue
+			}
+
+			w.result.Round = round
+			w.result.Event = ce
+			if err = w.complete(ctx, round); err != nil {
+				return false, err
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (w *operationWatcher) matches(ev *Event) bool {
+	switch w.result.Kind {
+	case OperationDeposit:
+		return ev.Deposit != nil && ev.Deposit.From.Equal(w.result.From) && ev.Deposit.Nonce == w.result.Nonce
+	case OperationWithdraw:
+		return ev.Withdraw != nil && ev.Withdraw.From.Equal(w.result.From) && ev.Withdraw.Nonce == w.result.Nonce
+	default:
+		return false
+	}
+}
+
+func (w *operationWatcher) complete(ctx context.Context, round uint64) error {
+	var (
+		consensusErr *ConsensusError
+		from, to     staking.Address
+	)
+	switch {
+	case w.result.Event.Deposit != nil:
+		ev := w.result.Event.Deposit
+		consensusErr = ev.Error
+		from, to = ev.From.ConsensusAddress(), w.runtimeAddress
+	case w.result.Event.Withdraw != nil:
+		ev := w.result.Event.Withdraw
+		consensusErr = ev.Error
+		from, to = w.runtimeAddress, ev.To.ConsensusAddress()
+	}
+
+	if consensusErr != nil {
+		w.result.State = OperationFailed
+		w.result.ConsensusError = consensusErr
+		w.result.Err = coreErrors.FromCode(consensusErr.Module, consensusErr.Code, "")
+		return nil
+	}
+	w.result.State = OperationSucceeded
+
+	if w.tracker.stakingEvents == nil {
+		return nil
+	}
+
+	// Consensus messages emitted in a round are executed when that round is finalized and their
+	// results are processed in the following round. Scan all consensus heights in between.
+	endHeight := w.heights[round]
+	startHeight, ok := w.heights[round-1]
+	if !ok {
+		startHeight = endHeight
+	}
+	for height := startHeight; height <= endHeight; height++ {
+		evs, err := w.tracker.stakingEvents.GetEvents(ctx, height)
+		if err != nil {
+			return fmt.Errorf("consensusaccounts: failed to get staking events at height %d: %w", height, err)
+		}
+		for _, ev := range evs {
+			switch {
+			case ev.Transfer != nil:
+				if !ev.Transfer.From.Equal(from) || !ev.Transfer.To.Equal(to) {
+					continue
+				}
+			case ev.AllowanceChange != nil:
+				// Deposits withdraw from the allowance given to the runtime.
+				if w.result.Kind != OperationDeposit || !ev.AllowanceChange.Owner.Equal(from) || !ev.AllowanceChange.Beneficiary.Equal(to) {
+					continue
+				}
+			default:
+				continue
+			}
+			w.result.ConsensusEvents = append(w.result.ConsensusEvents, ev)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package consensusaccounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing/mock"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+type stakingEvents map[int64][]*staking.Event
+
+func (se stakingEvents) GetEvents(ctx context.Context, height int64) ([]*staking.Event, error) {
+	return se[height], nil
+}
+
+func eventsTx(code uint32, evs interface{}) *client.TransactionWithResults {
+	return &client.TransactionWithResults{
+		Events: []*types.Event{{Module: ModuleName, Code: code, Value: cbor.Marshal(evs)}},
+	}
+}
+
+func TestTracker(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("consensus accounts tracker test"), 0)
+	runtimeAddress := staking.NewRuntimeAddress(runtimeID)
+	alice := sdkTesting.Alice.Address
+	bob := sdkTesting.Bob.Address
+	amount := types.NewBaseUnits(*quantity.NewFromUint64(1_000), types.NativeDenomination)
+
+	rc := mock.NewRuntimeClient(runtimeID, "test chain context")
+	tracker := NewTracker(rc, stakingEvents{
+		1: {
+			{Transfer: &staking.TransferEvent{From: runtimeAddress, To: bob.ConsensusAddress(), Amount: amount.Amount}},
+			{Transfer: &staking.TransferEvent{From: runtimeAddress, To: alice.ConsensusAddress(), Amount: amount.Amount}},
+		},
+		3: {
+			{Transfer: &staking.TransferEvent{From: alice.ConsensusAddress(), To: runtimeAddress, Amount: amount.Amount}},
+			{AllowanceChange: &staking.AllowanceChangeEvent{Owner: alice.ConsensusAddress(), Beneficiary: runtimeAddress, AmountChange: amount.Amount}},
+		},
+	})
+
+	// Successful withdrawal, the result is emitted in the round following the transaction.
+	op, err := tracker.Watch(ctx, OperationWithdraw, alice, 5)
+	require.NoError(err, "Watch")
+	rc.AddBlock()
+	rc.AddBlock(eventsTx(WithdrawEventCode, []*WithdrawEvent{
+		{From: bob, Nonce: 5, To: bob, Amount: amount},
+		{From: alice, Nonce: 5, To: bob, Amount: amount},
+	}))
+	result, err := op.Result(ctx)
+	require.NoError(err, "Result")
+	require.Equal(OperationSucceeded, result.State)
+	require.EqualValues(2, result.Round)
+	require.NotNil(result.Event.Withdraw)
+	require.Equal(bob, result.Event.Withdraw.To)
+	require.Len(result.ConsensusEvents, 1, "only the transfer to the withdrawal recipient should match")
+	require.True(result.ConsensusEvents[0].Transfer.To.Equal(bob.ConsensusAddress()))
+
+	// Successful deposit matches both the transfer and the allowance change.
+	op, err = tracker.Watch(ctx, OperationDeposit, alice, 6)
+	require.NoError(err, "Watch")
+	rc.AddBlock(eventsTx(DepositEventCode, []*DepositEvent{{From: alice, Nonce: 6, To: alice, Amount: amount}}))
+	result, err = op.Result(ctx)
+	require.NoError(err, "Result")
+	require.Equal(OperationSucceeded, result.State)
+	require.Len(result.ConsensusEvents, 2)
+
+	// Failed withdrawal reports the decoded consensus error.
+	op, err = tracker.Watch(ctx, OperationWithdraw, alice, 7)
+	require.NoError(err, "Watch")
+	consensusErr := &ConsensusError{Module: staking.ModuleName, Code: 3}
+	rc.AddBlock(eventsTx(WithdrawEventCode, []*WithdrawEvent{{From: alice, Nonce: 7, To: alice, Amount: amount, Error: consensusErr}}))
+	result, err = op.Result(ctx)
+	require.NoError(err, "Result")
+	require.Equal(OperationFailed, result.State)
+	require.Equal(consensusErr, result.ConsensusError)
+	require.ErrorIs(result.Err, staking.ErrInsufficientBalance)
+	require.Empty(result.ConsensusEvents)
+
+	// Submitting a transaction for which no result is emitted times out.
+	tracker.TimeoutRounds = 0
+	tb := NewV1(rc).Withdraw(&bob, amount).
+		SetFeeGas(1_000_000).
+		AppendAuthSignature(sdkTesting.Alice.SigSpec, 8)
+	err = tb.AppendSign(ctx, sdkTesting.Alice.Signer)
+	require.NoError(err, "AppendSign")
+	result, err = tracker.Submit(ctx, tb)
+	require.NoError(err, "Submit")
+	require.Equal(OperationTimedOut, result.State)
+	require.Equal(alice, result.From)
+	require.EqualValues(8, result.Nonce)
+	require.Nil(result.Event)
+
+	// Transactions failing in the runtime fail immediately.
+	rc.SetSubmitHandler(func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		return &types.CallResult{Failed: &types.FailedCallResult{Module: "accounts", Code: 2}}, nil
+	})
+	tb = NewV1(rc).Deposit(nil, amount).
+		SetFeeGas(1_000_000).
+		AppendAuthSignature(sdkTesting.Alice.SigSpec, 9)
+	err = tb.AppendSign(ctx, sdkTesting.Alice.Signer)
+	require.NoError(err, "AppendSign")
+	result, err = tracker.Submit(ctx, tb)
+	require.NoError(err, "Submit")
+	require.Equal(OperationFailed, result.State)
+	require.Equal(OperationDeposit, result.Kind)
+	require.Error(result.Err)
+}
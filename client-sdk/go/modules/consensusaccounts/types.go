@@ -49,6 +49,9 @@ type ConsensusError struct {
 // ModuleName is the consensus accounts module name.
 const ModuleName = "consensus_accounts"
 
+// PendingWithdrawalAddress is the address of the account holding tokens of pending withdrawals.
+var PendingWithdrawalAddress = types.NewAddressForModule(ModuleName, []byte("pending-withdrawal"))
+
 const (
 	// DepositEventCode is the event code for the deposit event.
 	DepositEventCode = 1
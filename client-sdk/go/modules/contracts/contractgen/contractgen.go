@@ -0,0 +1,416 @@
+// Package contractgen generates typed Go clients for Oasis Wasm contracts from their contract
+// schema.
+//
+// The generated clients use the contracts.V1 module client.
+package contractgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/contracts"
+)
+
+// Config is the code generator configuration.
+type Config struct {
+	// Package is the name of the Go package of the generated code.
+	Package string
+	// Schema is the JSON-encoded contract schema.
+	Schema []byte
+}
+
+type tmplField struct {
+	Name string
+	Type string
+	Tag  string
+}
+
+type tmplStruct struct {
+	Name   string
+	Doc    string
+	Fields []tmplField
+}
+
+type tmplVariant struct {
+	Name    string
+	Variant string
+	Type    string
+}
+
+type tmplMethod struct {
+	Name     string
+	Variant  string
+	Request  string
+	HasArgs  bool
+	Response string
+	RspField string
+}
+
+type tmplEvent struct {
+	Name    string
+	Variant string
+	Type    string
+	Code    uint32
+}
+
+type tmplData struct {
+	Package     string
+	Contract    string
+	Structs     []tmplStruct
+	Requests    []tmplVariant
+	Responses   []tmplVariant
+	Events      []tmplEvent
+	Instantiate *tmplMethod
+	Calls       []tmplMethod
+	Queries     []tmplMethod
+}
+
+// reservedNames are identifiers used by the generated code which cannot be used as method names.
+var reservedNames = map[string]bool{
+	"Client":      true,
+	"NewClient":   true,
+	"InstanceID":  true,
+	"DecodeEvent": true,
+	"Request":     true,
+	"Response":    true,
+	"Event":       true,
+}
+
+// Generate generates a typed Go client for the contract described by the given configuration.
+func Generate(cfg *Config) ([]byte, error) {
+	if !token.IsIdentifier(cfg.Package) {
+		return nil, fmt.Errorf("contractgen: invalid package name '%s'", cfg.Package)
+	}
+
+	s, err := contracts.ParseSchema(cfg.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("contractgen: %w", err)
+	}
+
+	data := tmplData{
+		Package:  cfg.Package,
+		Contract: s.Name,
+	}
+	if data.Contract == "" {
+		data.Contract = "contract"
+	}
+
+	// Make sure generated identifiers are unique.
+	names := make(map[string]bool)
+	declare := func(name string) error {
+		if names[name] || reservedNames[name] || !token.IsIdentifier(name) {
+			return fmt.Errorf("contractgen: conflicting or invalid identifier '%s'", name)
+		}
+		names[name] = true
+		return nil
+	}
+	addStruct := func(name, doc string, st contracts.SchemaStruct) error {
+		if err := declare(name); err != nil {
+			return err
+		}
+		ts := tmplStruct{Name: name, Doc: doc}
+		for _, f := range st {
+			ts.Fields = append(ts.Fields, convertField(&f))
+		}
+		data.Structs = append(data.Structs, ts)
+		return nil
+	}
+
+	for _, name := range sortedKeys(s.Types) {
+		if err = addStruct(toCamelCase(name), fmt.Sprintf("is the %s type.", name), s.Types[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range sortedKeys(s.Responses) {
+		typ := toCamelCase(name) + "Response"
+		if err = addStruct(typ, fmt.Sprintf("is the %s response.", name), s.Responses[name]); err != nil {
+			return nil, err
+		}
+		data.Responses = append(data.Responses, tmplVariant{Name: toCamelCase(name), Variant: name, Type: typ})
+	}
+
+	addMethod := func(kind contracts.RequestKind, name string, m *contracts.SchemaMethod) (*tmplMethod, error) {
+		typ := toCamelCase(name) + "Request"
+		doc := fmt.Sprintf("is the %s %s request.", name, kind)
+		if kind == contracts.RequestInstantiate {
+			doc = "is the instantiation request."
+		}
+		if err := addStruct(typ, doc, m.Fields); err != nil {
+			return nil, err
+		}
+		data.Requests = append(data.Requests, tmplVariant{Name: toCamelCase(name), Variant: name, Type: typ})
+
+		tm := &tmplMethod{
+			Name:    toCamelCase(name),
+			Variant: name,
+			Request: typ,
+			HasArgs: len(m.Fields) > 0,
+		}
+		if m.Response != "" {
+			tm.Response = toCamelCase(m.Response) + "Response"
+			tm.RspField = toCamelCase(m.Response)
+		}
+		if kind != contracts.RequestInstantiate && reservedNames[tm.Name] {
+			return nil, fmt.Errorf("contractgen: method name '%s' is reserved", name)
+		}
+		return tm, nil
+	}
+
+	if s.Instantiate != nil {
+		if data.Instantiate, err = addMethod(contracts.RequestInstantiate, contracts.InstantiateVariant, s.Instantiate); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range sortedKeys(s.Calls) {
+		tm, err := addMethod(contracts.RequestCall, name, s.Calls[name])
+		if err != nil {
+			return nil, err
+		}
+		data.Calls = append(data.Calls, *tm)
+	}
+	for _, name := range sortedKeys(s.Queries) {
+		tm, err := addMethod(contracts.RequestQuery, name, s.Queries[name])
+		if err != nil {
+			return nil, err
+		}
+		data.Queries = append(data.Queries, *tm)
+	}
+
+	for _, name := range sortedKeys(s.Events) {
+		ev := s.Events[name]
+		typ := toCamelCase(name) + "Event"
+		if err = addStruct(typ, fmt.Sprintf("is the %s event (code %d).", name, ev.Code), ev.Fields); err != nil {
+			return nil, err
+		}
+		data.Events = append(data.Events, tmplEvent{Name: toCamelCase(name), Variant: name, Type: typ, Code: ev.Code})
+	}
+	sort.Slice(data.Events, func(i, j int) bool { return data.Events[i].Code < data.Events[j].Code })
+
+	var buf bytes.Buffer
+	if err = clientTemplate.Execute(&buf, &data); err != nil {
+		return nil, fmt.Errorf("contractgen: %w", err)
+	}
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("contractgen: failed to format generated code: %w", err)
+	}
+	return code, nil
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch tm := m.(type) {
+	case map[string]contracts.SchemaStruct:
+		for k := range tm {
+			keys = append(keys, k)
+		}
+	case map[string]*contracts.SchemaMethod:
+		for k := range tm {
+			keys = append(keys, k)
+		}
+	case map[string]*contracts.SchemaEvent:
+		for k := range tm {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// toCamelCase converts a snake_case schema name into an exported Go identifier.
+func toCamelCase(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		switch part {
+		case "":
+		case "id":
+			b.WriteString("ID")
+		default:
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+func convertField(f *contracts.SchemaField) tmplField {
+	typ := goType(f.Type)
+	tag := f.Name
+	if f.Optional {
+		tag += ",omitempty"
+		// Optional values are pointers unless they are already nillable.
+		if !strings.HasPrefix(typ, "[]") && typ != "interface{}" {
+			typ = "*" + typ
+		}
+	}
+	return tmplField{
+		Name: toCamelCase(f.Name),
+		Type: typ,
+		Tag:  fmt.Sprintf("`json:\"%s\"`", tag),
+	}
+}
+
+// goType returns the Go type used for the given schema type.
+func goType(typ string) string {
+	switch typ {
+	case contracts.SchemaTypeBool:
+		return "bool"
+	case contracts.SchemaTypeU8:
+		return "uint8"
+	case contracts.SchemaTypeU16:
+		return "uint16"
+	case contracts.SchemaTypeU32:
+		return "uint32"
+	case contracts.SchemaTypeU64:
+		return "uint64"
+	case contracts.SchemaTypeU128:
+		return "quantity.Quantity"
+	case contracts.SchemaTypeI8:
+		return "int8"
+	case contracts.SchemaTypeI16:
+		return "int16"
+	case contracts.SchemaTypeI32:
+		return "int32"
+	case contracts.SchemaTypeI64:
+		return "int64"
+	case contracts.SchemaTypeString:
+		return "string"
+	case contracts.SchemaTypeBytes:
+		return "[]byte"
+	case contracts.SchemaTypeAddress:
+		return "types.Address"
+	case contracts.SchemaTypeInstanceID:
+		return "contracts.InstanceID"
+	case contracts.SchemaTypeCodeID:
+		return "contracts.CodeID"
+	case contracts.SchemaTypeAny:
+		return "interface{}"
+	}
+	if strings.HasPrefix(typ, "[]") {
+		return "[]" + goType(strings.TrimPrefix(typ, "[]"))
+	}
+	return toCamelCase(typ)
+}
+
+var clientTemplate = template.Must(template.New("client").Parse(`// Code generated by oasis-contract-gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/contracts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = context.Background
+	_ = fmt.Errorf
+	_ = strings.HasPrefix
+	_ = cbor.Marshal
+	_ = quantity.NewQuantity
+	_ = client.RoundLatest
+	_ = types.NativeDenomination
+)
+{{range $s := .Structs}}
+// {{$s.Name}} {{$s.Doc}}
+type {{$s.Name}} struct{{if $s.Fields}} {
+{{range $s.Fields}}	{{.Name}} {{.Type}} {{.Tag}}
+{{end}}}{{else}}{}{{end}}
+{{end}}
+// Request is a request understood by the {{.Contract}} contract.
+type Request struct {
+{{range .Requests}}	{{.Name}} *{{.Type}} ` + "`" + `json:"{{.Variant}},omitempty"` + "`" + `
+{{end}}}
+
+// Response is a response returned by the {{.Contract}} contract.
+type Response struct {
+{{range .Responses}}	{{.Name}} *{{.Type}} ` + "`" + `json:"{{.Variant}},omitempty"` + "`" + `
+{{end}}}
+
+// Event is an event emitted by the {{.Contract}} contract.
+type Event struct {
+{{range .Events}}	{{.Name}} *{{.Type}}
+{{end}}}
+
+// Client is a client for an instance of the {{.Contract}} contract.
+type Client struct {
+	contracts contracts.V1
+	id        contracts.InstanceID
+}
+
+// NewClient creates a new client for the given {{.Contract}} contract instance.
+func NewClient(c contracts.V1, id contracts.InstanceID) *Client {
+	return &Client{contracts: c, id: id}
+}
+
+// InstanceID returns the identifier of the contract instance.
+func (c *Client) InstanceID() contracts.InstanceID {
+	return c.id
+}
+{{with .Instantiate}}
+// Instantiate generates a transaction instantiating the {{$.Contract}} contract from the given code.
+func Instantiate(c contracts.V1, codeID contracts.CodeID, upgradesPolicy contracts.Policy, args *{{.Request}}, tokens []types.BaseUnits) *client.TransactionBuilder {
+	return c.Instantiate(codeID, upgradesPolicy, &Request{ {{.Name}}: args}, tokens)
+}
+{{end}}{{range $m := .Calls}}
+// {{$m.Name}} generates a transaction calling the {{$m.Variant}} method.
+func (c *Client) {{$m.Name}}({{if $m.HasArgs}}args *{{$m.Request}}, {{end}}tokens []types.BaseUnits) *client.TransactionBuilder {
+	return c.contracts.Call(c.id, &Request{ {{$m.Name}}: {{if $m.HasArgs}}args{{else}}&{{$m.Request}}{}{{end}}}, tokens)
+}
+{{end}}{{range $m := .Queries}}
+// {{$m.Name}} queries the {{$m.Variant}} method.
+func (c *Client) {{$m.Name}}(ctx context.Context, round uint64{{if $m.HasArgs}}, args *{{$m.Request}}{{end}}) ({{if $m.Response}}*{{$m.Response}}{{else}}*Response{{end}}, error) {
+	var rsp Response
+	if err := c.contracts.Custom(ctx, round, c.id, &Request{ {{$m.Name}}: {{if $m.HasArgs}}args{{else}}&{{$m.Request}}{}{{end}}}, &rsp); err != nil {
+		return nil, err
+	}{{if $m.Response}}
+	if rsp.{{$m.RspField}} == nil {
+		return nil, fmt.Errorf("{{$.Package}}: unexpected response to {{$m.Variant}} query")
+	}
+	return rsp.{{$m.RspField}}, nil{{else}}
+	return &rsp, nil{{end}}
+}
+{{end}}
+// DecodeEvent decodes the {{.Contract}} events emitted by the contract instance.
+func (c *Client) DecodeEvent(event *types.Event) ([]*Event, error) {
+	if !strings.HasPrefix(event.Module, contracts.ModuleName+".") {
+		return nil, nil
+	}
+	var contractEvents []*contracts.Event
+	if err := cbor.Unmarshal(event.Value, &contractEvents); err != nil {
+		return nil, fmt.Errorf("decode contract event value: %w", err)
+	}
+
+	var events []*Event
+	for _, contractEvent := range contractEvents {
+		if contractEvent.ID != c.id {
+			continue
+		}
+
+		switch event.Code {
+{{range .Events}}		case {{.Code}}:
+			var ev {{.Type}}
+			if err := cbor.Unmarshal(contractEvent.Data, &ev); err != nil {
+				return nil, fmt.Errorf("decode {{$.Contract}} {{.Variant}} event value: %w", err)
+			}
+			events = append(events, &Event{ {{.Name}}: &ev})
+{{end}}		default:
+			return nil, fmt.Errorf("invalid {{$.Contract}} event code: %v", event.Code)
+		}
+	}
+	return events, nil
+}
+`))
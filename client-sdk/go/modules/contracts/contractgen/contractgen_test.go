@@ -0,0 +1,97 @@
+package contractgen
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/contracts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/contracts/contractgen/internal/oas20"
+	handwritten "github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/contracts/oas20"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// customOnlyV1 is a contracts.V1 implementation which answers Custom queries with a fixed result.
+type customOnlyV1 struct {
+	contracts.V1
+
+	data   []byte
+	result interface{}
+}
+
+func (c *customOnlyV1) Custom(ctx context.Context, round uint64, id contracts.InstanceID, data, rsp interface{}) error {
+	c.data = cbor.Marshal(data)
+	return cbor.Unmarshal(cbor.Marshal(c.result), rsp)
+}
+
+func TestGenerate(t *testing.T) {
+	require := require.New(t)
+
+	schema, err := os.ReadFile("../testdata/oas20.schema.json")
+	require.NoError(err, "ReadFile")
+
+	// Generated client must match the checked in client.
+	code, err := Generate(&Config{Package: "oas20", Schema: schema})
+	require.NoError(err, "Generate")
+	expected, err := os.ReadFile("internal/oas20/oas20.go")
+	require.NoError(err, "ReadFile")
+	require.Equal(string(expected), string(code), "generated client should be up to date (run go generate)")
+
+	for _, cfg := range []*Config{
+		{Package: "", Schema: schema},
+		{Package: "oas20", Schema: []byte("{")},
+		{Package: "oas20", Schema: []byte(`{"calls": {"decode_event": {}}}`)},
+		{Package: "oas20", Schema: []byte(`{"types": {"transfer_request": []}, "calls": {"transfer": {}}}`)},
+	} {
+		_, err = Generate(cfg)
+		require.Error(err, "Generate should fail for invalid configuration")
+	}
+}
+
+func TestGeneratedClient(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	mock := &customOnlyV1{V1: contracts.NewV1(nil)}
+	c := oas20.NewClient(mock, 42)
+	amount := *quantity.NewFromUint64(1_000)
+
+	// Generated requests must be encoded in the same way as the hand-written ones.
+	tx := c.Transfer(&oas20.TransferRequest{To: sdkTesting.Bob.Address, Amount: amount}, []types.BaseUnits{})
+	expectedTx := handwritten.NewClient(contracts.NewV1(nil), 42).Transfer(sdkTesting.Bob.Address, amount)
+	require.Equal(expectedTx.GetTransaction().Call.Body, tx.GetTransaction().Call.Body)
+
+	mock.result = &handwritten.Response{Balance: &handwritten.BalanceResponse{Balance: amount}}
+	balance, err := c.Balance(ctx, client.RoundLatest, &oas20.BalanceRequest{Address: sdkTesting.Alice.Address})
+	require.NoError(err, "Balance")
+	require.Equal(amount, balance.Balance)
+	require.Equal(cbor.Marshal(&handwritten.Request{Balance: &handwritten.Balance{Address: sdkTesting.Alice.Address}}), mock.data)
+
+	mock.result = &handwritten.Response{Empty: &handwritten.Empty{}}
+	_, err = c.TokenInformation(ctx, client.RoundLatest)
+	require.Error(err, "TokenInformation should fail on unexpected response")
+	require.Equal(cbor.Marshal(&handwritten.Request{TokenInformation: &handwritten.TokenInformation{}}), mock.data)
+
+	ev := &types.Event{
+		Module: "contracts.1",
+		Code:   2,
+		Value: cbor.Marshal([]*contracts.Event{
+			{ID: 42, Data: cbor.Marshal(&handwritten.TransferredEvent{From: sdkTesting.Alice.Address, To: sdkTesting.Bob.Address, Amount: amount})},
+			{ID: 43, Data: cbor.Marshal(&handwritten.TransferredEvent{})},
+		}),
+	}
+	evs, err := c.DecodeEvent(ev)
+	require.NoError(err, "DecodeEvent")
+	require.Len(evs, 1)
+	require.NotNil(evs[0].Transferred)
+	require.Equal(sdkTesting.Alice.Address, evs[0].Transferred.From)
+	require.Equal(sdkTesting.Bob.Address, evs[0].Transferred.To)
+	require.Equal(amount, evs[0].Transferred.Amount)
+}
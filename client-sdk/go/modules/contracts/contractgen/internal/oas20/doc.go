@@ -0,0 +1,5 @@
+// Package oas20 contains a generated client for the OAS20 token contract used to test the code
+// generator.
+package oas20
+
+//go:generate go run ../../oasis-contract-gen -schema ../../../testdata/oas20.schema.json -pkg oas20 -out oas20.go
@@ -0,0 +1,359 @@
+// Code generated by oasis-contract-gen. DO NOT EDIT.
+
+package oas20
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/contracts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = context.Background
+	_ = fmt.Errorf
+	_ = strings.HasPrefix
+	_ = cbor.Marshal
+	_ = quantity.NewQuantity
+	_ = client.RoundLatest
+	_ = types.NativeDenomination
+)
+
+// InitialBalance is the initial_balance type.
+type InitialBalance struct {
+	Address types.Address     `json:"address"`
+	Amount  quantity.Quantity `json:"amount"`
+}
+
+// MintingInformation is the minting_information type.
+type MintingInformation struct {
+	Minter types.Address      `json:"minter"`
+	Cap    *quantity.Quantity `json:"cap,omitempty"`
+}
+
+// TokenInformation is the token_information type.
+type TokenInformation struct {
+	Name        string              `json:"name"`
+	Symbol      string              `json:"symbol"`
+	Decimals    uint8               `json:"decimals"`
+	TotalSupply quantity.Quantity   `json:"total_supply"`
+	Minting     *MintingInformation `json:"minting,omitempty"`
+}
+
+// AllowanceResponse is the allowance response.
+type AllowanceResponse struct {
+	Allowance quantity.Quantity `json:"allowance"`
+}
+
+// BalanceResponse is the balance response.
+type BalanceResponse struct {
+	Balance quantity.Quantity `json:"balance"`
+}
+
+// EmptyResponse is the empty response.
+type EmptyResponse struct{}
+
+// TokenInformationResponse is the token_information response.
+type TokenInformationResponse struct {
+	TokenInformation TokenInformation `json:"token_information"`
+}
+
+// InstantiateRequest is the instantiation request.
+type InstantiateRequest struct {
+	Name            string              `json:"name"`
+	Symbol          string              `json:"symbol"`
+	Decimals        uint8               `json:"decimals"`
+	InitialBalances []InitialBalance    `json:"initial_balances,omitempty"`
+	Minting         *MintingInformation `json:"minting,omitempty"`
+}
+
+// AllowRequest is the allow call request.
+type AllowRequest struct {
+	Beneficiary  types.Address     `json:"beneficiary"`
+	Negative     bool              `json:"negative"`
+	AmountChange quantity.Quantity `json:"amount_change"`
+}
+
+// BurnRequest is the burn call request.
+type BurnRequest struct {
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// MintRequest is the mint call request.
+type MintRequest struct {
+	To     types.Address     `json:"to"`
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// SendRequest is the send call request.
+type SendRequest struct {
+	To     contracts.InstanceID `json:"to"`
+	Amount quantity.Quantity    `json:"amount"`
+	Data   interface{}          `json:"data"`
+}
+
+// TransferRequest is the transfer call request.
+type TransferRequest struct {
+	To     types.Address     `json:"to"`
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// WithdrawRequest is the withdraw call request.
+type WithdrawRequest struct {
+	From   types.Address     `json:"from"`
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// AllowanceRequest is the allowance query request.
+type AllowanceRequest struct {
+	Allower     types.Address `json:"allower"`
+	Beneficiary types.Address `json:"beneficiary"`
+}
+
+// BalanceRequest is the balance query request.
+type BalanceRequest struct {
+	Address types.Address `json:"address"`
+}
+
+// TokenInformationRequest is the token_information query request.
+type TokenInformationRequest struct{}
+
+// AllowanceChangedEvent is the allowance_changed event (code 5).
+type AllowanceChangedEvent struct {
+	Owner        types.Address     `json:"owner"`
+	Beneficiary  types.Address     `json:"beneficiary"`
+	Allowance    quantity.Quantity `json:"allowance"`
+	Negative     bool              `json:"negative"`
+	AmountChange quantity.Quantity `json:"amount_change"`
+}
+
+// BurnedEvent is the burned event (code 4).
+type BurnedEvent struct {
+	From   types.Address     `json:"from"`
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// InstantiatedEvent is the instantiated event (code 1).
+type InstantiatedEvent struct {
+	TokenInformation TokenInformation `json:"token_information"`
+}
+
+// MintedEvent is the minted event (code 7).
+type MintedEvent struct {
+	To     types.Address     `json:"to"`
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// SentEvent is the sent event (code 3).
+type SentEvent struct {
+	From   types.Address        `json:"from"`
+	To     contracts.InstanceID `json:"to"`
+	Amount quantity.Quantity    `json:"amount"`
+}
+
+// TransferredEvent is the transferred event (code 2).
+type TransferredEvent struct {
+	From   types.Address     `json:"from"`
+	To     types.Address     `json:"to"`
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// WithdrewEvent is the withdrew event (code 6).
+type WithdrewEvent struct {
+	From   types.Address     `json:"from"`
+	To     types.Address     `json:"to"`
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// Request is a request understood by the OAS20 contract.
+type Request struct {
+	Instantiate      *InstantiateRequest      `json:"instantiate,omitempty"`
+	Allow            *AllowRequest            `json:"allow,omitempty"`
+	Burn             *BurnRequest             `json:"burn,omitempty"`
+	Mint             *MintRequest             `json:"mint,omitempty"`
+	Send             *SendRequest             `json:"send,omitempty"`
+	Transfer         *TransferRequest         `json:"transfer,omitempty"`
+	Withdraw         *WithdrawRequest         `json:"withdraw,omitempty"`
+	Allowance        *AllowanceRequest        `json:"allowance,omitempty"`
+	Balance          *BalanceRequest          `json:"balance,omitempty"`
+	TokenInformation *TokenInformationRequest `json:"token_information,omitempty"`
+}
+
+// Response is a response returned by the OAS20 contract.
+type Response struct {
+	Allowance        *AllowanceResponse        `json:"allowance,omitempty"`
+	Balance          *BalanceResponse          `json:"balance,omitempty"`
+	Empty            *EmptyResponse            `json:"empty,omitempty"`
+	TokenInformation *TokenInformationResponse `json:"token_information,omitempty"`
+}
+
+// Event is an event emitted by the OAS20 contract.
+type Event struct {
+	Instantiated     *InstantiatedEvent
+	Transferred      *TransferredEvent
+	Sent             *SentEvent
+	Burned           *BurnedEvent
+	AllowanceChanged *AllowanceChangedEvent
+	Withdrew         *WithdrewEvent
+	Minted           *MintedEvent
+}
+
+// Client is a client for an instance of the OAS20 contract.
+type Client struct {
+	contracts contracts.V1
+	id        contracts.InstanceID
+}
+
+// NewClient creates a new client for the given OAS20 contract instance.
+func NewClient(c contracts.V1, id contracts.InstanceID) *Client {
+	return &Client{contracts: c, id: id}
+}
+
+// InstanceID returns the identifier of the contract instance.
+func (c *Client) InstanceID() contracts.InstanceID {
+	return c.id
+}
+
+// Instantiate generates a transaction instantiating the OAS20 contract from the given code.
+func Instantiate(c contracts.V1, codeID contracts.CodeID, upgradesPolicy contracts.Policy, args *InstantiateRequest, tokens []types.BaseUnits) *client.TransactionBuilder {
+	return c.Instantiate(codeID, upgradesPolicy, &Request{Instantiate: args}, tokens)
+}
+
+// Allow generates a transaction calling the allow method.
+func (c *Client) Allow(args *AllowRequest, tokens []types.BaseUnits) *client.TransactionBuilder {
+	return c.contracts.Call(c.id, &Request{Allow: args}, tokens)
+}
+
+// Burn generates a transaction calling the burn method.
+func (c *Client) Burn(args *BurnRequest, tokens []types.BaseUnits) *client.TransactionBuilder {
+	return c.contracts.Call(c.id, &Request{Burn: args}, tokens)
+}
+
+// Mint generates a transaction calling the mint method.
+func (c *Client) Mint(args *MintRequest, tokens []types.BaseUnits) *client.TransactionBuilder {
+	return c.contracts.Call(c.id, &Request{Mint: args}, tokens)
+}
+
+// Send generates a transaction calling the send method.
+func (c *Client) Send(args *SendRequest, tokens []types.BaseUnits) *client.TransactionBuilder {
+	return c.contracts.Call(c.id, &Request{Send: args}, tokens)
+}
+
+// Transfer generates a transaction calling the transfer method.
+func (c *Client) Transfer(args *TransferRequest, tokens []types.BaseUnits) *client.TransactionBuilder {
+	return c.contracts.Call(c.id, &Request{Transfer: args}, tokens)
+}
+
+// Withdraw generates a transaction calling the withdraw method.
+func (c *Client) Withdraw(args *WithdrawRequest, tokens []types.BaseUnits) *client.TransactionBuilder {
+	return c.contracts.Call(c.id, &Request{Withdraw: args}, tokens)
+}
+
+// Allowance queries the allowance method.
+func (c *Client) Allowance(ctx context.Context, round uint64, args *AllowanceRequest) (*AllowanceResponse, error) {
+	var rsp Response
+	if err := c.contracts.Custom(ctx, round, c.id, &Request{Allowance: args}, &rsp); err != nil {
+		return nil, err
+	}
+	if rsp.Allowance == nil {
+		return nil, fmt.Errorf("oas20: unexpected response to allowance query")
+	}
+	return rsp.Allowance, nil
+}
+
+// Balance queries the balance method.
+func (c *Client) Balance(ctx context.Context, round uint64, args *BalanceRequest) (*BalanceResponse, error) {
+	var rsp Response
+	if err := c.contracts.Custom(ctx, round, c.id, &Request{Balance: args}, &rsp); err != nil {
+		return nil, err
+	}
+	if rsp.Balance == nil {
+		return nil, fmt.Errorf("oas20: unexpected response to balance query")
+	}
+	return rsp.Balance, nil
+}
+
+// TokenInformation queries the token_information method.
+func (c *Client) TokenInformation(ctx context.Context, round uint64) (*TokenInformationResponse, error) {
+	var rsp Response
+	if err := c.contracts.Custom(ctx, round, c.id, &Request{TokenInformation: &TokenInformationRequest{}}, &rsp); err != nil {
+		return nil, err
+	}
+	if rsp.TokenInformation == nil {
+		return nil, fmt.Errorf("oas20: unexpected response to token_information query")
+	}
+	return rsp.TokenInformation, nil
+}
+
+// DecodeEvent decodes the OAS20 events emitted by the contract instance.
+func (c *Client) DecodeEvent(event *types.Event) ([]*Event, error) {
+	if !strings.HasPrefix(event.Module, contracts.ModuleName+".") {
+		return nil, nil
+	}
+	var contractEvents []*contracts.Event
+	if err := cbor.Unmarshal(event.Value, &contractEvents); err != nil {
+		return nil, fmt.Errorf("decode contract event value: %w", err)
+	}
+
+	var events []*Event
+	for _, contractEvent := range contractEvents {
+		if contractEvent.ID != c.id {
+			continue
+		}
+
+		switch event.Code {
+		case 1:
+			var ev InstantiatedEvent
+			if err := cbor.Unmarshal(contractEvent.Data, &ev); err != nil {
+				return nil, fmt.Errorf("decode OAS20 instantiated event value: %w", err)
+			}
+			events = append(events, &Event{Instantiated: &ev})
+		case 2:
+			var ev TransferredEvent
+			if err := cbor.Unmarshal(contractEvent.Data, &ev); err != nil {
+				return nil, fmt.Errorf("decode OAS20 transferred event value: %w", err)
+			}
+			events = append(events, &Event{Transferred: &ev})
+		case 3:
+			var ev SentEvent
+			if err := cbor.Unmarshal(contractEvent.Data, &ev); err != nil {
+				return nil, fmt.Errorf("decode OAS20 sent event value: %w", err)
+			}
+			events = append(events, &Event{Sent: &ev})
+		case 4:
+			var ev BurnedEvent
+			if err := cbor.Unmarshal(contractEvent.Data, &ev); err != nil {
+				return nil, fmt.Errorf("decode OAS20 burned event value: %w", err)
+			}
+			events = append(events, &Event{Burned: &ev})
+		case 5:
+			var ev AllowanceChangedEvent
+			if err := cbor.Unmarshal(contractEvent.Data, &ev); err != nil {
+				return nil, fmt.Errorf("decode OAS20 allowance_changed event value: %w", err)
+			}
+			events = append(events, &Event{AllowanceChanged: &ev})
+		case 6:
+			var ev WithdrewEvent
+			if err := cbor.Unmarshal(contractEvent.Data, &ev); err != nil {
+				return nil, fmt.Errorf("decode OAS20 withdrew event value: %w", err)
+			}
+			events = append(events, &Event{Withdrew: &ev})
+		case 7:
+			var ev MintedEvent
+			if err := cbor.Unmarshal(contractEvent.Data, &ev); err != nil {
+				return nil, fmt.Errorf("decode OAS20 minted event value: %w", err)
+			}
+			events = append(events, &Event{Minted: &ev})
+		default:
+			return nil, fmt.Errorf("invalid OAS20 event code: %v", event.Code)
+		}
+	}
+	return events, nil
+}
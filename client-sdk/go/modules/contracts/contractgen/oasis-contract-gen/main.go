@@ -0,0 +1,54 @@
+// Command oasis-contract-gen generates typed Go clients for Oasis Wasm contracts from their
+// contract schema.
+//
+// Usage:
+//
+//	oasis-contract-gen -schema token.schema.json -pkg token -out token.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/contracts/contractgen"
+)
+
+func main() {
+	var (
+		schemaFile = flag.String("schema", "", "path to the contract schema")
+		pkg        = flag.String("pkg", "", "name of the Go package of the generated code")
+		out        = flag.String("out", "", "output file (default: standard output)")
+	)
+	flag.Parse()
+
+	if err := run(*schemaFile, *pkg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaFile, pkg, out string) error {
+	if schemaFile == "" || pkg == "" {
+		return fmt.Errorf("the -schema and -pkg flags are required")
+	}
+
+	schema, err := os.ReadFile(schemaFile)
+	if err != nil {
+		return fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	code, err := contractgen.Generate(&contractgen.Config{
+		Package: pkg,
+		Schema:  schema,
+	})
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+	return os.WriteFile(out, code, 0o644) // nolint: gosec
+}
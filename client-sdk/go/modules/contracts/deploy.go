@@ -0,0 +1,277 @@
+package contracts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	coreErrors "github.com/oasisprotocol/oasis-core/go/common/errors"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// ErrCodeCodeNotFound is the contracts module error code returned when code does not exist.
+const ErrCodeCodeNotFound = 9
+
+// SignFunc prepares the given transaction for submission, e.g. by setting the fee and appending
+// the caller's auth signature with the correct nonce, and signs it.
+type SignFunc func(ctx context.Context, tb *client.TransactionBuilder) error
+
+// DeployOptions are the options for deploying a contract.
+type DeployOptions struct {
+	// ABI is the ABI that the contract code conforms to.
+	ABI ABI
+	// InstantiatePolicy is the policy on who is allowed to instantiate the uploaded code.
+	InstantiatePolicy Policy
+	// UpgradesPolicy is the policy on who is allowed to upgrade the created instance.
+	UpgradesPolicy Policy
+	// Tokens that should be sent to the contract as part of the instantiate call.
+	Tokens []types.BaseUnits
+
+	// Uploader is the address of the account deploying the contract.
+	Uploader types.Address
+	// Uploads records the code previously uploaded by the uploader. If it contains code with the
+	// same binary, ABI and instantiate policy, the code is reused instead of being uploaded again.
+	// Newly uploaded code is recorded in it. Use a FileCodeStore to reuse code across processes.
+	// It may be nil in which case code is always uploaded.
+	Uploads CodeStore
+}
+
+// CodeStore records the identifiers of code uploaded by an account, indexed by the hash of the
+// uploaded Wasm binary.
+//
+// The contracts module identifies code by the hash of the validated and transformed code which
+// cannot be derived locally from the binary, so uploads need to be recorded by the uploader.
+type CodeStore interface {
+	// Get returns the identifier of the code with the given binary hash uploaded by the given
+	// uploader, if any.
+	Get(uploader types.Address, binaryHash hash.Hash) (CodeID, bool)
+	// Put records the identifier of the code with the given binary hash uploaded by the given
+	// uploader.
+	Put(uploader types.Address, binaryHash hash.Hash, id CodeID) error
+}
+
+type codeStoreKey struct {
+	uploader   types.Address
+	binaryHash hash.Hash
+}
+
+// MemoryCodeStore is an in-memory CodeStore.
+type MemoryCodeStore map[codeStoreKey]CodeID
+
+// NewMemoryCodeStore creates a new empty in-memory code store.
+func NewMemoryCodeStore() MemoryCodeStore {
+	return make(MemoryCodeStore)
+}
+
+// Implements CodeStore.
+func (s MemoryCodeStore) Get(uploader types.Address, binaryHash hash.Hash) (CodeID, bool) {
+	id, ok := s[codeStoreKey{uploader, binaryHash}]
+	return id, ok
+}
+
+// Implements CodeStore.
+func (s MemoryCodeStore) Put(uploader types.Address, binaryHash hash.Hash, id CodeID) error {
+	s[codeStoreKey{uploader, binaryHash}] = id
+	return nil
+}
+
+// FileCodeStore is a CodeStore persisted in a JSON file, so that code uploaded by previous runs
+// can be reused.
+type FileCodeStore struct {
+	l sync.Mutex
+
+	path string
+	// codes maps uploader addresses to hex-encoded binary hashes and code identifiers.
+	codes map[string]map[string]CodeID
+}
+
+// NewFileCodeStore opens the code store persisted in the given file. In case the file does not
+// exist, an empty store is created and the file is written on the first Put.
+func NewFileCodeStore(path string) (*FileCodeStore, error) {
+	s := &FileCodeStore{
+		path:  path,
+		codes: make(map[string]map[string]CodeID),
+	}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err = json.Unmarshal(data, &s.codes); err != nil {
+			return nil, fmt.Errorf("malformed code store '%s': %w", path, err)
+		}
+	case errors.Is(err, os.ErrNotExist):
+	default:
+		return nil, fmt.Errorf("failed to read code store: %w", err)
+	}
+	return s, nil
+}
+
+// Implements CodeStore.
+func (s *FileCodeStore) Get(uploader types.Address, binaryHash hash.Hash) (CodeID, bool) {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	id, ok := s.codes[uploader.String()][binaryHash.String()]
+	return id, ok
+}
+
+// Implements CodeStore.
+func (s *FileCodeStore) Put(uploader types.Address, binaryHash hash.Hash, id CodeID) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	codes := s.codes[uploader.String()]
+	if codes == nil {
+		codes = make(map[string]CodeID)
+		s.codes[uploader.String()] = codes
+	}
+	codes[binaryHash.String()] = id
+
+	data, err := json.MarshalIndent(s.codes, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first so that the store is never left partially written.
+	tmpPath := s.path + ".tmp"
+	if err = os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write code store: %w", err)
+	}
+	if err = os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("failed to write code store: %w", err)
+	}
+	return nil
+}
+
+// Deployment is the result of deploying a contract.
+type Deployment struct {
+	// Code is the information about the code used by the instance.
+	Code *Code
+	// CodeReused is true in case previously uploaded code has been reused.
+	CodeReused bool
+	// Instance is the information about the created instance.
+	Instance *Instance
+	// Round is the round in which the instance has been created.
+	Round uint64
+}
+
+// equalPolicies checks whether the given policies are the same.
+func equalPolicies(a, b Policy) bool {
+	switch {
+	case (a.Nobody == nil) != (b.Nobody == nil), (a.Everyone == nil) != (b.Everyone == nil):
+		return false
+	case a.Address == nil || b.Address == nil:
+		return a.Address == b.Address
+	default:
+		return a.Address.Equal(*b.Address)
+	}
+}
+
+// findUploadedCode looks up previously uploaded code recorded in the code store and makes sure
+// that it still matches the uploader, ABI and instantiate policy. It returns nil in case no such
+// code exists.
+func findUploadedCode(ctx context.Context, c V1, store CodeStore, binaryHash hash.Hash, opts *DeployOptions) (*Code, error) {
+	uploader := opts.Uploader
+	id, ok := store.Get(uploader, binaryHash)
+	if !ok {
+		return nil, nil
+	}
+	code, err := c.Code(ctx, client.RoundLatest, id)
+	if err != nil {
+		if module, errCode := coreErrors.Code(err); module == ModuleName && errCode == ErrCodeCodeNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query code %d: %w", id, err)
+	}
+	if code.ABI != opts.ABI || !code.Uploader.Equal(uploader) || !equalPolicies(code.InstantiatePolicy, opts.InstantiatePolicy) {
+		return nil, nil
+	}
+	return code, nil
+}
+
+// Deploy uploads the given contract code and instantiates it with the given data, encoded using
+// CBOR as defined by the Oasis ABI. Each transaction is prepared and signed by the given sign
+// function and the call waits for it to be included in a block.
+//
+// In case opts.Uploads contains matching code uploaded earlier, the upload step is skipped and
+// the existing code is used instead.
+func Deploy(ctx context.Context, c V1, code []byte, data interface{}, opts *DeployOptions, sign SignFunc) (*Deployment, error) {
+	var (
+		codeInfo   *Code
+		reused     bool
+		err        error
+		binaryHash = hash.NewFromBytes(code)
+	)
+	if opts.Uploads != nil {
+		codeInfo, err = findUploadedCode(ctx, c, opts.Uploads, binaryHash, opts)
+		if err != nil {
+			return nil, fmt.Errorf("contracts: %w", err)
+		}
+		reused = codeInfo != nil
+	}
+
+	if codeInfo == nil {
+		// Code is compressed by V1.Upload.
+		var upload UploadResult
+		round, err := submit(ctx, c.Upload(opts.ABI, opts.InstantiatePolicy, code), sign, &upload)
+		if err != nil {
+			return nil, fmt.Errorf("contracts: failed to upload code: %w", err)
+		}
+		if codeInfo, err = c.Code(ctx, round, upload.ID); err != nil {
+			return nil, fmt.Errorf("contracts: failed to query uploaded code %d: %w", upload.ID, err)
+		}
+		if opts.Uploads != nil {
+			if err = opts.Uploads.Put(opts.Uploader, binaryHash, codeInfo.ID); err != nil {
+				return nil, fmt.Errorf("contracts: failed to record uploaded code %d: %w", codeInfo.ID, err)
+			}
+		}
+	}
+
+	tokens := opts.Tokens
+	if tokens == nil {
+		tokens = []types.BaseUnits{}
+	}
+	var instantiate InstantiateResult
+	round, err := submit(ctx, c.Instantiate(codeInfo.ID, opts.UpgradesPolicy, data, tokens), sign, &instantiate)
+	if err != nil {
+		return nil, fmt.Errorf("contracts: failed to instantiate code %d: %w", codeInfo.ID, err)
+	}
+	instance, err := c.Instance(ctx, round, instantiate.ID)
+	if err != nil {
+		return nil, fmt.Errorf("contracts: failed to query instance %d: %w", instantiate.ID, err)
+	}
+	if instance.CodeID != codeInfo.ID {
+		return nil, fmt.Errorf("contracts: instance %d uses unexpected code (expected: %d got: %d)", instance.ID, codeInfo.ID, instance.CodeID)
+	}
+
+	return &Deployment{
+		Code:       codeInfo,
+		CodeReused: reused,
+		Instance:   instance,
+		Round:      round,
+	}, nil
+}
+
+// submit signs and submits the given transaction, waits for it to be included in a block and
+// returns the round.
+func submit(ctx context.Context, tb *client.TransactionBuilder, sign SignFunc, rsp interface{}) (uint64, error) {
+	if err := sign(ctx, tb); err != nil {
+		return 0, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	meta, err := tb.SubmitTxMeta(ctx, rsp)
+	if err != nil {
+		return 0, err
+	}
+	if meta.CheckTxError != nil {
+		return 0, fmt.Errorf("transaction check failed: module: %s code: %d message: %s",
+			meta.CheckTxError.Module,
+			meta.CheckTxError.Code,
+			meta.CheckTxError.Message,
+		)
+	}
+	return meta.Round, nil
+}
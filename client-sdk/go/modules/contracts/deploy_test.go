@@ -0,0 +1,195 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	coreErrors "github.com/oasisprotocol/oasis-core/go/common/errors"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing/mock"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestDeploy(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var (
+		codes      []*Code
+		instances  []*Instance
+		numUploads int
+	)
+	rc := mock.NewRuntimeClient(common.NewTestNamespaceFromSeed([]byte("contracts deploy test"), 0), "test chain context")
+	rc.SetQueryHandler(methodCode, func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		var q CodeQuery
+		if err := cbor.Unmarshal(args, &q); err != nil {
+			return nil, err
+		}
+		if int(q.ID) >= len(codes) {
+			return nil, coreErrors.FromCode(ModuleName, ErrCodeCodeNotFound, fmt.Sprintf("code %d not found", q.ID))
+		}
+		return codes[q.ID], nil
+	})
+	rc.SetQueryHandler(methodInstance, func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		var q InstanceQuery
+		if err := cbor.Unmarshal(args, &q); err != nil {
+			return nil, err
+		}
+		return instances[q.ID], nil
+	})
+	rc.SetSubmitHandler(func(utx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		var tx types.Transaction
+		if err := cbor.Unmarshal(utx.Body, &tx); err != nil {
+			return nil, err
+		}
+		caller, _ := tx.AuthInfo.SignerInfo[0].AddressSpec.Address()
+		switch tx.Call.Method {
+		case methodUpload:
+			var body Upload
+			if err := cbor.Unmarshal(tx.Call.Body, &body); err != nil {
+				return nil, err
+			}
+			numUploads++
+			codes = append(codes, &Code{
+				ID:                CodeID(len(codes)),
+				Hash:              hash.NewFromBytes(body.Code),
+				ABI:               body.ABI,
+				Uploader:          caller,
+				InstantiatePolicy: body.InstantiatePolicy,
+			})
+			return &types.CallResult{Ok: cbor.Marshal(&UploadResult{ID: CodeID(len(codes) - 1)})}, nil
+		case methodInstantiate:
+			var body Instantiate
+			if err := cbor.Unmarshal(tx.Call.Body, &body); err != nil {
+				return nil, err
+			}
+			if int(body.CodeID) >= len(codes) {
+				return &types.CallResult{Failed: &types.FailedCallResult{Module: ModuleName, Code: ErrCodeCodeNotFound}}, nil
+			}
+			instances = append(instances, &Instance{
+				ID:             InstanceID(len(instances)),
+				CodeID:         body.CodeID,
+				Creator:        caller,
+				UpgradesPolicy: body.UpgradesPolicy,
+			})
+			return &types.CallResult{Ok: cbor.Marshal(&InstantiateResult{ID: InstanceID(len(instances) - 1)})}, nil
+		default:
+			return nil, fmt.Errorf("unexpected method: %s", tx.Call.Method)
+		}
+	})
+
+	var nonce uint64
+	sign := func(signer sdkTesting.TestKey) SignFunc {
+		return func(ctx context.Context, tb *client.TransactionBuilder) error {
+			tb.SetFeeGas(1_000_000).AppendAuthSignature(signer.SigSpec, nonce)
+			nonce++
+			return tb.AppendSign(ctx, signer.Signer)
+		}
+	}
+
+	code := []byte("\x00asm contract code")
+	uploads := NewMemoryCodeStore()
+	opts := &DeployOptions{
+		ABI:               ABIOasisV1,
+		InstantiatePolicy: Policy{Everyone: &struct{}{}},
+		UpgradesPolicy:    Policy{Nobody: &struct{}{}},
+		Uploader:          sdkTesting.Alice.Address,
+		Uploads:           uploads,
+	}
+	dep, err := Deploy(ctx, NewV1(rc), code, map[string]interface{}{"instantiate": struct{}{}}, opts, sign(sdkTesting.Alice))
+	require.NoError(err, "Deploy")
+	require.False(dep.CodeReused)
+	require.EqualValues(0, dep.Code.ID)
+	require.Equal(sdkTesting.Alice.Address, dep.Code.Uploader)
+	require.EqualValues(0, dep.Instance.ID)
+	require.EqualValues(0, dep.Instance.CodeID)
+	require.EqualValues(2, dep.Round)
+	require.Equal(1, numUploads)
+	id, ok := uploads.Get(sdkTesting.Alice.Address, hash.NewFromBytes(code))
+	require.True(ok, "uploaded code should be recorded")
+	require.EqualValues(0, id)
+
+	// Deploying again should reuse the recorded code.
+	dep, err = Deploy(ctx, NewV1(rc), code, nil, opts, sign(sdkTesting.Alice))
+	require.NoError(err, "Deploy with existing code")
+	require.True(dep.CodeReused)
+	require.EqualValues(0, dep.Code.ID)
+	require.EqualValues(1, dep.Instance.ID)
+	require.Equal(1, numUploads)
+
+	// Different code should not be reused.
+	dep, err = Deploy(ctx, NewV1(rc), []byte("\x00asm other contract code"), nil, opts, sign(sdkTesting.Alice))
+	require.NoError(err, "Deploy with different code")
+	require.False(dep.CodeReused)
+	require.EqualValues(1, dep.Code.ID)
+	require.Equal(2, numUploads)
+
+	// Code recorded for someone else should not be reused.
+	opts.Uploader = sdkTesting.Bob.Address
+	require.NoError(uploads.Put(sdkTesting.Bob.Address, hash.NewFromBytes(code), 0))
+	dep, err = Deploy(ctx, NewV1(rc), code, nil, opts, sign(sdkTesting.Bob))
+	require.NoError(err, "Deploy by another uploader")
+	require.False(dep.CodeReused)
+	require.EqualValues(2, dep.Code.ID)
+	require.Equal(sdkTesting.Bob.Address, dep.Code.Uploader)
+	require.Equal(3, numUploads)
+
+	// Recorded code that no longer exists should be uploaded again.
+	require.NoError(uploads.Put(sdkTesting.Bob.Address, hash.NewFromBytes(code), 100))
+	dep, err = Deploy(ctx, NewV1(rc), code, nil, opts, sign(sdkTesting.Bob))
+	require.NoError(err, "Deploy with missing recorded code")
+	require.False(dep.CodeReused)
+	require.Equal(4, numUploads)
+
+	// Code with a different instantiate policy should not be reused.
+	opts.Uploader = sdkTesting.Alice.Address
+	opts.InstantiatePolicy = Policy{Address: &sdkTesting.Alice.Address}
+	dep, err = Deploy(ctx, NewV1(rc), code, nil, opts, sign(sdkTesting.Alice))
+	require.NoError(err, "Deploy with a different instantiate policy")
+	require.False(dep.CodeReused)
+	require.Equal(5, numUploads)
+	dep, err = Deploy(ctx, NewV1(rc), code, nil, opts, sign(sdkTesting.Alice))
+	require.NoError(err, "Deploy with the same instantiate policy")
+	require.True(dep.CodeReused)
+	require.Equal(5, numUploads)
+
+	// Uploads recorded in a file code store should be reused by later processes.
+	fn := filepath.Join(t.TempDir(), "uploads.json")
+	opts.Uploads, err = NewFileCodeStore(fn)
+	require.NoError(err, "NewFileCodeStore")
+	dep, err = Deploy(ctx, NewV1(rc), code, nil, opts, sign(sdkTesting.Alice))
+	require.NoError(err, "Deploy with file code store")
+	require.False(dep.CodeReused)
+	require.Equal(6, numUploads)
+	opts.Uploads, err = NewFileCodeStore(fn)
+	require.NoError(err, "NewFileCodeStore")
+	dep, err = Deploy(ctx, NewV1(rc), code, nil, opts, sign(sdkTesting.Alice))
+	require.NoError(err, "Deploy with reopened file code store")
+	require.True(dep.CodeReused)
+	require.Equal(6, numUploads)
+	require.NoError(os.WriteFile(fn, []byte("not json"), 0o600))
+	_, err = NewFileCodeStore(fn)
+	require.Error(err, "NewFileCodeStore should fail for malformed files")
+
+	// Without a code store, code is always uploaded.
+	opts.Uploads = nil
+	dep, err = Deploy(ctx, NewV1(rc), code, nil, opts, sign(sdkTesting.Bob))
+	require.NoError(err, "Deploy without code store")
+	require.False(dep.CodeReused)
+	require.Equal(7, numUploads)
+
+	_, err = Deploy(ctx, NewV1(rc), code, nil, opts, func(ctx context.Context, tb *client.TransactionBuilder) error {
+		return fmt.Errorf("signing failed")
+	})
+	require.Error(err, "Deploy should fail when signing fails")
+}
@@ -0,0 +1,140 @@
+package oas20
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/contracts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// Client is a client for an OAS20 token contract instance.
+type Client struct {
+	contracts contracts.V1
+	id        contracts.InstanceID
+}
+
+// NewClient creates a new OAS20 client for the given contract instance.
+func NewClient(c contracts.V1, id contracts.InstanceID) *Client {
+	return &Client{contracts: c, id: id}
+}
+
+// InstanceID returns the identifier of the token contract instance.
+func (c *Client) InstanceID() contracts.InstanceID {
+	return c.id
+}
+
+func (c *Client) call(req *Request) *client.TransactionBuilder {
+	return c.contracts.Call(c.id, req, []types.BaseUnits{})
+}
+
+func (c *Client) query(ctx context.Context, round uint64, req *Request) (*Response, error) {
+	var rsp Response
+	if err := c.contracts.Custom(ctx, round, c.id, req, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+// Transfer generates a transaction transferring the given amount of tokens to the given address.
+func (c *Client) Transfer(to types.Address, amount quantity.Quantity) *client.TransactionBuilder {
+	return c.call(&Request{Transfer: &Transfer{To: to, Amount: amount}})
+}
+
+// Send generates a transaction sending the given amount of tokens to the given contract instance
+// which is notified with the given data.
+func (c *Client) Send(to contracts.InstanceID, amount quantity.Quantity, data interface{}) *client.TransactionBuilder {
+	return c.call(&Request{Send: &Send{To: to, Amount: amount, Data: data}})
+}
+
+// Burn generates a transaction burning the given amount of the caller's tokens.
+func (c *Client) Burn(amount quantity.Quantity) *client.TransactionBuilder {
+	return c.call(&Request{Burn: &Burn{Amount: amount}})
+}
+
+// Mint generates a transaction minting the given amount of tokens to the given address.
+//
+// Only the minter configured for the token is allowed to mint.
+func (c *Client) Mint(to types.Address, amount quantity.Quantity) *client.TransactionBuilder {
+	return c.call(&Request{Mint: &Mint{To: to, Amount: amount}})
+}
+
+// Allow generates a transaction changing the beneficiary's allowance on the caller's tokens by
+// the given amount. The allowance is decreased in case negative is set.
+func (c *Client) Allow(beneficiary types.Address, negative bool, amountChange quantity.Quantity) *client.TransactionBuilder {
+	return c.call(&Request{Allow: &Allow{Beneficiary: beneficiary, Negative: negative, AmountChange: amountChange}})
+}
+
+// Withdraw generates a transaction withdrawing the given amount of tokens from an account that
+// has given the caller an allowance.
+func (c *Client) Withdraw(from types.Address, amount quantity.Quantity) *client.TransactionBuilder {
+	return c.call(&Request{Withdraw: &Withdraw{From: from, Amount: amount}})
+}
+
+// TokenInformation queries the token information.
+func (c *Client) TokenInformation(ctx context.Context, round uint64) (*TokenInformationResponse, error) {
+	rsp, err := c.query(ctx, round, &Request{TokenInformation: &TokenInformation{}})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.TokenInformation == nil {
+		return nil, fmt.Errorf("oas20: unexpected response to token information query")
+	}
+	return rsp.TokenInformation, nil
+}
+
+// Balance queries the token balance of the given address.
+func (c *Client) Balance(ctx context.Context, round uint64, address types.Address) (*quantity.Quantity, error) {
+	rsp, err := c.query(ctx, round, &Request{Balance: &Balance{Address: address}})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.Balance == nil {
+		return nil, fmt.Errorf("oas20: unexpected response to balance query")
+	}
+	return &rsp.Balance.Balance, nil
+}
+
+// Allowance queries the amount of the allower's tokens that the beneficiary is allowed to
+// withdraw.
+func (c *Client) Allowance(ctx context.Context, round uint64, allower, beneficiary types.Address) (*quantity.Quantity, error) {
+	rsp, err := c.query(ctx, round, &Request{Allowance: &Allowance{Allower: allower, Beneficiary: beneficiary}})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.Allowance == nil {
+		return nil, fmt.Errorf("oas20: unexpected response to allowance query")
+	}
+	return &rsp.Allowance.Allowance, nil
+}
+
+// FormatAmount formats the given amount of base units using the token's decimals and symbol.
+func (t *TokenInformationResponse) FormatAmount(amount quantity.Quantity) string {
+	return fmt.Sprintf("%s %s", prettyprint.QuantityFrac(amount, t.Decimals), t.Symbol)
+}
+
+// ParseAmount parses the given decimal token amount (e.g. "1.5") into base units using the
+// token's decimals.
+func (t *TokenInformationResponse) ParseAmount(amount string) (*quantity.Quantity, error) {
+	v, err := decimal.NewFromString(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Multiply to get the number of base units.
+	baseUnits := v.Mul(decimal.New(1, int32(t.Decimals)))
+	if !baseUnits.Equal(baseUnits.Truncate(0)) {
+		return nil, fmt.Errorf("amount '%s' has more than %d decimals", amount, t.Decimals)
+	}
+	var q quantity.Quantity
+	if err := q.FromBigInt(baseUnits.BigInt()); err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
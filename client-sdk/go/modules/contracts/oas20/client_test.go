@@ -0,0 +1,136 @@
+package oas20
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/contracts"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+)
+
+// customOnlyV1 is a contracts V1 implementation that answers custom queries using a handler.
+type customOnlyV1 struct {
+	contracts.V1
+
+	handler func(id contracts.InstanceID, req *Request) (*Response, error)
+}
+
+func (c *customOnlyV1) Custom(ctx context.Context, round uint64, id contracts.InstanceID, data, rsp interface{}) error {
+	var req Request
+	if err := cbor.Unmarshal(cbor.Marshal(data), &req); err != nil {
+		return err
+	}
+	r, err := c.handler(id, &req)
+	if err != nil {
+		return err
+	}
+	return cbor.Unmarshal(cbor.Marshal(r), rsp)
+}
+
+func TestClientTransactions(t *testing.T) {
+	require := require.New(t)
+
+	c := NewClient(contracts.NewV1(nil), 42)
+	amount := *quantity.NewFromUint64(1_000)
+	for _, tc := range []struct {
+		tb       *client.TransactionBuilder
+		expected Request
+	}{
+		{c.Transfer(sdkTesting.Bob.Address, amount), Request{Transfer: &Transfer{To: sdkTesting.Bob.Address, Amount: amount}}},
+		{c.Send(7, amount, "hello"), Request{Send: &Send{To: 7, Amount: amount, Data: "hello"}}},
+		{c.Burn(amount), Request{Burn: &Burn{Amount: amount}}},
+		{c.Mint(sdkTesting.Bob.Address, amount), Request{Mint: &Mint{To: sdkTesting.Bob.Address, Amount: amount}}},
+		{c.Allow(sdkTesting.Bob.Address, true, amount), Request{Allow: &Allow{Beneficiary: sdkTesting.Bob.Address, Negative: true, AmountChange: amount}}},
+		{c.Withdraw(sdkTesting.Bob.Address, amount), Request{Withdraw: &Withdraw{From: sdkTesting.Bob.Address, Amount: amount}}},
+	} {
+		tx := tc.tb.GetTransaction()
+		require.Equal("contracts.Call", string(tx.Call.Method))
+
+		var body contracts.Call
+		err := cbor.Unmarshal(tx.Call.Body, &body)
+		require.NoError(err, "Unmarshal call body")
+		require.EqualValues(42, body.ID)
+		require.Equal(cbor.Marshal(tc.expected), body.Data)
+	}
+}
+
+func TestClientQueries(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	info := TokenInformationResponse{
+		Name:        "Test token",
+		Symbol:      "TEST",
+		Decimals:    6,
+		TotalSupply: *quantity.NewFromUint64(1_000_000),
+	}
+	ct := &customOnlyV1{
+		handler: func(id contracts.InstanceID, req *Request) (*Response, error) {
+			if id != 42 {
+				return nil, fmt.Errorf("unknown instance: %d", id)
+			}
+			switch {
+			case req.TokenInformation != nil:
+				return &Response{TokenInformation: &info}, nil
+			case req.Balance != nil && req.Balance.Address == sdkTesting.Alice.Address:
+				return &Response{Balance: &BalanceResponse{Balance: *quantity.NewFromUint64(500)}}, nil
+			case req.Allowance != nil && req.Allowance.Allower == sdkTesting.Alice.Address && req.Allowance.Beneficiary == sdkTesting.Bob.Address:
+				return &Response{Allowance: &AllowanceResponse{Allowance: *quantity.NewFromUint64(10)}}, nil
+			default:
+				return &Response{Empty: &Empty{}}, nil
+			}
+		},
+	}
+
+	c := NewClient(ct, 42)
+	ti, err := c.TokenInformation(ctx, client.RoundLatest)
+	require.NoError(err, "TokenInformation")
+	require.True(info.Equal(ti), "token information should match")
+
+	balance, err := c.Balance(ctx, client.RoundLatest, sdkTesting.Alice.Address)
+	require.NoError(err, "Balance")
+	require.EqualValues(500, balance.ToBigInt().Uint64())
+
+	allowance, err := c.Allowance(ctx, client.RoundLatest, sdkTesting.Alice.Address, sdkTesting.Bob.Address)
+	require.NoError(err, "Allowance")
+	require.EqualValues(10, allowance.ToBigInt().Uint64())
+
+	_, err = c.Balance(ctx, client.RoundLatest, sdkTesting.Bob.Address)
+	require.Error(err, "Balance should fail on unexpected response")
+
+	_, err = NewClient(ct, 43).TokenInformation(ctx, client.RoundLatest)
+	require.Error(err, "TokenInformation should fail for unknown instance")
+}
+
+func TestAmountFormatting(t *testing.T) {
+	require := require.New(t)
+
+	info := TokenInformationResponse{Symbol: "TEST", Decimals: 6}
+	for _, tc := range []struct {
+		amount    string
+		baseUnits uint64
+		formatted string
+	}{
+		{"0", 0, "0.0 TEST"},
+		{"1", 1_000_000, "1.0 TEST"},
+		{"1.5", 1_500_000, "1.5 TEST"},
+		{"0.000001", 1, "0.000001 TEST"},
+	} {
+		q, err := info.ParseAmount(tc.amount)
+		require.NoError(err, tc.amount)
+		require.EqualValues(tc.baseUnits, q.ToBigInt().Uint64(), tc.amount)
+		require.Equal(tc.formatted, info.FormatAmount(*q), tc.amount)
+	}
+
+	for _, amount := range []string{"", "abc", "-1", "0.0000001"} {
+		_, err := info.ParseAmount(amount)
+		require.Error(err, amount)
+	}
+}
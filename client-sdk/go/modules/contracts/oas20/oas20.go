@@ -12,8 +12,6 @@ import (
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 )
 
-// XXX: create a more highlevel helper wrapping Contracts client.
-
 // InitialBalance is the OAS20 contract initial balance information.
 type InitialBalance struct {
 	Address types.Address     `json:"address"`
@@ -53,11 +51,41 @@ type Send struct {
 	Data   interface{}          `json:"data"`
 }
 
+// Burn is the OAS20 contract's burn request.
+type Burn struct {
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// Mint is the OAS20 contract's mint request.
+type Mint struct {
+	To     types.Address     `json:"to"`
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// Allow is the OAS20 contract's allow request.
+type Allow struct {
+	Beneficiary  types.Address     `json:"beneficiary"`
+	Negative     bool              `json:"negative"`
+	AmountChange quantity.Quantity `json:"amount_change"`
+}
+
+// Withdraw is the OAS20 contract's withdraw request.
+type Withdraw struct {
+	From   types.Address     `json:"from"`
+	Amount quantity.Quantity `json:"amount"`
+}
+
 // Balance is the OAS20 contract's balance query request.
 type Balance struct {
 	Address types.Address `json:"address"`
 }
 
+// Allowance is the OAS20 contract's allowance query request.
+type Allowance struct {
+	Allower     types.Address `json:"allower"`
+	Beneficiary types.Address `json:"beneficiary"`
+}
+
 // TokenInformation is the OAS20 contract's token information request.
 type TokenInformation struct{}
 
@@ -66,8 +94,13 @@ type Request struct {
 	Instantiate      *Instantiate      `json:"instantiate,omitempty"`
 	Transfer         *Transfer         `json:"transfer,omitempty"`
 	Send             *Send             `json:"send,omitempty"`
+	Burn             *Burn             `json:"burn,omitempty"`
+	Mint             *Mint             `json:"mint,omitempty"`
+	Allow            *Allow            `json:"allow,omitempty"`
+	Withdraw         *Withdraw         `json:"withdraw,omitempty"`
 	TokenInformation *TokenInformation `json:"token_information,omitempty"`
 	Balance          *Balance          `json:"balance,omitempty"`
+	Allowance        *Allowance        `json:"allowance,omitempty"`
 }
 
 // TokenInformationResponse is the token information response.
@@ -123,6 +156,7 @@ type Empty struct{}
 type Response struct {
 	TokenInformation *TokenInformationResponse `json:"token_information,omitempty"`
 	Balance          *BalanceResponse          `json:"balance,omitempty"`
+	Allowance        *AllowanceResponse        `json:"allowance,omitempty"`
 	Empty            *Empty                    `json:"empty,omitempty"`
 }
 
@@ -131,6 +165,11 @@ type BalanceResponse struct {
 	Balance quantity.Quantity `json:"balance"`
 }
 
+// AllowanceResponse is the OAS20 allowance response.
+type AllowanceResponse struct {
+	Allowance quantity.Quantity `json:"allowance"`
+}
+
 type eventDecoder struct {
 	codeID     contracts.CodeID
 	instanceID contracts.InstanceID
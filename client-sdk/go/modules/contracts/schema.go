@@ -0,0 +1,491 @@
+package contracts
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// Primitive schema types.
+const (
+	SchemaTypeBool       = "bool"
+	SchemaTypeU8         = "u8"
+	SchemaTypeU16        = "u16"
+	SchemaTypeU32        = "u32"
+	SchemaTypeU64        = "u64"
+	SchemaTypeU128       = "u128"
+	SchemaTypeI8         = "i8"
+	SchemaTypeI16        = "i16"
+	SchemaTypeI32        = "i32"
+	SchemaTypeI64        = "i64"
+	SchemaTypeString     = "string"
+	SchemaTypeBytes      = "bytes"
+	SchemaTypeAddress    = "address"
+	SchemaTypeInstanceID = "instance_id"
+	SchemaTypeCodeID     = "code_id"
+	SchemaTypeAny        = "any"
+
+	// schemaListPrefix is the prefix of list types (e.g. "[]u64").
+	schemaListPrefix = "[]"
+)
+
+var schemaIntegerBits = map[string]struct {
+	bits   uint
+	signed bool
+}{
+	SchemaTypeU8:         {8, false},
+	SchemaTypeU16:        {16, false},
+	SchemaTypeU32:        {32, false},
+	SchemaTypeU64:        {64, false},
+	SchemaTypeU128:       {128, false},
+	SchemaTypeI8:         {8, true},
+	SchemaTypeI16:        {16, true},
+	SchemaTypeI32:        {32, true},
+	SchemaTypeI64:        {64, true},
+	SchemaTypeInstanceID: {64, false},
+	SchemaTypeCodeID:     {64, false},
+}
+
+// RequestKind is the kind of a contract request.
+type RequestKind uint8
+
+const (
+	// RequestInstantiate is the kind of requests passed on contract instantiation.
+	RequestInstantiate = RequestKind(iota)
+	// RequestCall is the kind of requests passed in contract calls.
+	RequestCall
+	// RequestQuery is the kind of requests passed in contract queries.
+	RequestQuery
+)
+
+// String returns a string representation of a request kind.
+func (k RequestKind) String() string {
+	switch k {
+	case RequestInstantiate:
+		return "instantiate"
+	case RequestCall:
+		return "call"
+	case RequestQuery:
+		return "query"
+	default:
+		return "[unknown]"
+	}
+}
+
+// SchemaField is a field of a schema structure.
+type SchemaField struct {
+	// Name is the name of the field as encoded.
+	Name string `json:"name"`
+	// Type is the type of the field. It is either a primitive type, a list type of the form
+	// []<type> or the name of a type defined in the schema.
+	Type string `json:"type"`
+	// Optional specifies whether the field may be omitted.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// SchemaStruct is a schema structure.
+type SchemaStruct []SchemaField
+
+// SchemaMethod is a contract method (a request variant).
+type SchemaMethod struct {
+	// Fields are the arguments of the method.
+	Fields SchemaStruct `json:"fields,omitempty"`
+	// Response is the name of the response variant returned by the method.
+	Response string `json:"response,omitempty"`
+}
+
+// SchemaEvent is a contract event.
+type SchemaEvent struct {
+	// Code is the event code.
+	Code uint32 `json:"code"`
+	// Fields are the event fields.
+	Fields SchemaStruct `json:"fields,omitempty"`
+}
+
+// Schema describes the interface of a contract using the Oasis ABI.
+//
+// Requests and responses are encoded as maps with a single key naming the variant (e.g. the
+// method) and a value containing the fields of the given variant.
+type Schema struct {
+	// Name is the name of the contract.
+	Name string `json:"name"`
+	// Types are the named structure types used by the contract.
+	Types map[string]SchemaStruct `json:"types,omitempty"`
+	// Instantiate is the instantiation request which uses the "instantiate" variant.
+	Instantiate *SchemaMethod `json:"instantiate,omitempty"`
+	// Calls are the call request variants.
+	Calls map[string]*SchemaMethod `json:"calls,omitempty"`
+	// Queries are the query request variants.
+	Queries map[string]*SchemaMethod `json:"queries,omitempty"`
+	// Responses are the response variants.
+	Responses map[string]SchemaStruct `json:"responses,omitempty"`
+	// Events are the events emitted by the contract.
+	Events map[string]*SchemaEvent `json:"events,omitempty"`
+}
+
+// InstantiateVariant is the name of the request variant used on contract instantiation.
+const InstantiateVariant = "instantiate"
+
+// ParseSchema parses and validates a JSON-encoded contract schema.
+func ParseSchema(data []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("malformed contract schema: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate validates the schema.
+func (s *Schema) Validate() error {
+	for name, st := range s.Types {
+		if isSchemaPrimitive(name) || strings.HasPrefix(name, schemaListPrefix) {
+			return fmt.Errorf("contract schema: type name '%s' is reserved", name)
+		}
+		if err := s.validateStruct(st); err != nil {
+			return fmt.Errorf("contract schema: type '%s': %w", name, err)
+		}
+	}
+	for name, st := range s.Responses {
+		if err := s.validateStruct(st); err != nil {
+			return fmt.Errorf("contract schema: response '%s': %w", name, err)
+		}
+	}
+
+	variants := make(map[string]bool)
+	validateMethod := func(name string, m *SchemaMethod) error {
+		if variants[name] {
+			return fmt.Errorf("contract schema: duplicate request '%s'", name)
+		}
+		variants[name] = true
+		if m == nil {
+			return fmt.Errorf("contract schema: request '%s' is missing", name)
+		}
+		if err := s.validateStruct(m.Fields); err != nil {
+			return fmt.Errorf("contract schema: request '%s': %w", name, err)
+		}
+		if _, ok := s.Responses[m.Response]; m.Response != "" && !ok {
+			return fmt.Errorf("contract schema: request '%s': unknown response '%s'", name, m.Response)
+		}
+		return nil
+	}
+	if s.Instantiate != nil {
+		if err := validateMethod(InstantiateVariant, s.Instantiate); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedSchemaKeys(s.Calls) {
+		if err := validateMethod(name, s.Calls[name]); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedSchemaKeys(s.Queries) {
+		if err := validateMethod(name, s.Queries[name]); err != nil {
+			return err
+		}
+	}
+
+	codes := make(map[uint32]string)
+	for name, ev := range s.Events {
+		if ev == nil {
+			return fmt.Errorf("contract schema: event '%s' is missing", name)
+		}
+		if other, ok := codes[ev.Code]; ok {
+			return fmt.Errorf("contract schema: events '%s' and '%s' have the same code %d", name, other, ev.Code)
+		}
+		codes[ev.Code] = name
+		if err := s.validateStruct(ev.Fields); err != nil {
+			return fmt.Errorf("contract schema: event '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateStruct(st SchemaStruct) error {
+	names := make(map[string]bool)
+	for _, f := range st {
+		if f.Name == "" {
+			return fmt.Errorf("field without name")
+		}
+		if names[f.Name] {
+			return fmt.Errorf("duplicate field '%s'", f.Name)
+		}
+		names[f.Name] = true
+		if err := s.validateType(f.Type); err != nil {
+			return fmt.Errorf("field '%s': %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateType(typ string) error {
+	switch {
+	case isSchemaPrimitive(typ):
+		return nil
+	case strings.HasPrefix(typ, schemaListPrefix):
+		return s.validateType(strings.TrimPrefix(typ, schemaListPrefix))
+	default:
+		if _, ok := s.Types[typ]; !ok {
+			return fmt.Errorf("unknown type '%s'", typ)
+		}
+		return nil
+	}
+}
+
+// Method returns the request method with the given name of the given kind.
+func (s *Schema) Method(kind RequestKind, name string) (*SchemaMethod, bool) {
+	var m *SchemaMethod
+	switch kind {
+	case RequestInstantiate:
+		if name == InstantiateVariant {
+			m = s.Instantiate
+		}
+	case RequestCall:
+		m = s.Calls[name]
+	case RequestQuery:
+		m = s.Queries[name]
+	}
+	return m, m != nil
+}
+
+// EncodeRequest validates the given generic request (e.g. decoded from YAML or JSON) of the given
+// kind against the schema and converts it into a form that can be CBOR-encoded as expected by the
+// contract.
+//
+// Requests for methods without fields may also be given as a plain string naming the method.
+func (s *Schema) EncodeRequest(kind RequestKind, data interface{}) (interface{}, error) {
+	var (
+		name string
+		args interface{}
+	)
+	switch d := data.(type) {
+	case string:
+		name = d
+	default:
+		m, err := genericMap(data)
+		if err != nil || len(m) != 1 {
+			return nil, fmt.Errorf("contract schema: %s request must be a map with a single method", kind)
+		}
+		for k, v := range m {
+			name, args = k, v
+		}
+	}
+
+	method, ok := s.Method(kind, name)
+	if !ok {
+		return nil, fmt.Errorf("contract schema: unknown %s method '%s'", kind, name)
+	}
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+	enc, err := s.encodeStruct(method.Fields, args)
+	if err != nil {
+		return nil, fmt.Errorf("contract schema: %s '%s': %w", kind, name, err)
+	}
+	return map[string]interface{}{name: enc}, nil
+}
+
+func (s *Schema) encodeStruct(st SchemaStruct, data interface{}) (map[string]interface{}, error) {
+	m, err := genericMap(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{})
+	for _, f := range st {
+		v, ok := m[f.Name]
+		if !ok || v == nil {
+			if !f.Optional {
+				return nil, fmt.Errorf("missing field '%s'", f.Name)
+			}
+			continue
+		}
+		if result[f.Name], err = s.encodeValue(f.Type, v); err != nil {
+			return nil, fmt.Errorf("field '%s': %w", f.Name, err)
+		}
+		delete(m, f.Name)
+	}
+	for name := range m {
+		return nil, fmt.Errorf("unknown field '%s'", name)
+	}
+	return result, nil
+}
+
+func (s *Schema) encodeValue(typ string, v interface{}) (interface{}, error) {
+	if ib, ok := schemaIntegerBits[typ]; ok {
+		n, err := genericInteger(v)
+		if err != nil {
+			return nil, err
+		}
+		if !integerFits(n, ib.bits, ib.signed) {
+			return nil, fmt.Errorf("value %s out of range for %s", n, typ)
+		}
+		switch {
+		case typ == SchemaTypeU128:
+			var q quantity.Quantity
+			if err = q.FromBigInt(n); err != nil {
+				return nil, err
+			}
+			return &q, nil
+		case ib.signed:
+			return n.Int64(), nil
+		default:
+			return n.Uint64(), nil
+		}
+	}
+
+	switch {
+	case typ == SchemaTypeBool:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected bool, got %T", v)
+		}
+		return b, nil
+	case typ == SchemaTypeString:
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", v)
+		}
+		return str, nil
+	case typ == SchemaTypeBytes:
+		str, ok := v.(string)
+		if !ok || !strings.HasPrefix(str, "0x") {
+			return nil, fmt.Errorf("expected 0x-prefixed hex-encoded bytes")
+		}
+		data, err := hex.DecodeString(str[2:])
+		if err != nil {
+			return nil, fmt.Errorf("malformed hex-encoded bytes: %w", err)
+		}
+		return data, nil
+	case typ == SchemaTypeAddress:
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected address, got %T", v)
+		}
+		var addr types.Address
+		if err := addr.UnmarshalText([]byte(str)); err != nil {
+			return nil, fmt.Errorf("malformed address: %w", err)
+		}
+		return addr, nil
+	case typ == SchemaTypeAny:
+		return v, nil
+	case strings.HasPrefix(typ, schemaListPrefix):
+		list, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected list, got %T", v)
+		}
+		result := make([]interface{}, 0, len(list))
+		for i, item := range list {
+			enc, err := s.encodeValue(strings.TrimPrefix(typ, schemaListPrefix), item)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+			result = append(result, enc)
+		}
+		return result, nil
+	default:
+		st, ok := s.Types[typ]
+		if !ok {
+			return nil, fmt.Errorf("unknown type '%s'", typ)
+		}
+		return s.encodeStruct(st, v)
+	}
+}
+
+func isSchemaPrimitive(typ string) bool {
+	if _, ok := schemaIntegerBits[typ]; ok {
+		return true
+	}
+	switch typ {
+	case SchemaTypeBool, SchemaTypeString, SchemaTypeBytes, SchemaTypeAddress, SchemaTypeAny:
+		return true
+	default:
+		return false
+	}
+}
+
+// genericMap converts a generic map (e.g. decoded from YAML) into a map with string keys.
+func genericMap(v interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	switch m := v.(type) {
+	case map[string]interface{}:
+		for k, v := range m {
+			result[k] = v
+		}
+	case map[interface{}]interface{}:
+		for k, v := range m {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("non-string map key: %v", k)
+			}
+			result[key] = v
+		}
+	default:
+		return nil, fmt.Errorf("expected map, got %T", v)
+	}
+	return result, nil
+}
+
+// maxExactFloatInteger is the largest magnitude up to which all integers are exactly representable
+// as a float64.
+const maxExactFloatInteger = 1 << 53
+
+// genericInteger converts a generic integer value (e.g. decoded from YAML or JSON) or a decimal
+// string into a big integer.
+//
+// Floating point numbers are only accepted when they are exactly representable integers so that
+// large integers which were rounded by the decoder are rejected instead of silently losing
+// precision. Such integers must be given as decimal strings instead.
+func genericInteger(v interface{}) (*big.Int, error) {
+	switch n := v.(type) {
+	case int:
+		return big.NewInt(int64(n)), nil
+	case int64:
+		return big.NewInt(n), nil
+	case uint64:
+		return new(big.Int).SetUint64(n), nil
+	case float64:
+		if n != math.Trunc(n) || math.IsInf(n, 0) {
+			return nil, fmt.Errorf("expected integer, got %v", n)
+		}
+		if math.Abs(n) > maxExactFloatInteger {
+			return nil, fmt.Errorf("integer %v cannot be represented exactly, use a string instead", n)
+		}
+		return big.NewInt(int64(n)), nil
+	case string:
+		i, ok := new(big.Int).SetString(n, 10)
+		if !ok {
+			return nil, fmt.Errorf("malformed integer '%s'", n)
+		}
+		return i, nil
+	default:
+		return nil, fmt.Errorf("expected integer, got %T", v)
+	}
+}
+
+func integerFits(n *big.Int, bits uint, signed bool) bool {
+	if !signed {
+		return n.Sign() >= 0 && n.BitLen() <= int(bits)
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), bits-1)
+	return n.Cmp(new(big.Int).Neg(limit)) >= 0 && n.Cmp(limit) < 0
+}
+
+func sortedSchemaKeys(m map[string]*SchemaMethod) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,142 @@
+package contracts
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+)
+
+func TestSchemaEncodeRequest(t *testing.T) {
+	require := require.New(t)
+
+	data, err := os.ReadFile("testdata/oas20.schema.json")
+	require.NoError(err, "ReadFile")
+	s, err := ParseSchema(data)
+	require.NoError(err, "ParseSchema")
+
+	alice := sdkTesting.Alice.Address
+	for _, tc := range []struct {
+		kind     RequestKind
+		yaml     string
+		expected interface{}
+	}{
+		{
+			RequestCall,
+			"transfer: {to: " + alice.String() + ", amount: 1000}",
+			map[string]interface{}{"transfer": map[string]interface{}{"to": alice, "amount": quantity.NewFromUint64(1000)}},
+		},
+		{
+			RequestCall,
+			"send: {to: 5, amount: '340282366920938463463374607431768211455', data: {hello: world}}",
+			map[string]interface{}{"send": map[string]interface{}{
+				"to":     uint64(5),
+				"amount": mustQuantity("340282366920938463463374607431768211455"),
+				"data":   map[interface{}]interface{}{"hello": "world"},
+			}},
+		},
+		{
+			RequestCall,
+			"transfer: {to: " + alice.String() + ", amount: '100000000000000000123'}",
+			map[string]interface{}{"transfer": map[string]interface{}{"to": alice, "amount": mustQuantity("100000000000000000123")}},
+		},
+		{
+			RequestCall,
+			"transfer: {to: " + alice.String() + ", amount: 9007199254740992}",
+			map[string]interface{}{"transfer": map[string]interface{}{"to": alice, "amount": quantity.NewFromUint64(9007199254740992)}},
+		},
+		{
+			RequestQuery,
+			"token_information",
+			map[string]interface{}{"token_information": map[string]interface{}{}},
+		},
+		{
+			RequestQuery,
+			"token_information: {}",
+			map[string]interface{}{"token_information": map[string]interface{}{}},
+		},
+		{
+			RequestInstantiate,
+			"instantiate: {name: Test, symbol: TST, decimals: 6, initial_balances: [{address: " + alice.String() + ", amount: 10}]}",
+			map[string]interface{}{"instantiate": map[string]interface{}{
+				"name":     "Test",
+				"symbol":   "TST",
+				"decimals": uint64(6),
+				"initial_balances": []interface{}{
+					map[string]interface{}{"address": alice, "amount": quantity.NewFromUint64(10)},
+				},
+			}},
+		},
+	} {
+		var req interface{}
+		err = yaml.Unmarshal([]byte(tc.yaml), &req)
+		require.NoError(err, tc.yaml)
+
+		enc, err := s.EncodeRequest(tc.kind, req)
+		require.NoError(err, tc.yaml)
+		require.Equal(cbor.Marshal(tc.expected), cbor.Marshal(enc), tc.yaml)
+	}
+
+	for _, tc := range []struct {
+		kind RequestKind
+		yaml string
+	}{
+		{RequestCall, "unknown: {}"},
+		{RequestCall, "balance: {address: " + alice.String() + "}"},
+		{RequestQuery, "transfer: {to: " + alice.String() + ", amount: 1}"},
+		{RequestCall, "transfer: {to: " + alice.String() + "}"},
+		{RequestCall, "transfer: {to: " + alice.String() + ", amount: 1, extra: 1}"},
+		{RequestCall, "transfer: {to: bad, amount: 1}"},
+		{RequestCall, "transfer: {to: " + alice.String() + ", amount: -1}"},
+		{RequestCall, "transfer: {to: " + alice.String() + ", amount: 1.5}"},
+		{RequestCall, "transfer: {to: " + alice.String() + ", amount: 100000000000000000123}"},
+		{RequestCall, "transfer: {to: " + alice.String() + ", amount: 1.0e+20}"},
+		{RequestCall, "transfer: {to: " + alice.String() + ", amount: '340282366920938463463374607431768211456'}"},
+		{RequestCall, "allow: {beneficiary: " + alice.String() + ", negative: 'no', amount_change: 1}"},
+		{RequestInstantiate, "instantiate: {name: Test, symbol: TST, decimals: 256}"},
+		{RequestInstantiate, "instantiate: {name: Test, symbol: TST, decimals: 6, initial_balances: {}}"},
+		{RequestCall, "{transfer: {}, burn: {}}"},
+		{RequestCall, "[]"},
+	} {
+		var req interface{}
+		err = yaml.Unmarshal([]byte(tc.yaml), &req)
+		require.NoError(err, tc.yaml)
+
+		_, err = s.EncodeRequest(tc.kind, req)
+		require.Error(err, tc.yaml)
+	}
+}
+
+func TestParseSchema(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []string{
+		`{`,
+		`{"types": {"u64": []}}`,
+		`{"types": {"x": [{"name": "a", "type": "unknown"}]}}`,
+		`{"types": {"x": [{"name": "a", "type": "u8"}, {"name": "a", "type": "u8"}]}}`,
+		`{"types": {"x": [{"type": "u8"}]}}`,
+		`{"calls": {"x": {"fields": [{"name": "a", "type": "[]unknown"}]}}}`,
+		`{"calls": {"x": {}}, "queries": {"x": {}}}`,
+		`{"calls": {"instantiate": {}}, "instantiate": {}}`,
+		`{"calls": {"x": {"response": "unknown"}}}`,
+		`{"events": {"x": {"code": 1}, "y": {"code": 1}}}`,
+	} {
+		_, err := ParseSchema([]byte(tc))
+		require.Error(err, tc)
+	}
+}
+
+func mustQuantity(s string) *quantity.Quantity {
+	var q quantity.Quantity
+	if err := q.UnmarshalText([]byte(s)); err != nil {
+		panic(err)
+	}
+	return &q
+}
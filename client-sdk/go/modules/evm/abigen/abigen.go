@@ -0,0 +1,271 @@
+// Package abigen generates typed Go bindings for EVM contracts from their Solidity JSON ABI.
+//
+// The generated bindings use the evm.V1 module client and the evm.Contract helper.
+package abigen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"text/template"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
+)
+
+// Config is the code generator configuration.
+type Config struct {
+	// Package is the name of the Go package of the generated code.
+	Package string
+	// Type is the name of the generated contract type.
+	Type string
+	// ABI is the Solidity JSON ABI of the contract.
+	ABI []byte
+}
+
+type tmplArgument struct {
+	Name string
+	Type string
+}
+
+type tmplMethod struct {
+	Name     string
+	ABIName  string
+	ID       string
+	Solidity string
+	Constant bool
+	Inputs   []tmplArgument
+	Outputs  []tmplArgument
+}
+
+type tmplEvent struct {
+	Name     string
+	ABIName  string
+	Solidity string
+	Fields   []tmplArgument
+}
+
+type tmplData struct {
+	Package string
+	Type    string
+	ABI     string
+	Methods []tmplMethod
+	Events  []tmplEvent
+}
+
+// Generate generates typed Go bindings for the contract described by the given configuration.
+func Generate(cfg *Config) ([]byte, error) {
+	if !token.IsIdentifier(cfg.Package) {
+		return nil, fmt.Errorf("abigen: invalid package name '%s'", cfg.Package)
+	}
+	if !token.IsIdentifier(cfg.Type) || !token.IsExported(cfg.Type) {
+		return nil, fmt.Errorf("abigen: invalid type name '%s'", cfg.Type)
+	}
+
+	contractABI, err := evm.ParseABI(cfg.ABI)
+	if err != nil {
+		return nil, fmt.Errorf("abigen: %w", err)
+	}
+
+	// Compact the ABI so that it can be embedded as a string constant.
+	var compactABI bytes.Buffer
+	if err = json.Compact(&compactABI, cfg.ABI); err != nil {
+		return nil, fmt.Errorf("abigen: %w", err)
+	}
+
+	data := tmplData{
+		Package: cfg.Package,
+		Type:    cfg.Type,
+		ABI:     fmt.Sprintf("%q", compactABI.String()),
+	}
+
+	for _, name := range sortedKeys(contractABI.Methods) {
+		method := contractABI.Methods[name]
+		tm := tmplMethod{
+			Name:     abi.ToCamelCase(name),
+			ABIName:  name,
+			ID:       hexutil.Encode(method.ID),
+			Solidity: method.String(),
+			Constant: method.IsConstant(),
+			Inputs:   convertArguments(method.Inputs, false),
+			Outputs:  convertArguments(method.Outputs, false),
+		}
+		data.Methods = append(data.Methods, tm)
+	}
+
+	for _, name := range sortedKeys(contractABI.Events) {
+		event := contractABI.Events[name]
+		te := tmplEvent{
+			Name:     abi.ToCamelCase(name),
+			ABIName:  name,
+			Solidity: event.String(),
+		}
+		for _, arg := range event.Inputs {
+			te.Fields = append(te.Fields, tmplArgument{
+				Name: abi.ToCamelCase(arg.Name),
+				Type: goType(arg.Type, arg.Indexed),
+			})
+		}
+		data.Events = append(data.Events, te)
+	}
+
+	var buf bytes.Buffer
+	if err = bindingTemplate.Execute(&buf, &data); err != nil {
+		return nil, fmt.Errorf("abigen: %w", err)
+	}
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("abigen: failed to format generated code: %w", err)
+	}
+	return code, nil
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch tm := m.(type) {
+	case map[string]abi.Method:
+		for k := range tm {
+			keys = append(keys, k)
+		}
+	case map[string]abi.Event:
+		for k := range tm {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// reservedNames are identifiers used by the generated code which cannot be used as argument names.
+var reservedNames = map[string]bool{
+	"c":       true,
+	"ctx":     true,
+	"round":   true,
+	"opts":    true,
+	"value":   true,
+	"out":     true,
+	"err":     true,
+	"abi":     true,
+	"big":     true,
+	"client":  true,
+	"common":  true,
+	"context": true,
+	"evm":     true,
+}
+
+func convertArguments(args abi.Arguments, indexed bool) []tmplArgument {
+	result := make([]tmplArgument, 0, len(args))
+	for i, arg := range args {
+		name := arg.Name
+		switch {
+		case name == "" || token.IsKeyword(name) || !token.IsIdentifier(name):
+			name = fmt.Sprintf("arg%d", i)
+		case reservedNames[name]:
+			name = "arg" + abi.ToCamelCase(name)
+		}
+		result = append(result, tmplArgument{
+			Name: name,
+			Type: goType(arg.Type, indexed && arg.Indexed),
+		})
+	}
+	return result
+}
+
+// goType returns the Go type used for the given ABI type.
+//
+// Indexed event arguments of dynamic or composite types are only available as their hash.
+func goType(t abi.Type, indexed bool) string {
+	if indexed {
+		switch t.T {
+		case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+			return "common.Hash"
+		}
+	}
+	return t.GetType().String()
+}
+
+var bindingTemplate = template.Must(template.New("binding").Parse(`// Code generated by oasis-evm-abigen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = context.Background
+	_ = big.NewInt
+	_ = abi.ConvertType
+	_ = common.Big1
+	_ = client.RoundLatest
+)
+
+// {{.Type}}ABI is the Solidity JSON ABI of the {{.Type}} contract.
+const {{.Type}}ABI = {{.ABI}}
+
+// {{.Type}} is a typed binding for the {{.Type}} contract.
+type {{.Type}} struct {
+	contract *evm.Contract
+}
+
+// New{{.Type}} creates a new binding for the {{.Type}} contract deployed at the given address.
+func New{{.Type}}(e evm.V1, address []byte) (*{{.Type}}, error) {
+	contractABI, err := evm.ParseABI([]byte({{.Type}}ABI))
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Type}}{contract: evm.NewContract(e, address, contractABI)}, nil
+}
+
+// Contract returns the underlying contract helper.
+func (c *{{.Type}}) Contract() *evm.Contract {
+	return c.contract
+}
+{{range $m := .Methods}}{{if $m.Constant}}
+// {{$m.Name}} simulates a call to the contract method {{$m.ID}}.
+//
+// Solidity: {{$m.Solidity}}
+func (c *{{$.Type}}) {{$m.Name}}(ctx context.Context, round uint64, opts *evm.CallOptions{{range $m.Inputs}}, {{.Name}} {{.Type}}{{end}}) ({{range $m.Outputs}}{{.Type}}, {{end}}error) {
+	{{if $m.Outputs}}out{{else}}_{{end}}, err := c.contract.SimulateCall(ctx, round, opts, "{{$m.ABIName}}"{{range $m.Inputs}}, {{.Name}}{{end}})
+	if err != nil {
+		return {{range $m.Outputs}}*new({{.Type}}), {{end}}err
+	}
+	return {{range $i, $o := $m.Outputs}}*abi.ConvertType(out[{{$i}}], new({{$o.Type}})).(*{{$o.Type}}), {{end}}nil
+}
+{{else}}
+// {{$m.Name}} generates a transaction calling the contract method {{$m.ID}}.
+//
+// Solidity: {{$m.Solidity}}
+func (c *{{$.Type}}) {{$m.Name}}(value []byte{{range $m.Inputs}}, {{.Name}} {{.Type}}{{end}}) (*client.TransactionBuilder, error) {
+	return c.contract.Call(value, "{{$m.ABIName}}"{{range $m.Inputs}}, {{.Name}}{{end}})
+}
+{{end}}{{end}}{{range $e := .Events}}
+// {{$.Type}}{{$e.Name}} is the {{$e.ABIName}} event emitted by the {{$.Type}} contract.
+type {{$.Type}}{{$e.Name}} struct {
+{{range $e.Fields}}	{{.Name}} {{.Type}}
+{{end}}}
+
+// Parse{{$e.Name}} decodes the {{$e.ABIName}} event emitted by the {{$.Type}} contract.
+//
+// Solidity: {{$e.Solidity}}
+func (c *{{$.Type}}) Parse{{$e.Name}}(ev *evm.Event) (*{{$.Type}}{{$e.Name}}, error) {
+	var out {{$.Type}}{{$e.Name}}
+	if err := c.contract.UnpackEvent(&out, "{{$e.ABIName}}", ev); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+{{end}}`))
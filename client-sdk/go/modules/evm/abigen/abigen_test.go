@@ -0,0 +1,87 @@
+package abigen
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm/abigen/internal/erc20"
+)
+
+// simulateOnlyV1 is an evm.V1 implementation which only supports SimulateCall.
+type simulateOnlyV1 struct {
+	evm.V1
+
+	data   []byte
+	result []byte
+}
+
+func (s *simulateOnlyV1) SimulateCall(ctx context.Context, round uint64, gasPrice []byte, gasLimit uint64, caller []byte, address []byte, value []byte, data []byte) ([]byte, error) {
+	s.data = data
+	return s.result, nil
+}
+
+func TestGenerate(t *testing.T) {
+	require := require.New(t)
+
+	abiJSON, err := os.ReadFile("../testdata/ERC20.abi.json")
+	require.NoError(err, "ReadFile")
+
+	// Generated bindings must match the checked in bindings.
+	code, err := Generate(&Config{Package: "erc20", Type: "ERC20", ABI: abiJSON})
+	require.NoError(err, "Generate")
+	expected, err := os.ReadFile("internal/erc20/erc20.go")
+	require.NoError(err, "ReadFile")
+	require.Equal(string(expected), string(code), "generated bindings should be up to date (run go generate)")
+
+	for _, cfg := range []*Config{
+		{Package: "", Type: "ERC20", ABI: abiJSON},
+		{Package: "erc20", Type: "erc20", ABI: abiJSON},
+		{Package: "erc20", Type: "ERC20", ABI: []byte("{")},
+	} {
+		_, err = Generate(cfg)
+		require.Error(err, "Generate should fail for invalid configuration")
+	}
+}
+
+func TestGeneratedBindings(t *testing.T) {
+	require := require.New(t)
+
+	mock := &simulateOnlyV1{}
+	address := ethCommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	token, err := erc20.NewERC20(mock, address.Bytes())
+	require.NoError(err, "NewERC20")
+
+	mock.result = ethCommon.LeftPadBytes(big.NewInt(5000).Bytes(), 32)
+	balance, err := token.BalanceOf(context.Background(), client.RoundLatest, nil, address)
+	require.NoError(err, "BalanceOf")
+	require.EqualValues(big.NewInt(5000), balance)
+	require.EqualValues(token.Contract().ABI().Methods["balanceOf"].ID, mock.data[:4])
+
+	uint256, err := abi.NewType("uint256", "", nil)
+	require.NoError(err)
+	value, err := abi.Arguments{{Type: uint256}}.Pack(big.NewInt(7))
+	require.NoError(err)
+	to := ethCommon.HexToAddress("0x2000000000000000000000000000000000000002")
+	ev := &evm.Event{
+		Address: address.Bytes(),
+		Topics: [][]byte{
+			token.Contract().ABI().Events["Transfer"].ID.Bytes(),
+			ethCommon.LeftPadBytes(address.Bytes(), 32),
+			ethCommon.LeftPadBytes(to.Bytes(), 32),
+		},
+		Data: value,
+	}
+	transfer, err := token.ParseTransfer(ev)
+	require.NoError(err, "ParseTransfer")
+	require.Equal(address, transfer.From)
+	require.Equal(to, transfer.To)
+	require.EqualValues(big.NewInt(7), transfer.Value)
+}
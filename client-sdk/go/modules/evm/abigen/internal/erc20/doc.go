@@ -0,0 +1,5 @@
+// Package erc20 contains generated bindings for an ERC-20 token contract used to test the code
+// generator.
+package erc20
+
+//go:generate go run ../../oasis-evm-abigen -abi ../../../testdata/ERC20.abi.json -pkg erc20 -type ERC20 -out erc20.go
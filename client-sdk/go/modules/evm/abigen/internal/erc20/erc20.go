@@ -0,0 +1,116 @@
+// Code generated by oasis-evm-abigen. DO NOT EDIT.
+
+package erc20
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = context.Background
+	_ = big.NewInt
+	_ = abi.ConvertType
+	_ = common.Big1
+	_ = client.RoundLatest
+)
+
+// ERC20ABI is the Solidity JSON ABI of the ERC20 contract.
+const ERC20ABI = "[{\"type\":\"constructor\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"name_\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"symbol_\",\"type\":\"string\",\"internalType\":\"string\"}]},{\"type\":\"function\",\"name\":\"name\",\"stateMutability\":\"view\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"string\",\"internalType\":\"string\"}]},{\"type\":\"function\",\"name\":\"balanceOf\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"account\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"function\",\"name\":\"transfer\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"to\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}]},{\"type\":\"function\",\"name\":\"approve\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"spender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}]},{\"type\":\"event\",\"name\":\"Transfer\",\"anonymous\":false,\"inputs\":[{\"name\":\"from\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"to\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}]},{\"type\":\"event\",\"name\":\"Memo\",\"anonymous\":false,\"inputs\":[{\"name\":\"tag\",\"type\":\"string\",\"indexed\":true,\"internalType\":\"string\"},{\"name\":\"text\",\"type\":\"string\",\"indexed\":false,\"internalType\":\"string\"}]},{\"type\":\"error\",\"name\":\"InsufficientBalance\",\"inputs\":[{\"name\":\"available\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"required\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]}]"
+
+// ERC20 is a typed binding for the ERC20 contract.
+type ERC20 struct {
+	contract *evm.Contract
+}
+
+// NewERC20 creates a new binding for the ERC20 contract deployed at the given address.
+func NewERC20(e evm.V1, address []byte) (*ERC20, error) {
+	contractABI, err := evm.ParseABI([]byte(ERC20ABI))
+	if err != nil {
+		return nil, err
+	}
+	return &ERC20{contract: evm.NewContract(e, address, contractABI)}, nil
+}
+
+// Contract returns the underlying contract helper.
+func (c *ERC20) Contract() *evm.Contract {
+	return c.contract
+}
+
+// Approve generates a transaction calling the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (c *ERC20) Approve(value []byte, spender common.Address, argValue *big.Int) (*client.TransactionBuilder, error) {
+	return c.contract.Call(value, "approve", spender, argValue)
+}
+
+// BalanceOf simulates a call to the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (c *ERC20) BalanceOf(ctx context.Context, round uint64, opts *evm.CallOptions, account common.Address) (*big.Int, error) {
+	out, err := c.contract.SimulateCall(ctx, round, opts, "balanceOf", account)
+	if err != nil {
+		return *new(*big.Int), err
+	}
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+}
+
+// Name simulates a call to the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (c *ERC20) Name(ctx context.Context, round uint64, opts *evm.CallOptions) (string, error) {
+	out, err := c.contract.SimulateCall(ctx, round, opts, "name")
+	if err != nil {
+		return *new(string), err
+	}
+	return *abi.ConvertType(out[0], new(string)).(*string), nil
+}
+
+// Transfer generates a transaction calling the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (c *ERC20) Transfer(value []byte, to common.Address, amount *big.Int) (*client.TransactionBuilder, error) {
+	return c.contract.Call(value, "transfer", to, amount)
+}
+
+// ERC20Memo is the Memo event emitted by the ERC20 contract.
+type ERC20Memo struct {
+	Tag  common.Hash
+	Text string
+}
+
+// ParseMemo decodes the Memo event emitted by the ERC20 contract.
+//
+// Solidity: event Memo(string indexed tag, string text)
+func (c *ERC20) ParseMemo(ev *evm.Event) (*ERC20Memo, error) {
+	var out ERC20Memo
+	if err := c.contract.UnpackEvent(&out, "Memo", ev); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ERC20Transfer is the Transfer event emitted by the ERC20 contract.
+type ERC20Transfer struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+}
+
+// ParseTransfer decodes the Transfer event emitted by the ERC20 contract.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (c *ERC20) ParseTransfer(ev *evm.Event) (*ERC20Transfer, error) {
+	var out ERC20Transfer
+	if err := c.contract.UnpackEvent(&out, "Transfer", ev); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,56 @@
+// Command oasis-evm-abigen generates typed Go bindings for EVM contracts from their Solidity
+// JSON ABI.
+//
+// Usage:
+//
+//	oasis-evm-abigen -abi Token.abi -pkg token -type Token -out token.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/evm/abigen"
+)
+
+func main() {
+	var (
+		abiFile = flag.String("abi", "", "path to the Solidity JSON ABI of the contract")
+		pkg     = flag.String("pkg", "", "name of the Go package of the generated code")
+		typ     = flag.String("type", "", "name of the generated contract type")
+		out     = flag.String("out", "", "output file (default: standard output)")
+	)
+	flag.Parse()
+
+	if err := run(*abiFile, *pkg, *typ, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(abiFile, pkg, typ, out string) error {
+	if abiFile == "" || pkg == "" || typ == "" {
+		return fmt.Errorf("the -abi, -pkg and -type flags are required")
+	}
+
+	abiJSON, err := os.ReadFile(abiFile)
+	if err != nil {
+		return fmt.Errorf("failed to read ABI: %w", err)
+	}
+
+	code, err := abigen.Generate(&abigen.Config{
+		Package: pkg,
+		Type:    typ,
+		ABI:     abiJSON,
+	})
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+	return os.WriteFile(out, code, 0o644) // nolint: gosec
+}
@@ -0,0 +1,178 @@
+package evm
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+)
+
+// ParseArgs parses the given string representations of ABI arguments into values that can be
+// passed to abi.Arguments.Pack.
+//
+// Integers may be given in decimal or 0x-prefixed hexadecimal form, byte strings and addresses
+// in hexadecimal form and booleans as true/false. Arrays and slices are given as JSON arrays of
+// the string representations of their elements. Tuples are not supported.
+func ParseArgs(args abi.Arguments, values []string) ([]interface{}, error) {
+	if len(values) != len(args) {
+		return nil, fmt.Errorf("argument count mismatch: got %d for %d", len(values), len(args))
+	}
+	result := make([]interface{}, 0, len(args))
+	for i, arg := range args {
+		v, err := ParseArg(arg.Type, values[i])
+		if err != nil {
+			name := arg.Name
+			if name == "" {
+				name = fmt.Sprintf("%d", i)
+			}
+			return nil, fmt.Errorf("bad argument '%s': %w", name, err)
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+// ParseArg parses the string representation of a single value of the given ABI type.
+func ParseArg(typ abi.Type, value string) (interface{}, error) {
+	v, err := parseArg(typ, value)
+	if err != nil {
+		return nil, err
+	}
+	return v.Interface(), nil
+}
+
+func parseArg(typ abi.Type, value string) (reflect.Value, error) {
+	goType := typ.GetType()
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		n, ok := parseInteger(value)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("malformed integer '%s'", value)
+		}
+		if !integerInRange(typ, n) {
+			return reflect.Value{}, fmt.Errorf("value out of range for %s", typ)
+		}
+		if goType == reflect.TypeOf(&big.Int{}) {
+			return reflect.ValueOf(n), nil
+		}
+		v := reflect.New(goType).Elem()
+		if typ.T == abi.IntTy {
+			v.SetInt(n.Int64())
+		} else {
+			v.SetUint(n.Uint64())
+		}
+		return v, nil
+	case abi.BoolTy:
+		switch strings.ToLower(value) {
+		case "true":
+			return reflect.ValueOf(true), nil
+		case "false":
+			return reflect.ValueOf(false), nil
+		default:
+			return reflect.Value{}, fmt.Errorf("malformed boolean '%s'", value)
+		}
+	case abi.StringTy:
+		return reflect.ValueOf(value), nil
+	case abi.AddressTy:
+		if !ethCommon.IsHexAddress(value) {
+			return reflect.Value{}, fmt.Errorf("malformed address '%s'", value)
+		}
+		return reflect.ValueOf(ethCommon.HexToAddress(value)), nil
+	case abi.BytesTy:
+		data, err := decodeHex(value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(data), nil
+	case abi.FixedBytesTy:
+		data, err := decodeHex(value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if len(data) != typ.Size {
+			return reflect.Value{}, fmt.Errorf("expected %d bytes, got %d", typ.Size, len(data))
+		}
+		v := reflect.New(goType).Elem()
+		reflect.Copy(v, reflect.ValueOf(data))
+		return v, nil
+	case abi.SliceTy, abi.ArrayTy:
+		var elems []json.RawMessage
+		if err := json.Unmarshal([]byte(value), &elems); err != nil {
+			return reflect.Value{}, fmt.Errorf("malformed array: %w", err)
+		}
+		var v reflect.Value
+		switch typ.T {
+		case abi.SliceTy:
+			v = reflect.MakeSlice(goType, len(elems), len(elems))
+		default:
+			if len(elems) != typ.Size {
+				return reflect.Value{}, fmt.Errorf("expected %d elements, got %d", typ.Size, len(elems))
+			}
+			v = reflect.New(goType).Elem()
+		}
+		for i, raw := range elems {
+			// Elements may either be given as JSON strings or as bare JSON values.
+			var elem string
+			if err := json.Unmarshal(raw, &elem); err != nil {
+				elem = string(raw)
+			}
+			ev, err := parseArg(*typ.Elem, elem)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element %d: %w", i, err)
+			}
+			v.Index(i).Set(ev)
+		}
+		return v, nil
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported argument type: %s", typ)
+	}
+}
+
+func parseInteger(value string) (*big.Int, bool) {
+	neg := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+
+	var (
+		n  *big.Int
+		ok bool
+	)
+	switch {
+	case strings.HasPrefix(value, "0x"), strings.HasPrefix(value, "0X"):
+		n, ok = new(big.Int).SetString(value[2:], 16)
+	default:
+		n, ok = new(big.Int).SetString(value, 10)
+	}
+	if !ok {
+		return nil, false
+	}
+	if neg {
+		n.Neg(n)
+	}
+	return n, true
+}
+
+// integerInRange returns true iff the given integer can be represented by the given ABI integer
+// type.
+func integerInRange(typ abi.Type, n *big.Int) bool {
+	if typ.T == abi.UintTy {
+		return n.Sign() >= 0 && n.BitLen() <= typ.Size
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(typ.Size-1))
+	min := new(big.Int).Neg(limit)
+	max := limit.Sub(limit, big.NewInt(1))
+	return n.Cmp(min) >= 0 && n.Cmp(max) <= 0
+}
+
+func decodeHex(value string) ([]byte, error) {
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+	data, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("malformed hex value: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,83 @@
+package evm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArg(t *testing.T) {
+	require := require.New(t)
+
+	addr := ethCommon.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	for _, tc := range []struct {
+		typ      string
+		value    string
+		expected interface{}
+		ok       bool
+	}{
+		{"uint256", "1000", big.NewInt(1000), true},
+		{"uint256", "0x3e8", big.NewInt(1000), true},
+		{"uint256", "-1", nil, false},
+		{"uint256", "abc", nil, false},
+		{"uint8", "255", uint8(255), true},
+		{"uint8", "256", nil, false},
+		{"int8", "-128", int8(-128), true},
+		{"int8", "127", int8(127), true},
+		{"int8", "128", nil, false},
+		{"int8", "-129", nil, false},
+		{"int64", "-5", int64(-5), true},
+		{"int128", "-5", big.NewInt(-5), true},
+		{"bool", "true", true, true},
+		{"bool", "False", false, true},
+		{"bool", "yes", nil, false},
+		{"string", "hello world", "hello world", true},
+		{"address", addr.Hex(), addr, true},
+		{"address", "0x1234", nil, false},
+		{"bytes", "0xdeadbeef", []byte{0xde, 0xad, 0xbe, 0xef}, true},
+		{"bytes", "0xzz", nil, false},
+		{"bytes4", "deadbeef", [4]byte{0xde, 0xad, 0xbe, 0xef}, true},
+		{"bytes4", "0xdead", nil, false},
+		{"uint16[]", `[1, "0x2", "3"]`, []uint16{1, 2, 3}, true},
+		{"uint16[]", `[]`, []uint16{}, true},
+		{"uint16[]", `1`, nil, false},
+		{"address[2]", `["` + addr.Hex() + `", "` + addr.Hex() + `"]`, [2]ethCommon.Address{addr, addr}, true},
+		{"address[2]", `["` + addr.Hex() + `"]`, nil, false},
+		{"string[]", `["a", "b"]`, []string{"a", "b"}, true},
+	} {
+		typ, err := abi.NewType(tc.typ, "", nil)
+		require.NoError(err, tc.typ)
+
+		v, err := ParseArg(typ, tc.value)
+		if !tc.ok {
+			require.Error(err, "%s: %s", tc.typ, tc.value)
+			continue
+		}
+		require.NoError(err, "%s: %s", tc.typ, tc.value)
+		require.EqualValues(tc.expected, v, "%s: %s", tc.typ, tc.value)
+
+		// Parsed values should be accepted by the ABI encoder.
+		_, err = abi.Arguments{{Type: typ}}.Pack(v)
+		require.NoError(err, "%s: %s", tc.typ, tc.value)
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	require := require.New(t)
+
+	c := loadTestContract(t, nil)
+	method := c.ABI().Methods["transfer"]
+
+	args, err := ParseArgs(method.Inputs, []string{"0x5FbDB2315678afecb367f032d93F642f64180aa3", "100"})
+	require.NoError(err)
+	_, err = c.Pack("transfer", args...)
+	require.NoError(err)
+
+	_, err = ParseArgs(method.Inputs, []string{"0x5FbDB2315678afecb367f032d93F642f64180aa3"})
+	require.Error(err)
+	_, err = ParseArgs(method.Inputs, []string{"bad", "100"})
+	require.EqualError(err, "bad argument 'to': malformed address 'bad'")
+}
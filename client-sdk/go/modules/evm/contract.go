@@ -0,0 +1,230 @@
+package evm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+)
+
+// DefaultSimulateCallGasLimit is the gas limit used for simulated contract calls in case no gas
+// limit is specified.
+const DefaultSimulateCallGasLimit = 10_000_000
+
+// CallOptions are optional parameters for simulated contract calls.
+type CallOptions struct {
+	// GasPrice is the big-endian encoded gas price. Defaults to zero.
+	GasPrice []byte
+	// GasLimit is the gas limit. Defaults to DefaultSimulateCallGasLimit.
+	GasLimit uint64
+	// Caller is the address of the caller. Defaults to the zero address.
+	Caller []byte
+	// Value is the big-endian encoded amount of tokens to transfer. Defaults to zero.
+	Value []byte
+}
+
+// ContractEvent is a decoded EVM event emitted by a contract.
+type ContractEvent struct {
+	// Name is the name of the event as used in the contract ABI.
+	Name string `json:"name"`
+	// Signature is the canonical event signature.
+	Signature string `json:"signature"`
+	// Fields are the decoded event arguments by name.
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// Contract is a helper for interacting with an EVM contract with a known Solidity ABI.
+type Contract struct {
+	evm     V1
+	address []byte
+	abi     *abi.ABI
+}
+
+// ParseABI parses a Solidity JSON ABI.
+func ParseABI(data []byte) (*abi.ABI, error) {
+	contractABI, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("malformed contract ABI: %w", err)
+	}
+	return &contractABI, nil
+}
+
+// NewContract creates a new contract helper for the contract at the given address.
+func NewContract(evm V1, address []byte, contractABI *abi.ABI) *Contract {
+	return &Contract{
+		evm:     evm,
+		address: address,
+		abi:     contractABI,
+	}
+}
+
+// Address returns the contract address.
+func (c *Contract) Address() []byte {
+	return c.address
+}
+
+// ABI returns the contract ABI.
+func (c *Contract) ABI() *abi.ABI {
+	return c.abi
+}
+
+// Pack encodes the call data for invoking the given contract method.
+func (c *Contract) Pack(method string, args ...interface{}) ([]byte, error) {
+	data, err := c.abi.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode call to '%s': %w", method, err)
+	}
+	return data, nil
+}
+
+// Unpack decodes the return data of the given contract method.
+func (c *Contract) Unpack(method string, data []byte) ([]interface{}, error) {
+	out, err := c.abi.Unpack(method, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode result of '%s': %w", method, err)
+	}
+	return out, nil
+}
+
+// UnpackInto decodes the return data of the given contract method into out.
+func (c *Contract) UnpackInto(out interface{}, method string, data []byte) error {
+	if err := c.abi.UnpackIntoInterface(out, method, data); err != nil {
+		return fmt.Errorf("failed to decode result of '%s': %w", method, err)
+	}
+	return nil
+}
+
+// Call generates an EVM CALL transaction invoking the given contract method.
+//
+// The result of the transaction can be decoded using Unpack.
+func (c *Contract) Call(value []byte, method string, args ...interface{}) (*client.TransactionBuilder, error) {
+	data, err := c.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	return c.evm.Call(c.address, zeroIfEmpty(value), data), nil
+}
+
+// SimulateCall simulates invoking the given contract method and returns the decoded results.
+//
+// In case the call is reverted, a *RevertError is returned.
+func (c *Contract) SimulateCall(ctx context.Context, round uint64, opts *CallOptions, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := c.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts == nil {
+		opts = &CallOptions{}
+	}
+	gasLimit := opts.GasLimit
+	if gasLimit == 0 {
+		gasLimit = DefaultSimulateCallGasLimit
+	}
+	caller := opts.Caller
+	if caller == nil {
+		caller = make([]byte, ethCommon.AddressLength)
+	}
+
+	raw, err := c.evm.SimulateCall(ctx, round, zeroIfEmpty(opts.GasPrice), gasLimit, caller, c.address, zeroIfEmpty(opts.Value), data)
+	if err != nil {
+		return nil, c.DecodeError(err)
+	}
+	return c.Unpack(method, raw)
+}
+
+// DecodeError decodes the revert reason in case the given error has been caused by a reverted
+// call, including custom errors defined in the contract ABI. The error is returned as a
+// *RevertError in this case, otherwise it is returned unchanged.
+//
+// It can be used to decode errors returned when submitting transactions generated by Call.
+func (c *Contract) DecodeError(err error) error {
+	if rev := DecodeRevert(err, c.abi); rev != nil {
+		return rev
+	}
+	return err
+}
+
+// DecodeEvent decodes an EVM event emitted by the contract.
+//
+// In case the event has not been emitted by this contract or is not defined in the contract ABI,
+// nil is returned.
+func (c *Contract) DecodeEvent(ev *Event) (*ContractEvent, error) {
+	if !bytes.Equal(ev.Address, c.address) || len(ev.Topics) == 0 {
+		return nil, nil
+	}
+	abiEvent, err := c.abi.EventByID(ethCommon.BytesToHash(ev.Topics[0]))
+	if err != nil {
+		return nil, nil
+	}
+
+	fields := make(map[string]interface{})
+	if err = c.abi.UnpackIntoMap(fields, abiEvent.Name, ev.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode event '%s': %w", abiEvent.Name, err)
+	}
+	if err = abi.ParseTopicsIntoMap(fields, indexedArguments(abiEvent), eventTopics(ev)); err != nil {
+		return nil, fmt.Errorf("failed to decode event '%s': %w", abiEvent.Name, err)
+	}
+
+	return &ContractEvent{
+		Name:      abiEvent.Name,
+		Signature: abiEvent.Sig,
+		Fields:    fields,
+	}, nil
+}
+
+// UnpackEvent decodes an EVM event emitted by the contract into out.
+//
+// Fields of out are matched to event arguments in the same way as for UnpackInto.
+func (c *Contract) UnpackEvent(out interface{}, name string, ev *Event) error {
+	abiEvent, ok := c.abi.Events[name]
+	if !ok {
+		return fmt.Errorf("event '%s' not found in contract ABI", name)
+	}
+	if !bytes.Equal(ev.Address, c.address) {
+		return fmt.Errorf("event not emitted by contract")
+	}
+	if len(ev.Topics) == 0 || !bytes.Equal(ev.Topics[0], abiEvent.ID[:]) {
+		return fmt.Errorf("event signature mismatch (expected '%s')", abiEvent.Sig)
+	}
+
+	if len(ev.Data) > 0 {
+		if err := c.abi.UnpackIntoInterface(out, name, ev.Data); err != nil {
+			return fmt.Errorf("failed to decode event '%s': %w", name, err)
+		}
+	}
+	if err := abi.ParseTopics(out, indexedArguments(&abiEvent), eventTopics(ev)); err != nil {
+		return fmt.Errorf("failed to decode event '%s': %w", name, err)
+	}
+	return nil
+}
+
+func indexedArguments(ev *abi.Event) abi.Arguments {
+	var indexed abi.Arguments
+	for _, arg := range ev.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	return indexed
+}
+
+func eventTopics(ev *Event) []ethCommon.Hash {
+	// The first topic is the event signature.
+	topics := make([]ethCommon.Hash, 0, len(ev.Topics))
+	for _, topic := range ev.Topics[1:] {
+		topics = append(topics, ethCommon.BytesToHash(topic))
+	}
+	return topics
+}
+
+func zeroIfEmpty(v []byte) []byte {
+	if len(v) == 0 {
+		return make([]byte, 32)
+	}
+	return v
+}
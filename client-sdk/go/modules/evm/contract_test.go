@@ -0,0 +1,163 @@
+package evm
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+)
+
+// simulateOnlyV1 is a V1 implementation which only supports SimulateCall.
+type simulateOnlyV1 struct {
+	V1
+
+	query  *SimulateCallQuery
+	result []byte
+}
+
+func (s *simulateOnlyV1) SimulateCall(ctx context.Context, round uint64, gasPrice []byte, gasLimit uint64, caller []byte, address []byte, value []byte, data []byte) ([]byte, error) {
+	s.query = &SimulateCallQuery{
+		GasPrice: gasPrice,
+		GasLimit: gasLimit,
+		Caller:   caller,
+		Address:  address,
+		Value:    value,
+		Data:     data,
+	}
+	return s.result, nil
+}
+
+func loadTestContract(t *testing.T, e V1) *Contract {
+	data, err := os.ReadFile("testdata/ERC20.abi.json")
+	require.NoError(t, err, "ReadFile")
+	contractABI, err := ParseABI(data)
+	require.NoError(t, err, "ParseABI")
+
+	return NewContract(e, ethCommon.HexToAddress("0x1000000000000000000000000000000000000001").Bytes(), contractABI)
+}
+
+func TestContractCall(t *testing.T) {
+	require := require.New(t)
+
+	mock := &simulateOnlyV1{}
+	c := loadTestContract(t, mock)
+
+	// Method selectors must be computed correctly.
+	to := ethCommon.HexToAddress("0x2000000000000000000000000000000000000002")
+	data, err := c.Pack("transfer", to, big.NewInt(42))
+	require.NoError(err, "Pack")
+	require.Len(data, 4+2*32)
+	require.EqualValues(crypto.Keccak256([]byte("transfer(address,uint256)"))[:4], data[:4])
+
+	_, err = c.Pack("transfer", to)
+	require.Error(err, "Pack should fail with missing arguments")
+	_, err = c.Pack("nonExistent")
+	require.Error(err, "Pack should fail for unknown methods")
+
+	// Return values must be decoded.
+	encodedName, err := abi.Arguments{{Type: mustType("string")}}.Pack("Test")
+	require.NoError(err)
+	mock.result = encodedName
+	out, err := c.SimulateCall(context.Background(), client.RoundLatest, nil, "name")
+	require.NoError(err, "SimulateCall")
+	require.EqualValues([]interface{}{"Test"}, out)
+	require.EqualValues(c.Address(), mock.query.Address)
+	require.EqualValues(DefaultSimulateCallGasLimit, mock.query.GasLimit)
+	require.Len(mock.query.Caller, 20)
+	require.Len(mock.query.Value, 32)
+	require.Len(mock.query.GasPrice, 32)
+
+	var name string
+	err = c.UnpackInto(&name, "name", encodedName)
+	require.NoError(err, "UnpackInto")
+	require.Equal("Test", name)
+
+	opts := &CallOptions{GasLimit: 1000, Caller: to.Bytes()}
+	mock.result = ethCommon.LeftPadBytes(big.NewInt(1000).Bytes(), 32)
+	out, err = c.SimulateCall(context.Background(), client.RoundLatest, opts, "balanceOf", to)
+	require.NoError(err, "SimulateCall")
+	require.EqualValues(big.NewInt(1000), out[0])
+	require.EqualValues(1000, mock.query.GasLimit)
+	require.EqualValues(to.Bytes(), mock.query.Caller)
+}
+
+func TestContractEvents(t *testing.T) {
+	require := require.New(t)
+
+	c := loadTestContract(t, nil)
+	from := ethCommon.HexToAddress("0x3000000000000000000000000000000000000003")
+	to := ethCommon.HexToAddress("0x4000000000000000000000000000000000000004")
+	value, err := abi.Arguments{{Type: mustType("uint256")}}.Pack(big.NewInt(1234))
+	require.NoError(err)
+
+	ev := &Event{
+		Address: c.Address(),
+		Topics: [][]byte{
+			crypto.Keccak256([]byte("Transfer(address,address,uint256)")),
+			ethCommon.LeftPadBytes(from.Bytes(), 32),
+			ethCommon.LeftPadBytes(to.Bytes(), 32),
+		},
+		Data: value,
+	}
+
+	decoded, err := c.DecodeEvent(ev)
+	require.NoError(err, "DecodeEvent")
+	require.NotNil(decoded)
+	require.Equal("Transfer", decoded.Name)
+	require.Equal("Transfer(address,address,uint256)", decoded.Signature)
+	require.EqualValues(map[string]interface{}{
+		"from":  from,
+		"to":    to,
+		"value": big.NewInt(1234),
+	}, decoded.Fields)
+
+	var transfer struct {
+		From  ethCommon.Address
+		To    ethCommon.Address
+		Value *big.Int
+	}
+	err = c.UnpackEvent(&transfer, "Transfer", ev)
+	require.NoError(err, "UnpackEvent")
+	require.Equal(from, transfer.From)
+	require.Equal(to, transfer.To)
+	require.EqualValues(big.NewInt(1234), transfer.Value)
+
+	err = c.UnpackEvent(&transfer, "Memo", ev)
+	require.Error(err, "UnpackEvent should fail for a different event")
+
+	// Events emitted by other contracts or not in the ABI should be ignored.
+	other := *ev
+	other.Address = to.Bytes()
+	decoded, err = c.DecodeEvent(&other)
+	require.NoError(err, "DecodeEvent")
+	require.Nil(decoded)
+	err = c.UnpackEvent(&transfer, "Transfer", &other)
+	require.Error(err, "UnpackEvent should fail for events emitted by other contracts")
+
+	unknown := *ev
+	unknown.Topics = [][]byte{crypto.Keccak256([]byte("Unknown()"))}
+	decoded, err = c.DecodeEvent(&unknown)
+	require.NoError(err, "DecodeEvent")
+	require.Nil(decoded)
+
+	// Malformed event data should be rejected.
+	malformed := *ev
+	malformed.Data = []byte{1, 2, 3}
+	_, err = c.DecodeEvent(&malformed)
+	require.Error(err, "DecodeEvent should fail for malformed events")
+}
+
+func mustType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
@@ -0,0 +1,115 @@
+package evm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/helpers"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// CreateAddress returns the address of the contract created via CREATE by the given caller when
+// submitting a transaction with the given nonce.
+func CreateAddress(caller []byte, nonce uint64) []byte {
+	return crypto.CreateAddress(ethCommon.BytesToAddress(caller), nonce).Bytes()
+}
+
+// Create2Address returns the address of the contract created via CREATE2 by the given caller
+// using the given salt and init code.
+func Create2Address(caller []byte, salt [32]byte, initCode []byte) []byte {
+	return crypto.CreateAddress2(ethCommon.BytesToAddress(caller), salt, crypto.Keccak256(initCode)).Bytes()
+}
+
+// NativeAddress returns the native address corresponding to the given EVM address.
+func NativeAddress(address []byte) types.Address {
+	return types.NewAddressRaw(types.AddressV0Secp256k1EthContext, address)
+}
+
+// CallerAddress returns the EVM address of the caller that signed the given transaction.
+func CallerAddress(tx *types.Transaction) ([]byte, error) {
+	if len(tx.AuthInfo.SignerInfo) == 0 {
+		return nil, fmt.Errorf("transaction has no signers")
+	}
+	spec := tx.AuthInfo.SignerInfo[0].AddressSpec.Signature
+	if spec == nil || spec.Secp256k1Eth == nil {
+		return nil, fmt.Errorf("caller is not an Ethereum-compatible secp256k1 account")
+	}
+	return ethCommon.HexToAddress(helpers.EthAddressFromPubKey(*spec.Secp256k1Eth)).Bytes(), nil
+}
+
+// Deployment is a deployed EVM contract.
+type Deployment struct {
+	// Address is the EVM address of the contract.
+	Address []byte
+	// Hex is the checksummed hex-encoded EVM address of the contract.
+	Hex string
+	// NativeAddress is the native address corresponding to the contract address.
+	NativeAddress types.Address
+	// Round is the round in which the contract has been deployed.
+	Round uint64
+}
+
+// NewDeployment returns the deployment information for the given EVM contract address.
+func NewDeployment(address []byte, round uint64) *Deployment {
+	return &Deployment{
+		Address:       address,
+		Hex:           ethCommon.BytesToAddress(address).Hex(),
+		NativeAddress: NativeAddress(address),
+		Round:         round,
+	}
+}
+
+// Deploy submits the given signed CREATE transaction (e.g. as returned by V1.Create) and waits for
+// it to be included in a block.
+//
+// The contract address is predicted from the caller and nonce of the transaction and checked
+// against the address returned by the EVM module. Then the deployed code is queried and compared
+// against the expected runtime bytecode. In case runtimeCode is nil, only the presence of code is
+// verified.
+func Deploy(ctx context.Context, e V1, tb *client.TransactionBuilder, runtimeCode []byte) (*Deployment, error) {
+	tx := tb.GetTransaction()
+	if tx.Call.Method != methodCreate {
+		return nil, fmt.Errorf("evm: not a create transaction: %s", tx.Call.Method)
+	}
+	caller, err := CallerAddress(tx)
+	if err != nil {
+		return nil, fmt.Errorf("evm: %w", err)
+	}
+	expected := CreateAddress(caller, tx.AuthInfo.SignerInfo[0].Nonce)
+
+	var address []byte
+	meta, err := tb.SubmitTxMeta(ctx, &address)
+	switch {
+	case err != nil:
+		if rev := DecodeRevert(err, nil); rev != nil {
+			return nil, rev
+		}
+		return nil, err
+	case meta.CheckTxError != nil:
+		return nil, fmt.Errorf("evm: transaction check failed: module: %s code: %d message: %s",
+			meta.CheckTxError.Module,
+			meta.CheckTxError.Code,
+			meta.CheckTxError.Message,
+		)
+	case !bytes.Equal(address, expected):
+		return nil, fmt.Errorf("evm: contract created at unexpected address (expected: %X got: %X)", expected, address)
+	}
+
+	code, err := e.Code(ctx, meta.Round, address)
+	if err != nil {
+		return nil, fmt.Errorf("evm: failed to query deployed code: %w", err)
+	}
+	switch {
+	case len(code) == 0:
+		return nil, fmt.Errorf("evm: no code deployed at %X", address)
+	case runtimeCode != nil && !bytes.Equal(code, runtimeCode):
+		return nil, fmt.Errorf("evm: deployed code at %X does not match expected runtime code", address)
+	}
+
+	return NewDeployment(address, meta.Round), nil
+}
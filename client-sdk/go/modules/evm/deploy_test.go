@@ -0,0 +1,99 @@
+package evm
+
+import (
+	"context"
+	"testing"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing/mock"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestCreateAddress(t *testing.T) {
+	require := require.New(t)
+
+	caller := ethCommon.HexToAddress("0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0").Bytes()
+	for _, tc := range []struct {
+		nonce    uint64
+		expected string
+	}{
+		{0, "0xcd234a471b72ba2f1ccf0a70fcaba648a5eecd8d"},
+		{1, "0x343c43a37d37dff08ae8c4a11544c718abb4fcf8"},
+		{2, "0xf778b86fa74e846c4f0a1fbd1335fe81c00a0c91"},
+	} {
+		require.Equal(ethCommon.HexToAddress(tc.expected).Bytes(), CreateAddress(caller, tc.nonce), "nonce %d", tc.nonce)
+	}
+
+	// Test vectors from EIP-1014.
+	for _, tc := range []struct {
+		caller   string
+		salt     string
+		initCode []byte
+		expected string
+	}{
+		{"0x0000000000000000000000000000000000000000", "0x00", []byte{0x00}, "0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38"},
+		{"0xdeadbeef00000000000000000000000000000000", "0x00", []byte{0x00}, "0xB928f69Bb1D91Cd65274e3c79d8986362984fDA3"},
+		{"0x00000000000000000000000000000000deadbeef", "0xcafebabe", ethCommon.FromHex("0xdeadbeef"), "0x60f3f640a8508fC6a86d45DF051962668E1e8AC7"},
+	} {
+		salt := ethCommon.HexToHash(tc.salt)
+		addr := Create2Address(ethCommon.HexToAddress(tc.caller).Bytes(), salt, tc.initCode)
+		require.Equal(ethCommon.HexToAddress(tc.expected).Bytes(), addr, "caller %s", tc.caller)
+	}
+}
+
+func TestDeploy(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	runtimeCode := []byte{0x60, 0x80, 0x60, 0x40}
+	rc := mock.NewRuntimeClient(common.NewTestNamespaceFromSeed([]byte("evm deploy test"), 0), "test chain context")
+	rc.SetQueryHandler(methodCode, func(round uint64, args cbor.RawMessage) (interface{}, error) {
+		return runtimeCode, nil
+	})
+
+	deploy := func(nonce uint64, createdAddress []byte, expectedCode []byte) (*Deployment, error) {
+		rc.SetSubmitHandler(func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+			return &types.CallResult{Ok: cbor.Marshal(createdAddress)}, nil
+		})
+
+		tb := NewV1(rc).Create(make([]byte, 32), []byte{0xde, 0xad}).
+			SetFeeGas(100_000).
+			AppendAuthSignature(sdkTesting.Dave.SigSpec, nonce)
+		err := tb.AppendSign(ctx, sdkTesting.Dave.Signer)
+		require.NoError(err, "AppendSign")
+		return Deploy(ctx, NewV1(rc), tb, expectedCode)
+	}
+
+	expected := CreateAddress(sdkTesting.Dave.EthAddress[:], 5)
+	dep, err := deploy(5, expected, runtimeCode)
+	require.NoError(err, "Deploy")
+	require.Equal(expected, dep.Address)
+	require.Equal(ethCommon.BytesToAddress(expected).Hex(), dep.Hex)
+	require.Equal(types.NewAddressRaw(types.AddressV0Secp256k1EthContext, expected), dep.NativeAddress)
+	require.EqualValues(1, dep.Round)
+
+	dep, err = deploy(6, CreateAddress(sdkTesting.Dave.EthAddress[:], 6), nil)
+	require.NoError(err, "Deploy without expected code")
+	require.EqualValues(2, dep.Round)
+
+	_, err = deploy(7, expected, runtimeCode)
+	require.Error(err, "Deploy should fail on address mismatch")
+
+	_, err = deploy(8, CreateAddress(sdkTesting.Dave.EthAddress[:], 8), []byte{0x00})
+	require.Error(err, "Deploy should fail on code mismatch")
+
+	// Only CREATE transactions signed by secp256k1 accounts are supported.
+	tb := NewV1(rc).Call(expected, nil, nil).AppendAuthSignature(sdkTesting.Dave.SigSpec, 0)
+	_, err = Deploy(ctx, NewV1(rc), tb, nil)
+	require.Error(err)
+
+	tb = NewV1(rc).Create(nil, nil).AppendAuthSignature(sdkTesting.Alice.SigSpec, 0)
+	_, err = Deploy(ctx, NewV1(rc), tb, nil)
+	require.Error(err)
+}
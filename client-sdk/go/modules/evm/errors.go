@@ -0,0 +1,207 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	coreErrors "github.com/oasisprotocol/oasis-core/go/common/errors"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// ErrCodeReverted is the error code used by the EVM module for reverted calls.
+const ErrCodeReverted = 8
+
+const (
+	revertedPrefix      = "reverted: "
+	noRevertReason      = "no revert reason"
+	invalidReasonPrefix = "invalid reason prefix: '"
+	invalidReasonLength = "invalid reason length: '"
+)
+
+var (
+	errorStringSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicSelector       = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+
+	// panicReasons are the descriptions of the Solidity panic codes.
+	panicReasons = map[uint64]string{
+		0x00: "generic panic",
+		0x01: "assert(false)",
+		0x11: "arithmetic underflow or overflow",
+		0x12: "division or modulo by zero",
+		0x21: "enum overflow",
+		0x22: "invalid encoded storage byte array accessed",
+		0x31: "out-of-bounds array access; popping on an empty array",
+		0x32: "out-of-bounds access of an array or bytesN",
+		0x41: "out of memory",
+		0x51: "uninitialized function",
+	}
+)
+
+// RevertError is the error returned when an EVM call has been reverted.
+//
+// Depending on the revert data, one of Reason, PanicCode or CustomError is set. In case the revert
+// data could not be decoded, only Data is set.
+type RevertError struct {
+	// Reason is the revert reason passed to Error(string) (e.g. via require or revert).
+	Reason string
+	// PanicCode is the Solidity panic code passed to Panic(uint256) (e.g. via assert or an
+	// arithmetic overflow).
+	PanicCode *big.Int
+	// CustomError is the ABI-defined custom error that has been raised.
+	CustomError *abi.Error
+	// Args are the decoded arguments of the custom error.
+	Args []interface{}
+	// Data is the raw revert data if available.
+	//
+	// Note that the EVM module only returns the raw revert data in case it is not an Error(string)
+	// revert reason.
+	Data []byte
+
+	cause error
+}
+
+// Error returns the string representation of the revert error.
+func (e *RevertError) Error() string {
+	switch {
+	case e.CustomError != nil:
+		args := make([]string, 0, len(e.Args))
+		for i, arg := range e.Args {
+			args = append(args, fmt.Sprintf("%s: %v", e.CustomError.Inputs[i].Name, arg))
+		}
+		return fmt.Sprintf("execution reverted: %s(%s)", e.CustomError.Name, strings.Join(args, ", "))
+	case e.PanicCode != nil:
+		reason := "unknown panic code"
+		if e.PanicCode.IsUint64() {
+			if r, ok := panicReasons[e.PanicCode.Uint64()]; ok {
+				reason = r
+			}
+		}
+		return fmt.Sprintf("execution reverted: panic: %s (0x%x)", reason, e.PanicCode)
+	case e.Reason != "":
+		return fmt.Sprintf("execution reverted: %s", e.Reason)
+	case len(e.Data) > 0:
+		return fmt.Sprintf("execution reverted: %s", hexutil.Encode(e.Data))
+	default:
+		return "execution reverted"
+	}
+}
+
+// Unwrap returns the underlying error as returned by the EVM module.
+func (e *RevertError) Unwrap() error {
+	return e.cause
+}
+
+// IsPanic returns true iff the revert has been caused by a Solidity panic.
+func (e *RevertError) IsPanic() bool {
+	return e.PanicCode != nil
+}
+
+// DecodeRevertData decodes raw EVM revert data.
+//
+// Custom errors are decoded using the given contract ABI which may be nil in case it is not
+// available.
+func DecodeRevertData(data []byte, contractABI *abi.ABI) *RevertError {
+	rev := &RevertError{Data: data}
+	if len(data) < 4 {
+		return rev
+	}
+
+	selector, args := data[:4], data[4:]
+	switch {
+	case bytes.Equal(selector, errorStringSelector):
+		if reason, err := abi.UnpackRevert(data); err == nil {
+			rev.Reason = reason
+		}
+	case bytes.Equal(selector, panicSelector):
+		typ, _ := abi.NewType("uint256", "", nil)
+		if out, err := (abi.Arguments{{Type: typ}}).Unpack(args); err == nil {
+			rev.PanicCode = out[0].(*big.Int)
+		}
+	case contractABI != nil:
+		for _, abiError := range contractABI.Errors {
+			if !bytes.Equal(selector, abiError.ID[:4]) {
+				continue
+			}
+			if out, err := abiError.Inputs.Unpack(args); err == nil {
+				customError := abiError
+				rev.CustomError = &customError
+				rev.Args = out
+			}
+			break
+		}
+	}
+	return rev
+}
+
+// DecodeRevert extracts and decodes the revert reason from an error returned by a failed EVM call
+// transaction (a types.FailedCallResult) or by a failed simulated call.
+//
+// Custom errors are decoded using the given contract ABI which may be nil in case it is not
+// available. In case the error is not caused by a reverted EVM call, nil is returned.
+func DecodeRevert(err error, contractABI *abi.ABI) *RevertError {
+	var (
+		module      string
+		code        uint32
+		message     string
+		failed      *types.FailedCallResult
+		failedValue types.FailedCallResult
+	)
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &failed):
+		module, code, message = failed.Module, failed.Code, failed.Message
+	case errors.As(err, &failedValue):
+		module, code, message = failedValue.Module, failedValue.Code, failedValue.Message
+	default:
+		module, code = coreErrors.Code(err)
+		message = err.Error()
+	}
+	if module != ModuleName || code != ErrCodeReverted {
+		return nil
+	}
+
+	// Extract the revert reason as formatted by the EVM module.
+	if idx := strings.Index(message, revertedPrefix); idx >= 0 {
+		message = message[idx+len(revertedPrefix):]
+	}
+
+	var rev *RevertError
+	switch {
+	case message == noRevertReason:
+		rev = &RevertError{}
+	default:
+		rev = &RevertError{Reason: message}
+		if data, ok := decodeRawRevertData(message); ok {
+			rev = DecodeRevertData(data, contractABI)
+		}
+	}
+	rev.cause = err
+	return rev
+}
+
+// decodeRawRevertData extracts the raw revert data which the EVM module returns Base64-encoded in
+// case it is not a well-formed Error(string) revert reason.
+func decodeRawRevertData(message string) ([]byte, bool) {
+	for _, prefix := range []string{invalidReasonPrefix, invalidReasonLength} {
+		if !strings.HasPrefix(message, prefix) || !strings.HasSuffix(message, "'") {
+			continue
+		}
+		encoded := strings.TrimSuffix(strings.TrimPrefix(message, prefix), "'")
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, false
+		}
+		return data, true
+	}
+	return nil, false
+}
@@ -0,0 +1,125 @@
+package evm
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/require"
+
+	coreErrors "github.com/oasisprotocol/oasis-core/go/common/errors"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestDecodeRevert(t *testing.T) {
+	require := require.New(t)
+
+	c := loadTestContract(t, nil)
+	customErrorData, err := c.ABI().Errors["InsufficientBalance"].Inputs.Pack(big.NewInt(1), big.NewInt(2))
+	require.NoError(err)
+	customErrorID := c.ABI().Errors["InsufficientBalance"].ID
+	customErrorData = withSelector(customErrorID[:4], customErrorData)
+	panicData, err := abi.Arguments{{Type: mustType("uint256")}}.Pack(big.NewInt(0x11))
+	require.NoError(err)
+	panicData = withSelector(panicSelector, panicData)
+	errorStringData, err := abi.Arguments{{Type: mustType("string")}}.Pack("bad things")
+	require.NoError(err)
+	errorStringData = withSelector(errorStringSelector, errorStringData)
+
+	rawReason := func(data []byte) string {
+		return fmt.Sprintf("reverted: invalid reason prefix: '%s'", base64.StdEncoding.EncodeToString(data))
+	}
+
+	for _, tc := range []struct {
+		err         error
+		contractABI *abi.ABI
+		expected    string
+		check       func(rev *RevertError)
+	}{
+		{nil, nil, "", nil},
+		{fmt.Errorf("some other error"), nil, "", nil},
+		{&types.FailedCallResult{Module: "evm", Code: 2, Message: "execution failed: out of gas"}, nil, "", nil},
+		{&types.FailedCallResult{Module: "accounts", Code: ErrCodeReverted, Message: "reverted: foo"}, nil, "", nil},
+		{
+			&types.FailedCallResult{Module: "evm", Code: ErrCodeReverted, Message: "reverted: no revert reason"},
+			nil,
+			"execution reverted",
+			nil,
+		},
+		{
+			&types.FailedCallResult{Module: "evm", Code: ErrCodeReverted, Message: "reverted: bad things"},
+			nil,
+			"execution reverted: bad things",
+			func(rev *RevertError) { require.Equal("bad things", rev.Reason) },
+		},
+		{
+			types.FailedCallResult{Module: "evm", Code: ErrCodeReverted, Message: rawReason(panicData)},
+			nil,
+			"execution reverted: panic: arithmetic underflow or overflow (0x11)",
+			func(rev *RevertError) {
+				require.True(rev.IsPanic())
+				require.EqualValues(big.NewInt(0x11), rev.PanicCode)
+			},
+		},
+		{
+			&types.FailedCallResult{Module: "evm", Code: ErrCodeReverted, Message: rawReason(customErrorData)},
+			c.ABI(),
+			"execution reverted: InsufficientBalance(available: 1, required: 2)",
+			func(rev *RevertError) {
+				require.Equal("InsufficientBalance", rev.CustomError.Name)
+				require.EqualValues([]interface{}{big.NewInt(1), big.NewInt(2)}, rev.Args)
+			},
+		},
+		{
+			// Custom errors cannot be decoded without the ABI.
+			&types.FailedCallResult{Module: "evm", Code: ErrCodeReverted, Message: rawReason(customErrorData)},
+			nil,
+			"execution reverted: 0x" + fmt.Sprintf("%x", customErrorData),
+			func(rev *RevertError) {
+				require.Nil(rev.CustomError)
+				require.EqualValues(customErrorData, rev.Data)
+			},
+		},
+		{
+			// Simulated calls return coded errors.
+			coreErrors.FromCode("evm", ErrCodeReverted, rawReason(errorStringData)),
+			nil,
+			"execution reverted: bad things",
+			nil,
+		},
+		{
+			fmt.Errorf("wrapped: %w", coreErrors.FromCode("evm", ErrCodeReverted, "reverted: bad things")),
+			nil,
+			"execution reverted: bad things",
+			nil,
+		},
+	} {
+		rev := DecodeRevert(tc.err, tc.contractABI)
+		if tc.expected == "" {
+			require.Nil(rev, "%v", tc.err)
+			continue
+		}
+		require.NotNil(rev, "%v", tc.err)
+		require.Equal(tc.expected, rev.Error())
+		require.True(errors.Is(rev, tc.err) || rev.Unwrap() == tc.err, "revert error should wrap the cause")
+		if tc.check != nil {
+			tc.check(rev)
+		}
+	}
+
+	// Contract helpers should decode custom errors.
+	cause := &types.FailedCallResult{Module: "evm", Code: ErrCodeReverted, Message: rawReason(customErrorData)}
+	var rev *RevertError
+	require.True(errors.As(c.DecodeError(cause), &rev))
+	require.NotNil(rev.CustomError)
+	other := fmt.Errorf("other")
+	require.Equal(other, c.DecodeError(other))
+}
+
+func withSelector(selector, data []byte) []byte {
+	return append(append([]byte{}, selector...), data...)
+}
@@ -0,0 +1,553 @@
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Storage encodings used in Solidity storage layouts.
+const (
+	StorageEncodingInplace      = "inplace"
+	StorageEncodingMapping      = "mapping"
+	StorageEncodingDynamicArray = "dynamic_array"
+	StorageEncodingBytes        = "bytes"
+)
+
+// slotSize is the size of a single storage slot in bytes.
+const slotSize = 32
+
+// StorageLayout is the storage layout of a Solidity contract as generated by the compiler (e.g.
+// via solc --storage-layout).
+type StorageLayout struct {
+	// Storage are the state variables of the contract.
+	Storage []*StorageItem `json:"storage"`
+	// Types are the types of the state variables, indexed by type identifier.
+	Types map[string]*StorageType `json:"types"`
+}
+
+// StorageItem is a state variable or a struct member.
+type StorageItem struct {
+	// Label is the name of the state variable or member.
+	Label string `json:"label"`
+	// Slot is the (decimal) storage slot where the item starts. For struct members it is relative
+	// to the slot of the struct.
+	Slot string `json:"slot"`
+	// Offset is the byte offset of the item within the slot.
+	Offset int `json:"offset"`
+	// Type is the type identifier of the item.
+	Type string `json:"type"`
+}
+
+// StorageType is a type of a state variable.
+type StorageType struct {
+	// Encoding is the storage encoding of the type.
+	Encoding string `json:"encoding"`
+	// Label is the canonical type name.
+	Label string `json:"label"`
+	// NumberOfBytes is the (decimal) number of bytes used by the type.
+	NumberOfBytes string `json:"numberOfBytes"`
+	// Key is the key type identifier of mappings.
+	Key string `json:"key,omitempty"`
+	// Value is the value type identifier of mappings.
+	Value string `json:"value,omitempty"`
+	// Base is the element type identifier of arrays.
+	Base string `json:"base,omitempty"`
+	// Members are the members of structs.
+	Members []*StorageItem `json:"members,omitempty"`
+}
+
+// ParseStorageLayout parses a Solidity storage layout in JSON form.
+func ParseStorageLayout(data []byte) (*StorageLayout, error) {
+	var layout StorageLayout
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return nil, fmt.Errorf("evm: malformed storage layout: %w", err)
+	}
+	if err := layout.validate(); err != nil {
+		return nil, fmt.Errorf("evm: malformed storage layout: %w", err)
+	}
+	return &layout, nil
+}
+
+func (l *StorageLayout) validate() error {
+	validateItems := func(items []*StorageItem) error {
+		for _, item := range items {
+			if _, err := parseSlot(item.Slot); err != nil {
+				return fmt.Errorf("item '%s': %w", item.Label, err)
+			}
+			if item.Offset < 0 || item.Offset >= slotSize {
+				return fmt.Errorf("item '%s': bad offset: %d", item.Label, item.Offset)
+			}
+			if _, ok := l.Types[item.Type]; !ok {
+				return fmt.Errorf("item '%s': unknown type: %s", item.Label, item.Type)
+			}
+		}
+		return nil
+	}
+	if err := validateItems(l.Storage); err != nil {
+		return err
+	}
+	for id, typ := range l.Types {
+		if _, err := typ.size(); err != nil {
+			return fmt.Errorf("type '%s': %w", id, err)
+		}
+		for _, ref := range []string{typ.Key, typ.Value, typ.Base} {
+			if _, ok := l.Types[ref]; ref != "" && !ok {
+				return fmt.Errorf("type '%s': unknown type: %s", id, ref)
+			}
+		}
+		switch typ.Encoding {
+		case StorageEncodingInplace, StorageEncodingBytes:
+		case StorageEncodingMapping:
+			if typ.Key == "" || typ.Value == "" {
+				return fmt.Errorf("type '%s': mapping without key or value type", id)
+			}
+		case StorageEncodingDynamicArray:
+			if typ.Base == "" {
+				return fmt.Errorf("type '%s': array without base type", id)
+			}
+		default:
+			return fmt.Errorf("type '%s': unsupported encoding: %s", id, typ.Encoding)
+		}
+		if err := validateItems(typ.Members); err != nil {
+			return fmt.Errorf("type '%s': %w", id, err)
+		}
+	}
+	return nil
+}
+
+func (t *StorageType) size() (int, error) {
+	size, err := strconv.Atoi(t.NumberOfBytes)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("bad size: %s", t.NumberOfBytes)
+	}
+	return size, nil
+}
+
+// staticArrayLength returns the length of a statically-sized array or -1 in case the type is not
+// a statically-sized array.
+func (t *StorageType) staticArrayLength() int {
+	if t.Encoding != StorageEncodingInplace || t.Base == "" {
+		return -1
+	}
+	// The outermost array dimension is the last one (e.g. uint8[2][3] is an array of 3 elements).
+	idx := strings.LastIndex(t.Label, "[")
+	if idx < 0 || !strings.HasSuffix(t.Label, "]") {
+		return -1
+	}
+	n, err := strconv.Atoi(t.Label[idx+1 : len(t.Label)-1])
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+func parseSlot(slot string) (*big.Int, error) {
+	s, ok := new(big.Int).SetString(slot, 10)
+	if !ok || s.Sign() < 0 || s.BitLen() > 256 {
+		return nil, fmt.Errorf("bad slot: %s", slot)
+	}
+	return s, nil
+}
+
+// DefaultStorageMaxElements is the default maximum number of array elements read by a single
+// StorageInspector.Read call.
+const DefaultStorageMaxElements = 1024
+
+// StorageInspector reads typed state variables of a deployed contract using its storage layout.
+type StorageInspector struct {
+	evm         V1
+	address     []byte
+	layout      *StorageLayout
+	maxElements uint64
+}
+
+// NewStorageInspector creates a new storage inspector for the contract at the given address.
+func NewStorageInspector(evm V1, address []byte, layout *StorageLayout) *StorageInspector {
+	return &StorageInspector{
+		evm:         evm,
+		address:     address,
+		layout:      layout,
+		maxElements: DefaultStorageMaxElements,
+	}
+}
+
+// SetMaxElements sets the maximum number of array elements, including elements of nested arrays,
+// read by a single Read call. Reading larger arrays fails.
+func (si *StorageInspector) SetMaxElements(max uint64) *StorageInspector {
+	si.maxElements = max
+	return si
+}
+
+// Variables returns the names of all state variables.
+func (si *StorageInspector) Variables() []string {
+	names := make([]string, 0, len(si.layout.Storage))
+	for _, item := range si.layout.Storage {
+		names = append(names, item.Label)
+	}
+	return names
+}
+
+// Read reads the value at the given path at the given round.
+//
+// The path starts with the name of a state variable, followed by any number of mapping keys or
+// array indices in brackets and struct member selectors (e.g. balances[0x5FbDB...].amount or
+// matrix[1][2]). Mapping keys are given in the same form as accepted by ParseArg.
+//
+// Values are returned as follows:
+//   - integers and enums as *big.Int
+//   - booleans as bool
+//   - addresses and contracts as common.Address
+//   - fixed-size byte arrays and bytes as []byte
+//   - strings as string
+//   - arrays as []interface{}
+//   - structs as map[string]interface{}
+//
+// Mappings cannot be read as a whole and are returned as nil when part of a struct. Array
+// lengths are read from contract storage so the number of array elements read is limited (see
+// SetMaxElements).
+func (si *StorageInspector) Read(ctx context.Context, round uint64, path string) (interface{}, error) {
+	r := &storageReader{
+		si:    si,
+		ctx:   ctx,
+		round: round,
+		slots: make(map[string][]byte),
+	}
+	loc, err := r.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	if loc.typ.Encoding == StorageEncodingMapping {
+		return nil, fmt.Errorf("evm: mapping '%s' requires a key", path)
+	}
+	return r.read(loc)
+}
+
+// storageLocation is the location of a value in storage.
+type storageLocation struct {
+	slot   *big.Int
+	offset int
+	typ    *StorageType
+}
+
+type storageReader struct {
+	si    *StorageInspector
+	ctx   context.Context
+	round uint64
+
+	// slots caches the slots read so far.
+	slots map[string][]byte
+	// elements is the number of array elements read so far.
+	elements uint64
+}
+
+func (r *storageReader) readSlot(slot *big.Int) ([]byte, error) {
+	key := slot.String()
+	if value, ok := r.slots[key]; ok {
+		return value, nil
+	}
+	value, err := r.si.evm.Storage(r.ctx, r.round, r.si.address, ethCommon.BigToHash(slot).Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("evm: failed to read storage slot %s: %w", key, err)
+	}
+	value = ethCommon.LeftPadBytes(value, slotSize)
+	r.slots[key] = value
+	return value, nil
+}
+
+func (r *storageReader) itemLocation(base *big.Int, item *StorageItem) *storageLocation {
+	slot, _ := parseSlot(item.Slot)
+	return &storageLocation{
+		slot:   slot.Add(slot, base),
+		offset: item.Offset,
+		typ:    r.si.layout.Types[item.Type],
+	}
+}
+
+// resolve resolves the given path into a storage location.
+func (r *storageReader) resolve(path string) (*storageLocation, error) {
+	name := path
+	if idx := strings.IndexAny(path, "[."); idx >= 0 {
+		name = path[:idx]
+	}
+	var loc *storageLocation
+	for _, item := range r.si.layout.Storage {
+		if item.Label == name {
+			loc = r.itemLocation(new(big.Int), item)
+			break
+		}
+	}
+	if loc == nil {
+		return nil, fmt.Errorf("evm: unknown state variable '%s'", name)
+	}
+
+	rest := path[len(name):]
+	for len(rest) > 0 {
+		var err error
+		switch rest[0] {
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("evm: malformed path '%s': unterminated key", path)
+			}
+			if loc, err = r.index(loc, rest[1:end]); err != nil {
+				return nil, err
+			}
+			rest = rest[end+1:]
+		case '.':
+			member := rest[1:]
+			if idx := strings.IndexAny(member, "[."); idx >= 0 {
+				member = member[:idx]
+			}
+			if loc, err = r.member(loc, member); err != nil {
+				return nil, err
+			}
+			rest = rest[1+len(member):]
+		default:
+			return nil, fmt.Errorf("evm: malformed path '%s'", path)
+		}
+	}
+	return loc, nil
+}
+
+// index resolves a mapping key or an array index.
+func (r *storageReader) index(loc *storageLocation, key string) (*storageLocation, error) {
+	types := r.si.layout.Types
+	switch {
+	case loc.typ.Encoding == StorageEncodingMapping:
+		encodedKey, err := encodeMappingKey(types[loc.typ.Key], key)
+		if err != nil {
+			return nil, fmt.Errorf("evm: bad key '%s' for %s: %w", key, loc.typ.Label, err)
+		}
+		slot := crypto.Keccak256(encodedKey, ethCommon.BigToHash(loc.slot).Bytes())
+		return &storageLocation{
+			slot: new(big.Int).SetBytes(slot),
+			typ:  types[loc.typ.Value],
+		}, nil
+	case loc.typ.Encoding == StorageEncodingDynamicArray || loc.typ.staticArrayLength() >= 0:
+		index, ok := new(big.Int).SetString(key, 0)
+		if !ok || index.Sign() < 0 {
+			return nil, fmt.Errorf("evm: bad index '%s' for %s", key, loc.typ.Label)
+		}
+		length, err := r.arrayLength(loc)
+		if err != nil {
+			return nil, err
+		}
+		if !index.IsUint64() || index.Uint64() >= length {
+			return nil, fmt.Errorf("evm: index %s out of bounds for %s of length %d", index, loc.typ.Label, length)
+		}
+		return r.element(loc, index.Uint64())
+	default:
+		return nil, fmt.Errorf("evm: cannot index into %s", loc.typ.Label)
+	}
+}
+
+// member resolves a struct member.
+func (r *storageReader) member(loc *storageLocation, name string) (*storageLocation, error) {
+	for _, item := range loc.typ.Members {
+		if item.Label == name {
+			return r.itemLocation(loc.slot, item), nil
+		}
+	}
+	return nil, fmt.Errorf("evm: %s has no member '%s'", loc.typ.Label, name)
+}
+
+// arrayLength returns the length of a statically- or dynamically-sized array.
+func (r *storageReader) arrayLength(loc *storageLocation) (uint64, error) {
+	if n := loc.typ.staticArrayLength(); n >= 0 {
+		return uint64(n), nil
+	}
+	value, err := r.readSlot(loc.slot)
+	if err != nil {
+		return 0, err
+	}
+	length := new(big.Int).SetBytes(value)
+	if !length.IsUint64() {
+		return 0, fmt.Errorf("evm: bad array length: %s", length)
+	}
+	return length.Uint64(), nil
+}
+
+// element returns the location of the given array element.
+func (r *storageReader) element(loc *storageLocation, index uint64) (*storageLocation, error) {
+	base := r.si.layout.Types[loc.typ.Base]
+	size, _ := base.size()
+
+	start := new(big.Int).Set(loc.slot)
+	if loc.typ.Encoding == StorageEncodingDynamicArray {
+		start.SetBytes(crypto.Keccak256(ethCommon.BigToHash(loc.slot).Bytes()))
+	}
+
+	elem := &storageLocation{typ: base}
+	switch {
+	case size < slotSize:
+		// Multiple elements are packed into a single slot.
+		perSlot := uint64(slotSize / size)
+		elem.slot = start.Add(start, new(big.Int).SetUint64(index/perSlot))
+		elem.offset = int(index%perSlot) * size
+	default:
+		slotsPerElem := uint64((size + slotSize - 1) / slotSize)
+		offset := new(big.Int).Mul(new(big.Int).SetUint64(index), new(big.Int).SetUint64(slotsPerElem))
+		elem.slot = start.Add(start, offset)
+	}
+	return elem, nil
+}
+
+// read reads the value at the given location.
+func (r *storageReader) read(loc *storageLocation) (interface{}, error) {
+	typ := loc.typ
+	switch {
+	case typ.Encoding == StorageEncodingMapping:
+		return nil, nil
+	case typ.Encoding == StorageEncodingBytes:
+		return r.readBytes(loc)
+	case typ.Encoding == StorageEncodingDynamicArray || typ.staticArrayLength() >= 0:
+		length, err := r.arrayLength(loc)
+		if err != nil {
+			return nil, err
+		}
+		// Array lengths come from contract storage so they must not be trusted.
+		if length > r.si.maxElements-r.elements {
+			return nil, fmt.Errorf("evm: %s of length %d exceeds the maximum number of elements (%d)", typ.Label, length, r.si.maxElements)
+		}
+		r.elements += length
+
+		var values []interface{}
+		for i := uint64(0); i < length; i++ {
+			elem, err := r.element(loc, i)
+			if err != nil {
+				return nil, err
+			}
+			v, err := r.read(elem)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	case len(typ.Members) > 0:
+		values := make(map[string]interface{})
+		for _, item := range typ.Members {
+			v, err := r.read(r.itemLocation(loc.slot, item))
+			if err != nil {
+				return nil, err
+			}
+			values[item.Label] = v
+		}
+		return values, nil
+	default:
+		size, _ := typ.size()
+		if loc.offset+size > slotSize {
+			return nil, fmt.Errorf("evm: value of type %s does not fit into a slot", typ.Label)
+		}
+		value, err := r.readSlot(loc.slot)
+		if err != nil {
+			return nil, err
+		}
+		// Values are packed starting with the lower-order bytes of the slot.
+		return decodeStorageValue(typ, value[slotSize-loc.offset-size:slotSize-loc.offset])
+	}
+}
+
+// readBytes reads a bytes or string value.
+func (r *storageReader) readBytes(loc *storageLocation) (interface{}, error) {
+	value, err := r.readSlot(loc.slot)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	switch value[slotSize-1] & 1 {
+	case 0:
+		// Short values (at most 31 bytes) are stored in the same slot together with their length.
+		length := int(value[slotSize-1] / 2)
+		if length >= slotSize {
+			return nil, fmt.Errorf("evm: bad short %s length: %d", loc.typ.Label, length)
+		}
+		data = value[:length]
+	default:
+		// Long values are stored in consecutive slots starting at keccak256(slot).
+		length := new(big.Int).SetBytes(value)
+		length.Rsh(length, 1)
+		if !length.IsUint64() || length.Uint64() > 1<<24 {
+			return nil, fmt.Errorf("evm: bad long %s length: %s", loc.typ.Label, length)
+		}
+		start := new(big.Int).SetBytes(crypto.Keccak256(ethCommon.BigToHash(loc.slot).Bytes()))
+		for remaining := length.Uint64(); remaining > 0; {
+			chunk, err := r.readSlot(start)
+			if err != nil {
+				return nil, err
+			}
+			n := uint64(slotSize)
+			if remaining < n {
+				n = remaining
+			}
+			data = append(data, chunk[:n]...)
+			remaining -= n
+			start = new(big.Int).Add(start, big.NewInt(1))
+		}
+	}
+
+	if loc.typ.Label == "string" {
+		return string(data), nil
+	}
+	return append([]byte{}, data...), nil
+}
+
+// decodeStorageValue decodes a value type from its packed storage representation.
+func decodeStorageValue(typ *StorageType, raw []byte) (interface{}, error) {
+	label := typ.Label
+	switch {
+	case label == "bool":
+		return raw[len(raw)-1] != 0, nil
+	case label == "address", label == "address payable", strings.HasPrefix(label, "contract "):
+		return ethCommon.BytesToAddress(raw), nil
+	case strings.HasPrefix(label, "uint"), strings.HasPrefix(label, "enum "):
+		return new(big.Int).SetBytes(raw), nil
+	case strings.HasPrefix(label, "int"):
+		v := new(big.Int).SetBytes(raw)
+		if len(raw) > 0 && raw[0]&0x80 != 0 {
+			// Two's complement.
+			v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(8*len(raw))))
+		}
+		return v, nil
+	case strings.HasPrefix(label, "bytes"):
+		return append([]byte{}, raw...), nil
+	default:
+		return nil, fmt.Errorf("evm: unsupported storage type: %s", label)
+	}
+}
+
+// encodeMappingKey encodes a mapping key for slot derivation.
+func encodeMappingKey(typ *StorageType, key string) ([]byte, error) {
+	label := typ.Label
+	switch {
+	case typ.Encoding == StorageEncodingBytes && label == "string":
+		// Dynamic keys are not padded.
+		return []byte(key), nil
+	case typ.Encoding == StorageEncodingBytes:
+		return decodeHex(key)
+	case strings.HasPrefix(label, "contract "), label == "address payable":
+		label = "address"
+	case strings.HasPrefix(label, "enum "):
+		label = "uint8"
+	}
+
+	abiType, err := abi.NewType(label, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported key type: %s", typ.Label)
+	}
+	v, err := ParseArg(abiType, key)
+	if err != nil {
+		return nil, err
+	}
+	// Value types are padded to 32 bytes in the same way as in the ABI encoding.
+	return abi.Arguments{{Type: abiType}}.Pack(v)
+}
@@ -0,0 +1,183 @@
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// storageOnlyV1 is a V1 implementation that only supports storage queries.
+type storageOnlyV1 struct {
+	V1
+
+	slots map[ethCommon.Hash][]byte
+}
+
+func (s *storageOnlyV1) Storage(ctx context.Context, round uint64, address []byte, index []byte) ([]byte, error) {
+	if v, ok := s.slots[ethCommon.BytesToHash(index)]; ok {
+		return v, nil
+	}
+	return make([]byte, 32), nil
+}
+
+func (s *storageOnlyV1) set(slot ethCommon.Hash, offset int, value []byte) {
+	v, ok := s.slots[slot]
+	if !ok {
+		v = make([]byte, 32)
+		s.slots[slot] = v
+	}
+	copy(v[32-offset-len(value):32-offset], value)
+}
+
+func slotAt(base ethCommon.Hash, n int64) ethCommon.Hash {
+	return ethCommon.BigToHash(new(big.Int).Add(base.Big(), big.NewInt(n)))
+}
+
+func TestStorageInspector(t *testing.T) {
+	require := require.New(t)
+
+	data, err := os.ReadFile("testdata/Layout.storage.json")
+	require.NoError(err, "ReadFile")
+	layout, err := ParseStorageLayout(data)
+	require.NoError(err, "ParseStorageLayout")
+
+	owner := ethCommon.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	pad := func(n int64) []byte { return ethCommon.BigToHash(big.NewInt(n)).Bytes() }
+	longName := strings.Repeat("bob", 14)
+	longData := []byte(strings.Repeat("\x42", 33))
+
+	s := &storageOnlyV1{slots: make(map[ethCommon.Hash][]byte)}
+	// uint256 total.
+	s.set(slotAt(ethCommon.Hash{}, 0), 0, pad(1000))
+	// uint8 a; bool b; address owner.
+	s.set(slotAt(ethCommon.Hash{}, 1), 0, []byte{7})
+	s.set(slotAt(ethCommon.Hash{}, 1), 1, []byte{1})
+	s.set(slotAt(ethCommon.Hash{}, 1), 2, owner.Bytes())
+	// mapping(address => uint256) balances.
+	s.set(crypto.Keccak256Hash(ethCommon.LeftPadBytes(owner.Bytes(), 32), pad(2)), 0, pad(500))
+	// uint16[] values.
+	s.set(slotAt(ethCommon.Hash{}, 3), 0, pad(3))
+	valuesBase := crypto.Keccak256Hash(pad(3))
+	for i := int64(0); i < 3; i++ {
+		s.set(valuesBase, int(2*i), []byte{0, byte(i + 1)})
+	}
+	// User[] users.
+	s.set(slotAt(ethCommon.Hash{}, 4), 0, pad(1))
+	usersBase := crypto.Keccak256Hash(pad(4))
+	s.set(usersBase, 0, ethCommon.LeftPadBytes([]byte{1}, 16))
+	s.set(usersBase, 16, ethCommon.LeftPadBytes([]byte{99}, 16))
+	s.set(slotAt(usersBase, 1), 0, append([]byte("alice"), make([]byte, 27)...))
+	s.slots[slotAt(usersBase, 1)][31] = 2 * 5
+	// mapping(string => User) byName.
+	bobBase := crypto.Keccak256Hash([]byte("bob"), pad(5))
+	s.set(bobBase, 0, []byte{2})
+	s.set(bobBase, 16, []byte{50})
+	s.set(slotAt(bobBase, 1), 0, pad(2*int64(len(longName))+1))
+	bobNameBase := crypto.Keccak256Hash(slotAt(bobBase, 1).Bytes())
+	s.set(bobNameBase, 0, []byte(longName[:32]))
+	s.set(slotAt(bobNameBase, 1), 0, append([]byte(longName[32:]), make([]byte, 32-len(longName[32:]))...))
+	// string greeting.
+	s.set(slotAt(ethCommon.Hash{}, 6), 0, append([]byte("hello"), make([]byte, 27)...))
+	s.slots[slotAt(ethCommon.Hash{}, 6)][31] = 2 * 5
+	// int32[3] fixedArr.
+	s.set(slotAt(ethCommon.Hash{}, 7), 0, []byte{0xff, 0xff, 0xff, 0xff})
+	s.set(slotAt(ethCommon.Hash{}, 7), 4, []byte{0, 0, 0, 2})
+	s.set(slotAt(ethCommon.Hash{}, 7), 8, []byte{0xff, 0xff, 0xff, 0xfd})
+	// bytes data.
+	s.set(slotAt(ethCommon.Hash{}, 8), 0, pad(2*int64(len(longData))+1))
+	dataBase := crypto.Keccak256Hash(pad(8))
+	s.set(dataBase, 0, longData[:32])
+	s.set(slotAt(dataBase, 1), 0, append(longData[32:], make([]byte, 31)...))
+
+	si := NewStorageInspector(s, owner.Bytes(), layout)
+	require.Equal([]string{"total", "a", "b", "owner", "balances", "values", "users", "byName", "greeting", "fixedArr", "data"}, si.Variables())
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		path     string
+		expected interface{}
+	}{
+		{"total", big.NewInt(1000)},
+		{"a", big.NewInt(7)},
+		{"b", true},
+		{"owner", owner},
+		{"balances[" + owner.Hex() + "]", big.NewInt(500)},
+		{"balances[0x0000000000000000000000000000000000000001]", big.NewInt(0)},
+		{"values", []interface{}{big.NewInt(1), big.NewInt(2), big.NewInt(3)}},
+		{"values[2]", big.NewInt(3)},
+		{"users[0].id", big.NewInt(1)},
+		{"users[0].score", big.NewInt(99)},
+		{"users[0]", map[string]interface{}{"id": big.NewInt(1), "score": big.NewInt(99), "name": "alice"}},
+		{"byName[bob].name", longName},
+		{"byName[bob]", map[string]interface{}{"id": big.NewInt(2), "score": big.NewInt(50), "name": longName}},
+		{"greeting", "hello"},
+		{"fixedArr", []interface{}{big.NewInt(-1), big.NewInt(2), big.NewInt(-3)}},
+		{"fixedArr[2]", big.NewInt(-3)},
+		{"data", longData},
+	} {
+		v, err := si.Read(ctx, 1, tc.path)
+		require.NoError(err, tc.path)
+		// Compare formatted values as big integers with equal values may differ internally.
+		require.IsType(tc.expected, v, tc.path)
+		require.Equal(fmt.Sprint(tc.expected), fmt.Sprint(v), tc.path)
+	}
+
+	for _, path := range []string{
+		"unknown",
+		"balances",
+		"balances[bad]",
+		"values[3]",
+		"values[-1]",
+		"fixedArr[3]",
+		"users[0].unknown",
+		"total[0]",
+		"users[0",
+		"users[0]x",
+	} {
+		_, err := si.Read(ctx, 1, path)
+		require.Error(err, path)
+	}
+
+	// Reading arrays with more elements than allowed should fail.
+	si.SetMaxElements(5)
+	_, err = si.Read(ctx, 1, "values")
+	require.NoError(err, "values within the limit")
+	si.SetMaxElements(2)
+	_, err = si.Read(ctx, 1, "values")
+	require.Error(err, "values above the limit")
+	v, err := si.Read(ctx, 1, "values[2]")
+	require.NoError(err, "indexing should not be limited")
+	require.Equal("3", fmt.Sprint(v))
+
+	// Untrusted huge array lengths should be rejected without reading anything.
+	si.SetMaxElements(DefaultStorageMaxElements)
+	s.set(slotAt(ethCommon.Hash{}, 3), 0, ethCommon.BigToHash(new(big.Int).Lsh(big.NewInt(1), 63)).Bytes())
+	_, err = si.Read(ctx, 1, "values")
+	require.Error(err, "huge array length")
+}
+
+func TestParseStorageLayout(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []string{
+		`{`,
+		`{"storage": [{"label": "x", "slot": "a", "offset": 0, "type": "t_uint256"}], "types": {"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"}}}`,
+		`{"storage": [{"label": "x", "slot": "0", "offset": 32, "type": "t_uint256"}], "types": {"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"}}}`,
+		`{"storage": [{"label": "x", "slot": "0", "offset": 0, "type": "t_unknown"}], "types": {}}`,
+		`{"storage": [], "types": {"t_x": {"encoding": "inplace", "label": "x", "numberOfBytes": "0"}}}`,
+		`{"storage": [], "types": {"t_x": {"encoding": "mapping", "label": "x", "numberOfBytes": "32"}}}`,
+		`{"storage": [], "types": {"t_x": {"encoding": "dynamic_array", "label": "x", "numberOfBytes": "32"}}}`,
+		`{"storage": [], "types": {"t_x": {"encoding": "dynamic_array", "base": "t_y", "label": "x", "numberOfBytes": "32"}}}`,
+		`{"storage": [], "types": {"t_x": {"encoding": "unknown", "label": "x", "numberOfBytes": "32"}}}`,
+	} {
+		_, err := ParseStorageLayout([]byte(tc))
+		require.Error(err, tc)
+	}
+}
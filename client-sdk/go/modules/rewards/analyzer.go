@@ -0,0 +1,301 @@
+package rewards
+
+import (
+	"math/bits"
+	"sort"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// ForEpoch returns the per-entity reward amount for the given epoch based on the schedule.
+func (s *RewardSchedule) ForEpoch(epoch beacon.EpochTime) types.BaseUnits {
+	for _, step := range s.Steps {
+		if epoch < step.Until {
+			return step.Amount
+		}
+	}
+
+	// End of the schedule, default to no rewards.
+	return types.BaseUnits{}
+}
+
+// ParticipationThreshold returns the minimum number of good rounds an entity needs in an epoch
+// in order to be rewarded, given the maximum number of good rounds of any entity in that epoch.
+func (p *Parameters) ParticipationThreshold(maxGoodRounds uint64) uint64 {
+	if p.ParticipationThresholdDenominator == 0 {
+		return 0
+	}
+	if hi, _ := bits.Mul64(p.ParticipationThresholdNumerator, maxGoodRounds); hi != 0 {
+		// Same as the runtime, divide first in case of overflow.
+		hi, lo := bits.Mul64(maxGoodRounds/p.ParticipationThresholdDenominator, p.ParticipationThresholdNumerator)
+		if hi != 0 {
+			return ^uint64(0)
+		}
+		return lo
+	}
+	return p.ParticipationThresholdNumerator * maxGoodRounds / p.ParticipationThresholdDenominator
+}
+
+// RewardStatus is the reward outcome for an entity in an epoch.
+type RewardStatus string
+
+const (
+	// RewardStatusRewarded means that the entity has been rewarded.
+	RewardStatusRewarded = RewardStatus("rewarded")
+	// RewardStatusBelowThreshold means that the entity did not reach the participation threshold.
+	RewardStatusBelowThreshold = RewardStatus("below_threshold")
+	// RewardStatusForbidden means that the entity was forbidden from receiving rewards for the
+	// epoch because it negatively contributed to at least one round.
+	RewardStatusForbidden = RewardStatus("forbidden")
+	// RewardStatusNoSchedule means that the reward schedule specifies no rewards for the epoch.
+	RewardStatusNoSchedule = RewardStatus("no_schedule")
+	// RewardStatusNotDisbursed means that the entity was eligible for a reward, but no reward was
+	// disbursed, e.g. due to the reward pool having insufficient balance.
+	RewardStatusNotDisbursed = RewardStatus("not_disbursed")
+)
+
+// EntityReward is the reward accounting for a single entity in an epoch.
+type EntityReward struct {
+	// Entity is the entity public key.
+	Entity signature.PublicKey `json:"entity"`
+	// Address is the entity address that receives the rewards.
+	Address types.Address `json:"address"`
+
+	// GoodRounds is the number of rounds the entity positively contributed to.
+	GoodRounds uint64 `json:"good_rounds"`
+	// BadRounds is the number of rounds the entity negatively contributed to.
+	BadRounds uint64 `json:"bad_rounds"`
+
+	// Status is the reward outcome.
+	Status RewardStatus `json:"status"`
+	// Amount is the reward amount that the entity is entitled to.
+	Amount types.BaseUnits `json:"amount"`
+	// Disbursed is the reward amount that has been observed to be disbursed to the entity. It is
+	// only set in case disbursements have been observed for the epoch.
+	Disbursed *types.BaseUnits `json:"disbursed,omitempty"`
+}
+
+// EpochReward is the reward accounting for an epoch.
+type EpochReward struct {
+	// Epoch is the epoch.
+	Epoch beacon.EpochTime `json:"epoch"`
+	// Rounds is the number of processed round results in the epoch.
+	Rounds uint64 `json:"rounds"`
+	// RewardAmount is the per-entity reward amount for the epoch as specified by the schedule.
+	RewardAmount types.BaseUnits `json:"reward_amount"`
+	// MaxGoodRounds is the maximum number of good rounds of any entity in the epoch.
+	MaxGoodRounds uint64 `json:"max_good_rounds"`
+	// Threshold is the number of good rounds required to be rewarded.
+	Threshold uint64 `json:"threshold"`
+	// DisbursementObserved is true in case the disbursement of rewards for the epoch has been
+	// observed.
+	DisbursementObserved bool `json:"disbursement_observed"`
+
+	// Entities is the per-entity reward accounting, sorted by address.
+	Entities []*EntityReward `json:"entities"`
+}
+
+type entityParticipation struct {
+	good      uint64
+	bad       uint64
+	disbursed *types.BaseUnits
+}
+
+// status returns the reward outcome for the entity given the per-entity reward amount and the
+// participation threshold of the epoch, without taking disbursements into account.
+func (part *entityParticipation) status(amount types.BaseUnits, threshold uint64) RewardStatus {
+	switch {
+	case part.bad > 0:
+		return RewardStatusForbidden
+	case part.good < threshold:
+		return RewardStatusBelowThreshold
+	case amount.Amount.IsZero():
+		return RewardStatusNoSchedule
+	default:
+		return RewardStatusRewarded
+	}
+}
+
+type epochParticipation struct {
+	rounds   uint64
+	entities map[signature.PublicKey]*entityParticipation
+	observed bool
+
+	// params are the rewards module parameters in effect for the epoch.
+	params *Parameters
+}
+
+// summary returns the per-entity reward amount, the maximum number of good rounds of any entity
+// and the participation threshold for the epoch.
+func (ep *epochParticipation) summary(epoch beacon.EpochTime) (types.BaseUnits, uint64, uint64) {
+	var maxGoodRounds uint64
+	for _, part := range ep.entities {
+		// Entities that have been forbidden from rewards count as zero.
+		if part.bad == 0 && part.good > maxGoodRounds {
+			maxGoodRounds = part.good
+		}
+	}
+	return ep.params.Schedule.ForEpoch(epoch), maxGoodRounds, ep.params.ParticipationThreshold(maxGoodRounds)
+}
+
+// Analyzer reconstructs reward accounting from runtime round results in the same way as the
+// rewards module does.
+//
+// Round results must be added in order. The results of each round are attributed to the epoch
+// in which they are processed by the runtime.
+type Analyzer struct {
+	params *Parameters
+
+	epochs map[beacon.EpochTime]*epochParticipation
+}
+
+// NewAnalyzer creates a new rewards analyzer for the given rewards module parameters.
+func NewAnalyzer(params *Parameters) *Analyzer {
+	return &Analyzer{
+		params: params,
+		epochs: make(map[beacon.EpochTime]*epochParticipation),
+	}
+}
+
+// SetParameters updates the rewards module parameters, e.g. after a runtime upgrade. The
+// parameters apply to the epochs of round results added afterwards.
+func (a *Analyzer) SetParameters(params *Parameters) {
+	a.params = params
+}
+
+func (a *Analyzer) epoch(epoch beacon.EpochTime) *epochParticipation {
+	ep, ok := a.epochs[epoch]
+	if !ok {
+		ep = &epochParticipation{
+			entities: make(map[signature.PublicKey]*entityParticipation),
+		}
+		a.epochs[epoch] = ep
+	}
+	ep.params = a.params
+	return ep
+}
+
+func (ep *epochParticipation) entity(id signature.PublicKey) *entityParticipation {
+	e, ok := ep.entities[id]
+	if !ok {
+		e = &entityParticipation{}
+		ep.entities[id] = e
+	}
+	return e
+}
+
+// AddRoundResults adds the round results processed by the runtime in the given epoch.
+func (a *Analyzer) AddRoundResults(epoch beacon.EpochTime, results *roothash.RoundResults) {
+	ep := a.epoch(epoch)
+	ep.rounds++
+	for _, id := range results.GoodComputeEntities {
+		ep.entity(id).good++
+	}
+	for _, id := range results.BadComputeEntities {
+		ep.entity(id).bad++
+	}
+}
+
+// AddDisbursements adds the transfers performed in the first round of the given epoch, when the
+// rewards for all previous epochs are disbursed. Transfers not originating from the reward pool
+// are ignored.
+//
+// Transfers are only attributed to entities that are entitled to a reward, going through the
+// pending epochs in the same order as the runtime does.
+func (a *Analyzer) AddDisbursements(epoch beacon.EpochTime, transfers []*accounts.TransferEvent) {
+	var pending []beacon.EpochTime
+	for e, ep := range a.epochs {
+		if e < epoch && !ep.observed {
+			pending = append(pending, e)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i] < pending[j] })
+
+	// The runtime performs at most one transfer per rewarded entity for each disbursed epoch,
+	// in order.
+	used := make([]bool, len(transfers))
+	for _, e := range pending {
+		ep := a.epochs[e]
+		ep.observed = true
+
+		amount, _, threshold := ep.summary(e)
+		if amount.Amount.IsZero() {
+			// Nothing is disbursed for epochs without a scheduled reward.
+			continue
+		}
+		for id, part := range ep.entities {
+			if part.status(amount, threshold) != RewardStatusRewarded {
+				continue
+			}
+
+			addr := types.NewAddressFromConsensusPublicKey(id)
+			for i, tr := range transfers {
+				if used[i] || !tr.From.Equal(RewardPoolAddress) || !tr.To.Equal(addr) || tr.Amount.Denomination != amount.Denomination {
+					continue
+				}
+				used[i] = true
+				disbursed := tr.Amount
+				part.disbursed = &disbursed
+				break
+			}
+		}
+	}
+}
+
+// Report returns the reward accounting for all epochs with added round results, sorted by
+// epoch.
+func (a *Analyzer) Report() []*EpochReward {
+	var epochs []beacon.EpochTime
+	for e := range a.epochs {
+		epochs = append(epochs, e)
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+
+	report := make([]*EpochReward, 0, len(epochs))
+	for _, e := range epochs {
+		ep := a.epochs[e]
+		er := &EpochReward{
+			Epoch:                e,
+			Rounds:               ep.rounds,
+			DisbursementObserved: ep.observed,
+		}
+		er.RewardAmount, er.MaxGoodRounds, er.Threshold = ep.summary(e)
+
+		for id, part := range ep.entities {
+			entity := &EntityReward{
+				Entity:     id,
+				Address:    types.NewAddressFromConsensusPublicKey(id),
+				GoodRounds: part.good,
+				BadRounds:  part.bad,
+				Status:     part.status(er.RewardAmount, er.Threshold),
+				Amount:     types.NewBaseUnits(*quantity.NewQuantity(), er.RewardAmount.Denomination),
+			}
+			if entity.Status == RewardStatusRewarded {
+				entity.Amount = er.RewardAmount
+			}
+			if ep.observed {
+				entity.Disbursed = part.disbursed
+				if entity.Disbursed == nil {
+					zero := types.NewBaseUnits(*quantity.NewQuantity(), er.RewardAmount.Denomination)
+					entity.Disbursed = &zero
+				}
+				if entity.Status == RewardStatusRewarded && entity.Disbursed.Amount.IsZero() {
+					entity.Status = RewardStatusNotDisbursed
+				}
+			}
+			er.Entities = append(er.Entities, entity)
+		}
+		sort.Slice(er.Entities, func(i, j int) bool {
+			return er.Entities[i].Address.String() < er.Entities[j].Address.String()
+		})
+
+		report = append(report, er)
+	}
+	return report
+}
@@ -0,0 +1,221 @@
+package rewards
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func native(amount uint64) types.BaseUnits {
+	return types.NewBaseUnits(*quantity.NewFromUint64(amount), types.NativeDenomination)
+}
+
+func TestRewardSchedule(t *testing.T) {
+	require := require.New(t)
+
+	schedule := RewardSchedule{
+		Steps: []RewardStep{
+			{Until: 5, Amount: native(3000)},
+			{Until: 10, Amount: native(2000)},
+			{Until: 15, Amount: native(1000)},
+		},
+	}
+	for _, tc := range []struct {
+		epoch    beacon.EpochTime
+		expected uint64
+	}{
+		{1, 3000},
+		{5, 2000},
+		{9, 2000},
+		{10, 1000},
+		{14, 1000},
+		{15, 0},
+		{100, 0},
+	} {
+		require.EqualValues(*quantity.NewFromUint64(tc.expected), schedule.ForEpoch(tc.epoch).Amount, "epoch %d", tc.epoch)
+	}
+}
+
+func TestParticipationThreshold(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		numerator   uint64
+		denominator uint64
+		max         uint64
+		expected    uint64
+	}{
+		{0, 0, 10, 0},
+		{3, 4, 10, 7},
+		{1, 2, ^uint64(0), ^uint64(0) / 2},
+		{3, 4, ^uint64(0), ^uint64(0) / 4 * 3},
+	} {
+		params := Parameters{
+			ParticipationThresholdNumerator:   tc.numerator,
+			ParticipationThresholdDenominator: tc.denominator,
+		}
+		require.EqualValues(tc.expected, params.ParticipationThreshold(tc.max), "%d/%d of %d", tc.numerator, tc.denominator, tc.max)
+	}
+}
+
+func TestAnalyzer(t *testing.T) {
+	require := require.New(t)
+
+	alice := memorySigner.NewTestSigner("rewards analyzer: alice").Public()
+	bob := memorySigner.NewTestSigner("rewards analyzer: bob").Public()
+	charlie := memorySigner.NewTestSigner("rewards analyzer: charlie").Public()
+	addr := types.NewAddressFromConsensusPublicKey
+
+	a := NewAnalyzer(&Parameters{
+		Schedule: RewardSchedule{
+			Steps: []RewardStep{{Until: 2, Amount: native(1000)}},
+		},
+		ParticipationThresholdNumerator:   3,
+		ParticipationThresholdDenominator: 4,
+	})
+
+	// Epoch 1: Alice participates in all rounds, Bob in half of them and Charlie causes a
+	// discrepancy.
+	for i := 0; i < 4; i++ {
+		results := &roothash.RoundResults{GoodComputeEntities: []signature.PublicKey{alice}}
+		if i%2 == 0 {
+			results.GoodComputeEntities = append(results.GoodComputeEntities, bob, charlie)
+		}
+		if i == 3 {
+			results.BadComputeEntities = []signature.PublicKey{charlie}
+		}
+		a.AddRoundResults(1, results)
+	}
+	// Epoch 2: Everyone participates, but the schedule has ended.
+	a.AddRoundResults(2, &roothash.RoundResults{GoodComputeEntities: []signature.PublicKey{alice, bob}})
+	// Rewards for epoch 1 are disbursed in the first round of epoch 2.
+	a.AddDisbursements(2, []*accounts.TransferEvent{
+		{From: RewardPoolAddress, To: addr(alice), Amount: native(1000)},
+		{From: addr(bob), To: addr(alice), Amount: native(5)},
+	})
+
+	report := a.Report()
+	require.Len(report, 2)
+
+	epoch1 := report[0]
+	require.EqualValues(1, epoch1.Epoch)
+	require.EqualValues(4, epoch1.Rounds)
+	require.EqualValues(4, epoch1.MaxGoodRounds)
+	require.EqualValues(3, epoch1.Threshold)
+	require.True(epoch1.DisbursementObserved)
+	require.Len(epoch1.Entities, 3)
+	byEntity := make(map[signature.PublicKey]*EntityReward)
+	for _, er := range epoch1.Entities {
+		byEntity[er.Entity] = er
+	}
+	require.Equal(RewardStatusRewarded, byEntity[alice].Status)
+	require.Equal(native(1000), byEntity[alice].Amount)
+	require.Equal(native(1000), *byEntity[alice].Disbursed)
+	require.Equal(RewardStatusBelowThreshold, byEntity[bob].Status)
+	require.EqualValues(2, byEntity[bob].GoodRounds)
+	require.True(byEntity[bob].Disbursed.Amount.IsZero())
+	require.Equal(RewardStatusForbidden, byEntity[charlie].Status)
+	require.EqualValues(1, byEntity[charlie].BadRounds)
+
+	epoch2 := report[1]
+	require.EqualValues(2, epoch2.Epoch)
+	require.False(epoch2.DisbursementObserved)
+	for _, er := range epoch2.Entities {
+		require.Equal(RewardStatusNoSchedule, er.Status)
+		require.Nil(er.Disbursed)
+	}
+
+	// Eligible entities that did not receive rewards should be reported.
+	a = NewAnalyzer(&Parameters{
+		Schedule:                          RewardSchedule{Steps: []RewardStep{{Until: 10, Amount: native(1000)}}},
+		ParticipationThresholdNumerator:   1,
+		ParticipationThresholdDenominator: 1,
+	})
+	a.AddRoundResults(1, &roothash.RoundResults{GoodComputeEntities: []signature.PublicKey{alice}})
+	a.AddDisbursements(2, nil)
+	report = a.Report()
+	require.Len(report, 1)
+	require.Equal(RewardStatusNotDisbursed, report[0].Entities[0].Status)
+}
+
+func TestAnalyzerMultipleEpochs(t *testing.T) {
+	require := require.New(t)
+
+	alice := memorySigner.NewTestSigner("rewards analyzer: alice").Public()
+	bob := memorySigner.NewTestSigner("rewards analyzer: bob").Public()
+	addr := types.NewAddressFromConsensusPublicKey
+
+	a := NewAnalyzer(&Parameters{
+		Schedule:                          RewardSchedule{Steps: []RewardStep{{Until: 3, Amount: native(1000)}}},
+		ParticipationThresholdNumerator:   1,
+		ParticipationThresholdDenominator: 1,
+	})
+
+	// Epoch 1: Bob is below the threshold.
+	a.AddRoundResults(1, &roothash.RoundResults{GoodComputeEntities: []signature.PublicKey{alice, bob}})
+	a.AddRoundResults(1, &roothash.RoundResults{GoodComputeEntities: []signature.PublicKey{alice}})
+	// Epoch 2: Everyone is rewarded.
+	a.AddRoundResults(2, &roothash.RoundResults{GoodComputeEntities: []signature.PublicKey{alice, bob}})
+	// Rewards for epochs 1 and 2 are disbursed together in the first round of epoch 3. Bob's
+	// transfer belongs to epoch 2 as he was not rewarded in epoch 1.
+	a.AddDisbursements(3, []*accounts.TransferEvent{
+		{From: RewardPoolAddress, To: addr(alice), Amount: native(1000)},
+		{From: RewardPoolAddress, To: addr(alice), Amount: native(1000)},
+		{From: RewardPoolAddress, To: addr(bob), Amount: native(1000)},
+	})
+	// Epoch 3: The schedule has ended.
+	a.AddRoundResults(3, &roothash.RoundResults{GoodComputeEntities: []signature.PublicKey{alice}})
+	// Epoch 4: The parameters have been updated with a new schedule.
+	a.SetParameters(&Parameters{
+		Schedule: RewardSchedule{Steps: []RewardStep{{Until: 10, Amount: native(500)}}},
+	})
+	a.AddRoundResults(4, &roothash.RoundResults{GoodComputeEntities: []signature.PublicKey{alice}})
+	// Transfers from the reward pool in epochs without scheduled rewards are not disbursements.
+	a.AddDisbursements(4, []*accounts.TransferEvent{
+		{From: RewardPoolAddress, To: addr(alice), Amount: native(1000)},
+	})
+	a.AddDisbursements(5, []*accounts.TransferEvent{
+		{From: RewardPoolAddress, To: addr(alice), Amount: native(500)},
+	})
+
+	report := a.Report()
+	require.Len(report, 4)
+
+	for _, tc := range []struct {
+		epoch     beacon.EpochTime
+		entity    signature.PublicKey
+		status    RewardStatus
+		disbursed uint64
+	}{
+		{1, alice, RewardStatusRewarded, 1000},
+		{1, bob, RewardStatusBelowThreshold, 0},
+		{2, alice, RewardStatusRewarded, 1000},
+		{2, bob, RewardStatusRewarded, 1000},
+		{3, alice, RewardStatusNoSchedule, 0},
+		{4, alice, RewardStatusRewarded, 500},
+	} {
+		er := report[tc.epoch-1]
+		require.EqualValues(tc.epoch, er.Epoch)
+		require.True(er.DisbursementObserved, "epoch %d", tc.epoch)
+
+		var entity *EntityReward
+		for _, e := range er.Entities {
+			if e.Entity.Equal(tc.entity) {
+				entity = e
+			}
+		}
+		require.NotNil(entity, "epoch %d", tc.epoch)
+		require.Equal(tc.status, entity.Status, "epoch %d entity %s", tc.epoch, entity.Address)
+		require.Equal(native(tc.disbursed), *entity.Disbursed, "epoch %d entity %s", tc.epoch, entity.Address)
+	}
+	require.Equal(native(500), report[3].RewardAmount)
+}
@@ -0,0 +1,320 @@
+// Package mock implements a mock runtime client backed by in-memory state for use in tests.
+package mock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// QueryHandler handles a runtime query and returns a response that will be CBOR-encoded.
+type QueryHandler func(round uint64, args cbor.RawMessage) (interface{}, error)
+
+// SubmitHandler handles a submitted transaction and returns its result.
+type SubmitHandler func(tx *types.UnverifiedTransaction) (*types.CallResult, error)
+
+// RuntimeClient is a mock runtime client backed by in-memory state.
+//
+// Blocks are added via AddBlock, queries are served by handlers registered via SetQueryHandler and
+// submitted transactions are recorded and handled by the handler registered via SetSubmitHandler.
+type RuntimeClient struct {
+	l sync.Mutex
+
+	info    types.RuntimeInfo
+	blocks  []*block.Block
+	txs     map[uint64][]*client.TransactionWithResults
+	queries map[string]QueryHandler
+	submit  SubmitHandler
+
+	submitted []*types.UnverifiedTransaction
+
+	blockNotifier *pubsub.Broker
+}
+
+// NewRuntimeClient creates a new mock runtime client for the given runtime.
+//
+// The client starts with a genesis block at round zero.
+func NewRuntimeClient(runtimeID common.Namespace, chainContext signature.Context) *RuntimeClient {
+	rc := &RuntimeClient{
+		info: types.RuntimeInfo{
+			ID:           runtimeID,
+			ChainContext: chainContext,
+		},
+		txs:           make(map[uint64][]*client.TransactionWithResults),
+		queries:       make(map[string]QueryHandler),
+		blockNotifier: pubsub.NewBroker(false),
+	}
+	rc.blocks = append(rc.blocks, block.NewGenesisBlock(runtimeID, 0))
+	return rc
+}
+
+// AddBlock adds a new block containing the given transactions and returns it.
+//
+// Block watchers are notified of the new block. The consensus height of the block is reported
+// to be equal to its round.
+func (rc *RuntimeClient) AddBlock(txs ...*client.TransactionWithResults) *block.Block {
+	blk := rc.addBlock(txs)
+	rc.blockNotifier.Broadcast(&roothash.AnnotatedBlock{
+		Height: int64(blk.Header.Round),
+		Block:  blk,
+	})
+	return blk
+}
+
+func (rc *RuntimeClient) addBlock(txs []*client.TransactionWithResults) *block.Block {
+	rc.l.Lock()
+	defer rc.l.Unlock()
+
+	prev := rc.blocks[len(rc.blocks)-1]
+	var blk block.Block
+	blk.Header.Version = prev.Header.Version
+	blk.Header.Namespace = prev.Header.Namespace
+	blk.Header.Round = prev.Header.Round + 1
+	blk.Header.Timestamp = prev.Header.Timestamp + 1
+	blk.Header.HeaderType = block.Normal
+	blk.Header.PreviousHash = prev.Header.EncodedHash()
+
+	rc.blocks = append(rc.blocks, &blk)
+	rc.txs[blk.Header.Round] = txs
+	return &blk
+}
+
+// SetQueryHandler configures the handler for the given query method.
+func (rc *RuntimeClient) SetQueryHandler(method string, handler QueryHandler) {
+	rc.l.Lock()
+	defer rc.l.Unlock()
+
+	rc.queries[method] = handler
+}
+
+// SetSubmitHandler configures the handler for submitted transactions.
+//
+// In case no handler is configured, submitted transactions succeed with an empty result.
+func (rc *RuntimeClient) SetSubmitHandler(handler SubmitHandler) {
+	rc.l.Lock()
+	defer rc.l.Unlock()
+
+	rc.submit = handler
+}
+
+// Submitted returns all transactions that have been submitted so far.
+func (rc *RuntimeClient) Submitted() []*types.UnverifiedTransaction {
+	rc.l.Lock()
+	defer rc.l.Unlock()
+
+	return append([]*types.UnverifiedTransaction{}, rc.submitted...)
+}
+
+func (rc *RuntimeClient) resolveRound(round uint64) (uint64, error) {
+	latest := rc.blocks[len(rc.blocks)-1].Header.Round
+	switch {
+	case round == client.RoundLatest:
+		return latest, nil
+	case round > latest:
+		return 0, fmt.Errorf("mock: round %d not found", round)
+	default:
+		return round, nil
+	}
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) GetInfo(ctx context.Context) (*types.RuntimeInfo, error) {
+	info := rc.info
+	return &info, nil
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) SubmitTxRaw(ctx context.Context, tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+	rc.l.Lock()
+	defer rc.l.Unlock()
+
+	rc.submitted = append(rc.submitted, tx)
+	if rc.submit == nil {
+		return &types.CallResult{Ok: cbor.Marshal(nil)}, nil
+	}
+	return rc.submit(tx)
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) SubmitTxRawMeta(ctx context.Context, tx *types.UnverifiedTransaction) (*client.SubmitTxRawMeta, error) {
+	result, err := rc.SubmitTxRaw(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	blk := rc.AddBlock(&client.TransactionWithResults{Tx: *tx, Result: *result})
+	return &client.SubmitTxRawMeta{
+		TransactionMeta: client.TransactionMeta{Round: blk.Header.Round},
+		Result:          *result,
+	}, nil
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) SubmitTx(ctx context.Context, tx *types.UnverifiedTransaction) (cbor.RawMessage, error) {
+	meta, err := rc.SubmitTxMeta(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return meta.Result, nil
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) SubmitTxMeta(ctx context.Context, tx *types.UnverifiedTransaction) (*client.SubmitTxMeta, error) {
+	meta, err := rc.SubmitTxRawMeta(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case meta.Result.IsUnknown():
+		return nil, fmt.Errorf("mock: got unknown result: %X", meta.Result.Unknown)
+	case meta.Result.IsSuccess():
+		return &client.SubmitTxMeta{TransactionMeta: meta.TransactionMeta, Result: meta.Result.Ok}, nil
+	default:
+		return nil, meta.Result.Failed
+	}
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) SubmitTxNoWait(ctx context.Context, tx *types.UnverifiedTransaction) error {
+	_, err := rc.SubmitTxRawMeta(ctx, tx)
+	return err
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) GetGenesisBlock(ctx context.Context) (*block.Block, error) {
+	rc.l.Lock()
+	defer rc.l.Unlock()
+
+	return rc.blocks[0], nil
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) GetBlock(ctx context.Context, round uint64) (*block.Block, error) {
+	rc.l.Lock()
+	defer rc.l.Unlock()
+
+	round, err := rc.resolveRound(round)
+	if err != nil {
+		return nil, err
+	}
+	return rc.blocks[round], nil
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) GetLastRetainedBlock(ctx context.Context) (*block.Block, error) {
+	return rc.GetGenesisBlock(ctx)
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) GetTransactions(ctx context.Context, round uint64) ([]*types.UnverifiedTransaction, error) {
+	txs, err := rc.GetTransactionsWithResults(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*types.UnverifiedTransaction, 0, len(txs))
+	for _, tx := range txs {
+		result = append(result, &tx.Tx)
+	}
+	return result, nil
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) GetTransactionsWithResults(ctx context.Context, round uint64) ([]*client.TransactionWithResults, error) {
+	rc.l.Lock()
+	defer rc.l.Unlock()
+
+	round, err := rc.resolveRound(round)
+	if err != nil {
+		return nil, err
+	}
+	return rc.txs[round], nil
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) GetEventsRaw(ctx context.Context, round uint64) ([]*types.Event, error) {
+	txs, err := rc.GetTransactionsWithResults(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	var evs []*types.Event
+	for _, tx := range txs {
+		evs = append(evs, tx.Events...)
+	}
+	return evs, nil
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) GetEvents(ctx context.Context, round uint64, decoders []client.EventDecoder, includeUndecoded bool) ([]client.DecodedEvent, error) {
+	rawEvs, err := rc.GetEventsRaw(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+
+	evs := make([]client.DecodedEvent, 0)
+OUTER:
+	for _, ev := range rawEvs {
+		for _, decoder := range decoders {
+			decoded, err := decoder.DecodeEvent(ev)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode event: %w", err)
+			}
+			if decoded != nil {
+				evs = append(evs, decoded...)
+				continue OUTER
+			}
+		}
+		if includeUndecoded {
+			evs = append(evs, ev)
+		}
+	}
+	return evs, nil
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) WatchBlocks(ctx context.Context) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	ch := make(chan *roothash.AnnotatedBlock)
+	sub := rc.blockNotifier.Subscribe()
+	sub.Unwrap(ch)
+	return ch, sub, nil
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) WatchEvents(ctx context.Context, decoders []client.EventDecoder, includeUndecoded bool) (<-chan *client.BlockEvents, error) {
+	return nil, fmt.Errorf("mock: watching events is not supported")
+}
+
+// Implements client.RuntimeClient.
+func (rc *RuntimeClient) Query(ctx context.Context, round uint64, method string, args, rsp interface{}) error {
+	rc.l.Lock()
+	handler, ok := rc.queries[method]
+	round, err := rc.resolveRound(round)
+	rc.l.Unlock()
+	if !ok {
+		return fmt.Errorf("mock: unsupported query method '%s'", method)
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := handler(round, cbor.Marshal(args))
+	if err != nil {
+		return err
+	}
+	if rsp != nil {
+		if err = cbor.Unmarshal(cbor.Marshal(result), rsp); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+	return nil
+}
+
+var _ client.RuntimeClient = (*RuntimeClient)(nil)